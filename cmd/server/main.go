@@ -5,11 +5,16 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata" // Embed timezone data for restaurant schedules
 
 	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/events"
 	"eatright-backend/internal/app/handlers"
 	"eatright-backend/internal/app/middlewares"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/payments"
 	"eatright-backend/internal/app/repositories"
+	"eatright-backend/internal/app/scheduler"
 	"eatright-backend/internal/app/services"
 
 	"github.com/gofiber/fiber/v2"
@@ -59,6 +64,24 @@ func main() {
 	// 	&models.Restaurant{},
 	// 	&models.Listing{},
 	// 	&models.Order{},
+	// 	&models.RefreshToken{},
+	// 	&models.RevokedToken{},
+	// 	&models.PartnerApplication{},
+	// 	&models.RestaurantMember{},
+	// 	&models.RestaurantInvitation{},
+	// 	&models.OpeningHours{},
+	// 	&models.RestaurantClosure{},
+	// 	&models.ListingTemplate{},
+	// 	&models.ListingOccurrenceOverride{},
+	// 	&models.OrderStatusEvent{},
+	// 	&models.OrderItem{},
+	// 	&models.CartItem{},
+	// 	&models.IdempotencyKey{},
+	// 	&models.StockReservation{},
+	// 	&models.WaitlistEntry{},
+	// 	&models.RestaurantSubscription{},
+	// 	&models.Payment{},
+	// 	&models.Refund{},
 	// )
 	// if err != nil {
 	// 	log.Fatalf("❌ Failed to migrate database: %v", err)
@@ -68,17 +91,58 @@ func main() {
 	userRepo := repositories.NewUserRepository(db)
 	restaurantRepo := repositories.NewRestaurantRepository(db)
 	listingRepo := repositories.NewListingRepository(db)
-	orderRepo := repositories.NewOrderRepository(db, listingRepo)
+	reservationRepo := repositories.NewReservationRepository(db, listingRepo)
+	refundRepo := repositories.NewRefundRepository(db)
+	orderRepo := repositories.NewOrderRepository(db, listingRepo, reservationRepo, refundRepo)
+	tokenRepo := repositories.NewTokenRepository(db)
+	applicationRepo := repositories.NewPartnerApplicationRepository(db, tokenRepo)
+	membershipRepo := repositories.NewMembershipRepository(db)
+	templateRepo := repositories.NewListingTemplateRepository(db)
+	impactRepo := repositories.NewImpactRepository(db)
+	cartRepo := repositories.NewCartRepository(db)
+	idempotencyRepo := repositories.NewIdempotencyRepository(db)
+	waitlistRepo := repositories.NewWaitlistRepository(db, reservationRepo)
+	paymentRepo := repositories.NewPaymentRepository(db)
+
+	// Real-time event bus, shared between instances through Postgres when enabled
+	var eventBus events.Bus = events.NewLocalBus()
+	if cfg.Events.PgNotify {
+		pgBus := events.NewPostgresBus(db, cfg.Events.ListenURL)
+		pgBus.Start()
+		eventBus = pgBus
+	}
+
+	// Payment provider; orders are taken without payment when none is configured
+	var paymentProvider payments.PaymentProvider
+	var fakePayments *payments.FakeProvider
+	if cfg.Payments.Provider == "fake" {
+		fakePayments = payments.NewFakeProvider(cfg.Payments.WebhookSecret)
+		paymentProvider = fakePayments
+		log.Println("⚠️  Using the fake payment provider, no money is taken")
+	}
 
 	// Initialize services
-	authService, err := services.NewAuthService(userRepo, cfg)
+	authService, err := services.NewAuthService(userRepo, tokenRepo, cfg)
 	if err != nil {
 		log.Fatalf("❌ Failed to create auth service: %v", err)
 	}
-	userService := services.NewUserService(userRepo)
-	restaurantService := services.NewRestaurantService(restaurantRepo, userRepo)
-	listingService := services.NewListingService(listingRepo, restaurantRepo)
-	orderService := services.NewOrderService(orderRepo, listingRepo, restaurantRepo)
+	restaurantAuthorizer := services.NewRestaurantAuthorizer(membershipRepo)
+	userService := services.NewUserService(userRepo, orderRepo)
+	impactService := services.NewImpactService(impactRepo, restaurantRepo, cfg)
+	restaurantService := services.NewRestaurantService(restaurantRepo, userRepo, orderRepo, impactService, restaurantAuthorizer)
+	listingService := services.NewListingService(listingRepo, restaurantRepo, restaurantAuthorizer, eventBus)
+	paymentService := services.NewPaymentService(paymentRepo, orderRepo, refundRepo, paymentProvider, eventBus, cfg)
+	orderService := services.NewOrderService(orderRepo, listingRepo, cartRepo, reservationRepo, restaurantRepo, paymentService, restaurantAuthorizer, eventBus, cfg)
+	membershipService := services.NewMembershipService(membershipRepo, userRepo, restaurantAuthorizer)
+	templateService := services.NewListingTemplateService(templateRepo, listingRepo, restaurantRepo, restaurantAuthorizer)
+	applicationService := services.NewPartnerApplicationService(applicationRepo, userRepo, cfg)
+	adminService := services.NewAdminService(userRepo, restaurantRepo, listingRepo, orderRepo, paymentService, eventBus)
+	eventService := services.NewEventService(eventBus, restaurantAuthorizer)
+	cartService := services.NewCartService(cartRepo, listingRepo, reservationRepo)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo, cfg)
+	reservationService := services.NewReservationService(reservationRepo, listingRepo, eventBus, cfg)
+	waitlistService := services.NewWaitlistService(waitlistRepo, listingRepo, restaurantRepo)
+	refundService := services.NewRefundService(refundRepo, orderRepo, paymentService, restaurantAuthorizer, cfg)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
@@ -86,6 +150,34 @@ func main() {
 	restaurantHandler := handlers.NewRestaurantHandler(restaurantService)
 	listingHandler := handlers.NewListingHandler(listingService)
 	orderHandler := handlers.NewOrderHandler(orderService)
+	applicationHandler := handlers.NewPartnerApplicationHandler(applicationService)
+	adminHandler := handlers.NewAdminHandler(adminService)
+	membershipHandler := handlers.NewMembershipHandler(membershipService)
+	templateHandler := handlers.NewListingTemplateHandler(templateService)
+	impactHandler := handlers.NewImpactHandler(impactService)
+	eventHandler := handlers.NewEventHandler(eventService, authService, cfg)
+	cartHandler := handlers.NewCartHandler(cartService)
+	reservationHandler := handlers.NewReservationHandler(reservationService)
+	waitlistHandler := handlers.NewWaitlistHandler(waitlistService)
+	paymentHandler := handlers.NewPaymentHandler(paymentService, fakePayments)
+	refundHandler := handlers.NewRefundHandler(refundService)
+
+	// Start background jobs
+	jobScheduler := scheduler.New(db)
+	jobScheduler.Register(scheduler.NewListingExpiryJob(listingRepo), cfg.Scheduler.Interval)
+	jobScheduler.Register(scheduler.NewNoShowJob(orderRepo, eventBus, cfg.Scheduler.NoShowGrace), cfg.Scheduler.Interval)
+	jobScheduler.Register(scheduler.NewListingTemplateJob(templateRepo, listingRepo), cfg.Scheduler.Interval)
+	jobScheduler.Register(scheduler.NewIdempotencyPurgeJob(idempotencyRepo), cfg.Scheduler.Interval)
+	jobScheduler.Register(scheduler.NewTokenPurgeJob(tokenRepo), cfg.Scheduler.Interval)
+	jobScheduler.Register(scheduler.NewReservationExpiryJob(reservationRepo, eventBus), cfg.Scheduler.Interval)
+	jobScheduler.Register(scheduler.NewWaitlistJob(waitlistRepo, eventBus, cfg.Orders.WaitlistHold, cfg.Orders.MaxReservations), cfg.Scheduler.WaitlistInterval)
+	if paymentService.Enabled() {
+		jobScheduler.Register(scheduler.NewPaymentExpiryJob(orderRepo, eventBus, cfg.Payments.Timeout), cfg.Scheduler.Interval)
+		jobScheduler.Register(scheduler.NewRefundJob(refundRepo, paymentService, cfg.Scheduler.Interval, cfg.Payments.RefundMaxAttempts), cfg.Scheduler.Interval)
+	}
+	if cfg.Scheduler.Enabled {
+		jobScheduler.Start()
+	}
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
@@ -98,8 +190,8 @@ func main() {
 	app.Use(middlewares.Logger())
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "*", // Changed to * for easier debugging, change back to cfg.CORS.AllowedOrigins for production
-		AllowMethods:     "GET,POST,PATCH,DELETE,OPTIONS",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
+		AllowMethods:     "GET,POST,PUT,PATCH,DELETE,OPTIONS",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, Idempotency-Key",
 		AllowCredentials: true,
 	}))
 
@@ -119,57 +211,166 @@ func main() {
 
 	// API routes
 	api := app.Group("/api")
+	authMiddleware := middlewares.AuthMiddleware(cfg, authService)
+
+	// Retried order creation replays the first response instead of ordering twice
+	idempotency := middlewares.Idempotency(idempotencyService)
 
-	// Auth routes (public)
+	// Auth routes
 	authRoutes := api.Group("/auth")
-	authRoutes.Post("/verify", authHandler.VerifyToken)
+	authRoutes.Post("/verify", authHandler.VerifyToken)   // Public
+	authRoutes.Post("/refresh", authHandler.RefreshToken) // Public
+	authRoutes.Post("/logout", authMiddleware, authHandler.Logout)
+	authRoutes.Post("/logout-all", authMiddleware, authHandler.LogoutAll)
 
 	// User routes (protected)
-	userRoutes := api.Group("/users", middlewares.AuthMiddleware(cfg))
+	userRoutes := api.Group("/users", authMiddleware)
 	userRoutes.Get("/me", userHandler.GetMe)
 
 	// Restaurant routes
 	restaurantRoutes := api.Group("/restaurants")
-	restaurantRoutes.Get("/", restaurantHandler.GetRestaurants)       // Public
-	restaurantRoutes.Get("/:id", restaurantHandler.GetRestaurantByID) // Public
-	restaurantRoutes.Post("/",                                        // Protected, restaurant role only
-		middlewares.AuthMiddleware(cfg),
-		middlewares.RestaurantOnly(),
+	restaurantRoutes.Get("/", restaurantHandler.GetRestaurants)                         // Public
+	restaurantRoutes.Get("/mine", authMiddleware, restaurantHandler.GetMyRestaurants)   // Protected, before /:id
+	restaurantRoutes.Get("/:id", restaurantHandler.GetRestaurantByID)                   // Public
+	restaurantRoutes.Patch("/:id", authMiddleware, restaurantHandler.UpdateRestaurant)  // Protected, restaurant permission checked by service
+	restaurantRoutes.Delete("/:id", authMiddleware, restaurantHandler.DeleteRestaurant) // Protected, owner only
+	restaurantRoutes.Post("/",                                                          // Protected, restaurant role only
+		authMiddleware,
+		middlewares.RequireRole(models.RoleRestaurant),
 		restaurantHandler.CreateRestaurant,
 	)
 
+	// Listing stock stream (public, registered before /listings/:id)
+	api.Get("/listings/stream", eventHandler.StreamListingStock)
+
 	// Listing routes
 	listingRoutes := api.Group("/listings")
 	listingRoutes.Get("/", listingHandler.GetListings)       // Public
 	listingRoutes.Get("/:id", listingHandler.GetListingByID) // Public
 
-	// Create listing (protected, restaurant role only)
-	api.Post("/restaurants/:id/listings",
-		middlewares.AuthMiddleware(cfg),
-		middlewares.RestaurantOnly(),
-		listingHandler.CreateListing,
-	)
-
-	// Update listing stock and status (protected, restaurant role only)
-	listingRoutes.Patch("/:id/stock",
-		middlewares.AuthMiddleware(cfg),
-		middlewares.RestaurantOnly(),
-		listingHandler.UpdateStock,
-	)
-	listingRoutes.Patch("/:id/status",
-		middlewares.AuthMiddleware(cfg),
-		middlewares.RestaurantOnly(),
-		listingHandler.UpdateStatus,
-	)
+	// Create listing (protected, restaurant permission checked by service)
+	api.Post("/restaurants/:id/listings", authMiddleware, listingHandler.CreateListing)
+
+	// Impact routes
+	restaurantRoutes.Get("/:id/impact", impactHandler.GetRestaurantImpact) // Public
+	api.Get("/impact", impactHandler.GetPlatformImpact)                    // Public
+	api.Get("/impact/me", authMiddleware, impactHandler.GetMyImpact)
+
+	// Restaurant savings including revenue (protected, restaurant permission or admin checked by service)
+	restaurantRoutes.Get("/:id/savings", authMiddleware, restaurantHandler.GetRestaurantSavings)
+
+	// Listing template routes (protected, restaurant permission checked by service)
+	restaurantRoutes.Post("/:id/listing-templates", authMiddleware, templateHandler.CreateTemplate)
+	restaurantRoutes.Get("/:id/listing-templates", authMiddleware, templateHandler.GetTemplates)
+	templateRoutes := api.Group("/listing-templates", authMiddleware)
+	templateRoutes.Patch("/:id", templateHandler.UpdateTemplate)
+	templateRoutes.Delete("/:id", templateHandler.DeleteTemplate)
+	templateRoutes.Put("/:id/occurrences/:date", templateHandler.SetOccurrenceOverride)
+	templateRoutes.Delete("/:id/occurrences/:date", templateHandler.DeleteOccurrenceOverride)
+
+	// Restaurant schedule routes (protected, restaurant permission checked by service)
+	restaurantRoutes.Put("/:id/hours", authMiddleware, restaurantHandler.SetOpeningHours)
+	restaurantRoutes.Post("/:id/closures", authMiddleware, restaurantHandler.CreateClosure)
+	restaurantRoutes.Delete("/:id/closures/:closureId", authMiddleware, restaurantHandler.DeleteClosure)
+
+	// Restaurant membership routes (protected, restaurant permission checked by service)
+	restaurantRoutes.Get("/:id/members", authMiddleware, membershipHandler.GetMembers)
+	restaurantRoutes.Patch("/:id/members/:userId", authMiddleware, membershipHandler.UpdateMemberRole)
+	restaurantRoutes.Delete("/:id/members/:userId", authMiddleware, membershipHandler.RemoveMember)
+	restaurantRoutes.Post("/:id/invitations", authMiddleware, membershipHandler.InviteMember)
+	restaurantRoutes.Get("/:id/invitations", authMiddleware, membershipHandler.GetRestaurantInvitations)
+	restaurantRoutes.Delete("/:id/invitations/:invitationId", authMiddleware, membershipHandler.RevokeInvitation)
+
+	// Invitation routes for the invited user (protected)
+	invitationRoutes := api.Group("/invitations", authMiddleware)
+	invitationRoutes.Get("/me", membershipHandler.GetMyInvitations)
+	invitationRoutes.Post("/:id/accept", membershipHandler.AcceptInvitation)
+	invitationRoutes.Post("/:id/decline", membershipHandler.DeclineInvitation)
+
+	// Update listing stock and status (protected, restaurant permission checked by service)
+	listingRoutes.Patch("/:id/stock", authMiddleware, listingHandler.UpdateStock)
+	listingRoutes.Patch("/:id/status", authMiddleware, listingHandler.UpdateStatus)
+	listingRoutes.Put("/:id/price-schedule", authMiddleware, listingHandler.SetPriceSchedule)
+	listingRoutes.Put("/:id/max-per-customer", authMiddleware, listingHandler.SetMaxPerCustomer)
+
+	// Restaurant order queue (protected, restaurant permission checked by service)
+	restaurantRoutes.Get("/:id/orders", authMiddleware, orderHandler.GetRestaurantOrders)
+	restaurantRoutes.Get("/:id/orders/board", authMiddleware, orderHandler.GetOrderBoard)
+	restaurantRoutes.Get("/:id/orders/stream", authMiddleware, eventHandler.StreamRestaurantOrders)
+	restaurantRoutes.Get("/:id/refunds", authMiddleware, refundHandler.GetRestaurantRefunds)
 
 	// Order routes (protected)
-	orderRoutes := api.Group("/orders", middlewares.AuthMiddleware(cfg))
-	orderRoutes.Post("/", orderHandler.CreateOrder)
+	orderRoutes := api.Group("/orders", authMiddleware)
+	orderRoutes.Post("/", idempotency, orderHandler.CreateOrder)
 	orderRoutes.Get("/me", orderHandler.GetMyOrders)
-	orderRoutes.Patch("/:id/status",
-		middlewares.RestaurantOnly(),
-		orderHandler.UpdateOrderStatus,
-	)
+	orderRoutes.Get("/me/stream", eventHandler.StreamMyOrders)
+	orderRoutes.Get("/:id", orderHandler.GetOrder)
+	orderRoutes.Patch("/:id/status", orderHandler.UpdateOrderStatus)
+	orderRoutes.Post("/:id/cancel", orderHandler.CancelOrder)
+	orderRoutes.Post("/:id/pickup", orderHandler.VerifyPickup)
+	orderRoutes.Post("/:id/refunds", refundHandler.RequestRefund)
+
+	// Refund decisions (protected, restaurant permission checked by service)
+	refundRoutes := api.Group("/refunds", authMiddleware)
+	refundRoutes.Post("/:id/approve", refundHandler.ApproveRefund)
+	refundRoutes.Post("/:id/reject", refundHandler.RejectRefund)
+
+	// Cart routes (protected)
+	cartRoutes := api.Group("/cart", authMiddleware)
+	cartRoutes.Get("/", cartHandler.GetCart)
+	cartRoutes.Delete("/", cartHandler.Clear)
+	cartRoutes.Put("/items/:listingId", cartHandler.SetItem)
+	cartRoutes.Delete("/items/:listingId", cartHandler.RemoveItem)
+	cartRoutes.Post("/checkout", idempotency, orderHandler.Checkout)
+
+	// Stock reservation routes (protected)
+	listingRoutes.Post("/:id/reservations", authMiddleware, reservationHandler.Reserve)
+	reservationRoutes := api.Group("/reservations", authMiddleware)
+	reservationRoutes.Get("/me", reservationHandler.GetMyReservations)
+	reservationRoutes.Delete("/:id", reservationHandler.Release)
+
+	// Waitlist and back-in-stock subscription routes (protected)
+	listingRoutes.Post("/:id/waitlist", authMiddleware, waitlistHandler.JoinWaitlist)
+	listingRoutes.Delete("/:id/waitlist", authMiddleware, waitlistHandler.LeaveWaitlist)
+	restaurantRoutes.Post("/:id/subscription", authMiddleware, waitlistHandler.Subscribe)
+	restaurantRoutes.Delete("/:id/subscription", authMiddleware, waitlistHandler.Unsubscribe)
+	waitlistRoutes := api.Group("/waitlist", authMiddleware)
+	waitlistRoutes.Get("/me", waitlistHandler.GetMyWaitlist)
+	waitlistRoutes.Get("/me/stream", eventHandler.StreamMyWaitlist)
+
+	// Payment routes (public webhook, verified by signature)
+	if paymentService.Enabled() {
+		api.Post("/payments/webhook", paymentHandler.Webhook)
+	}
+	if fakePayments != nil {
+		api.Post("/payments/fake/:intentId", authMiddleware, paymentHandler.SimulatePayment)
+	}
+
+	// Partner application routes (protected)
+	applicationRoutes := api.Group("/partner-applications", authMiddleware)
+	applicationRoutes.Post("/", applicationHandler.SubmitApplication)
+	applicationRoutes.Get("/me", applicationHandler.GetMyApplications)
+	applicationRoutes.Get("/:id", applicationHandler.GetApplicationByID)
+	applicationRoutes.Post("/:id/withdraw", applicationHandler.WithdrawApplication)
+
+	// Admin routes (protected, admin role only)
+	adminRoutes := api.Group("/admin", authMiddleware, middlewares.RequireRole(models.RoleAdmin))
+	adminRoutes.Get("/partner-applications", applicationHandler.ListApplications)
+	adminRoutes.Post("/partner-applications/:id/approve", applicationHandler.ApproveApplication)
+	adminRoutes.Post("/partner-applications/:id/reject", applicationHandler.RejectApplication)
+	adminRoutes.Get("/users", adminHandler.ListUsers)
+	adminRoutes.Get("/users/:id", adminHandler.GetUser)
+	adminRoutes.Post("/users/:id/suspend", adminHandler.SuspendUser)
+	adminRoutes.Post("/users/:id/unsuspend", adminHandler.UnsuspendUser)
+	adminRoutes.Post("/restaurants/:id/suspend", adminHandler.SuspendRestaurant)
+	adminRoutes.Post("/restaurants/:id/unsuspend", adminHandler.UnsuspendRestaurant)
+	adminRoutes.Post("/listings/:id/deactivate", adminHandler.DeactivateListing)
+	adminRoutes.Post("/orders/:id/cancel", adminHandler.CancelOrder)
+	adminRoutes.Post("/orders/:id/refunds", refundHandler.IssueRefund)
+	adminRoutes.Get("/refunds", refundHandler.ListRefunds)
+	adminRoutes.Post("/refunds/:id/approve", refundHandler.ReviewApproveRefund)
+	adminRoutes.Post("/refunds/:id/reject", refundHandler.ReviewRejectRefund)
+	adminRoutes.Post("/refunds/:id/retry", refundHandler.RetryRefund)
 
 	// Start server
 	port := cfg.Server.Port
@@ -188,9 +389,13 @@ func main() {
 	<-quit
 
 	log.Println("🛑 Shutting down server...")
+	eventBus.Close() // End open event streams so shutdown does not wait on them
 	if err := app.Shutdown(); err != nil {
 		log.Fatalf("❌ Server shutdown error: %v", err)
 	}
+	if cfg.Scheduler.Enabled {
+		jobScheduler.Stop()
+	}
 
 	log.Println("✅ Server shutdown complete")
 }
@@ -23,9 +23,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/verify": {
+        "/admin/listings/{id}/deactivate": {
             "post": {
-                "description": "Verifies a Supabase Google OAuth token and returns a backend JWT for API authentication",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deactivates any listing regardless of ownership (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -33,47 +38,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "Verify Supabase token",
+                "summary": "Force-deactivate listing",
                 "parameters": [
                     {
-                        "description": "Supabase Token",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.VerifyTokenRequest"
-                        }
+                        "type": "string",
+                        "description": "Listing ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Authentication successful",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/handlers.VerifyTokenResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request body",
+                        "description": "Listing deactivated successfully",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "401": {
-                        "description": "Token verification failed",
+                    "404": {
+                        "description": "Listing not found",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -81,9 +66,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/listings": {
-            "get": {
-                "description": "Retrieves all active food listings from all restaurants",
+        "/admin/orders/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels any order regardless of ownership and returns its quantity to stock; paid orders are refunded in full (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -91,33 +81,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Listings"
+                    "Admin"
+                ],
+                "summary": "Cancel order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancellation Reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CancelOrderRequest"
+                        }
+                    }
                 ],
-                "summary": "List all food offerings",
                 "responses": {
                     "200": {
-                        "description": "Listings retrieved successfully",
+                        "description": "Order cancelled successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Listing"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -125,9 +123,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/listings/{id}": {
-            "get": {
-                "description": "Retrieves detailed information about a specific food listing",
+        "/admin/orders/{id}/refunds": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Refunds part or all of any paid order and sends it to the payment provider at once (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -135,21 +138,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Listings"
+                    "Admin"
                 ],
-                "summary": "Get listing details",
+                "summary": "Issue refund",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Listing ID (UUID)",
+                        "description": "Order ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Refund",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RefundRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Listing retrieved successfully",
+                    "201": {
+                        "description": "Refund created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -159,7 +171,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Listing"
+                                            "$ref": "#/definitions/models.Refund"
                                         }
                                     }
                                 }
@@ -167,13 +179,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid listing ID",
+                        "description": "Invalid amount or reason, or order not paid",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "404": {
-                        "description": "Listing not found",
+                        "description": "Order not found",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -181,14 +193,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/listings/{id}/status": {
-            "patch": {
+        "/admin/partner-applications": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Activates or deactivates a listing (restaurant owner only)",
+                "description": "Lists partner applications, optionally filtered by status (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -196,42 +208,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Listings"
+                    "Admin"
                 ],
-                "summary": "Toggle listing status",
+                "summary": "List partner applications",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Listing ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Status Update",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UpdateStatusRequest"
-                        }
+                        "description": "Filter by status (pending, approved, rejected, withdrawn)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Status updated successfully",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
+                        "description": "Applications retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.PartnerApplication"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Admin role required",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -239,14 +250,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/listings/{id}/stock": {
-            "patch": {
+        "/admin/partner-applications/{id}/approve": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the stock quantity of a listing (restaurant owner only)",
+                "description": "Approves a pending application and grants the applicant the restaurant role. The applicant's current access tokens are revoked in the same transaction; their sessions are kept, and their next /auth/refresh returns a fresh token with the new role. (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -254,42 +265,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Listings"
+                    "Admin"
                 ],
-                "summary": "Update listing stock",
+                "summary": "Approve partner application",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Listing ID (UUID)",
+                        "description": "Application ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Stock Update",
+                        "description": "Review Note",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateStockRequest"
+                            "$ref": "#/definitions/handlers.ReviewApplicationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Stock updated successfully",
+                        "description": "Application approved successfully",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PartnerApplication"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Application is no longer pending",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Application not found",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -297,14 +319,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders": {
+        "/admin/partner-applications/{id}/reject": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new food order and automatically decrements stock",
+                "description": "Rejects a pending application with an optional note (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -312,23 +334,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Create order",
+                "summary": "Reject partner application",
                 "parameters": [
                     {
-                        "description": "Order Details",
+                        "type": "string",
+                        "description": "Application ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review Note",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateOrderRequest"
+                            "$ref": "#/definitions/handlers.ReviewApplicationRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Order created successfully",
+                    "200": {
+                        "description": "Application rejected successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -338,7 +366,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Order"
+                                            "$ref": "#/definitions/models.PartnerApplication"
                                         }
                                     }
                                 }
@@ -346,13 +374,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request or insufficient stock",
+                        "description": "Application is no longer pending",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Application not found",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -360,14 +388,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/me": {
+        "/admin/refunds": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves all orders placed by the authenticated user",
+                "description": "Lists refunds across all restaurants, newest first, optionally filtered by status (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -375,12 +403,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
+                ],
+                "summary": "List refunds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (requested, approved, rejected, succeeded, failed)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get user's order history",
                 "responses": {
                     "200": {
-                        "description": "Orders retrieved successfully",
+                        "description": "Refunds retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -390,24 +438,36 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Order"
-                                            }
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/utils.PaginatedData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/models.Refund"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid status",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Admin role required",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -415,14 +475,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/status": {
-            "patch": {
+        "/admin/refunds/{id}/approve": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates order status - pending/ready/completed/cancelled (restaurant owner only)",
+                "description": "Approves a refund request, including one the restaurant rejected, and sends it to the payment provider (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -430,42 +490,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Update order status",
+                "summary": "Approve refund request (admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID (UUID)",
+                        "description": "Refund ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Status Update",
+                        "description": "Decision Note",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateOrderStatusRequest"
+                            "$ref": "#/definitions/handlers.ReviewRefundRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order status updated successfully",
+                        "description": "Refund approved successfully",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Refund"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
-                    "403": {
-                        "description": "Forbidden - not restaurant owner",
+                    "400": {
+                        "description": "Refund can no longer be approved or exceeds the payment",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Refund not found",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -473,9 +544,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/restaurants": {
-            "get": {
-                "description": "Retrieves all restaurants or nearby restaurants if lat/lng provided",
+        "/admin/refunds/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rejects a refund request waiting for a decision with an optional note (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -483,32 +559,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Restaurants"
+                    "Admin"
                 ],
-                "summary": "List restaurants",
+                "summary": "Reject refund request (admin)",
                 "parameters": [
                     {
-                        "type": "number",
-                        "description": "Latitude for nearby search",
-                        "name": "lat",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Longitude for nearby search",
-                        "name": "lng",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Refund ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "number",
-                        "description": "Max distance in km (default: 10)",
-                        "name": "distance",
-                        "in": "query"
+                        "description": "Decision Note",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReviewRefundRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Restaurants retrieved successfully",
+                        "description": "Refund rejected successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -518,10 +591,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Restaurant"
-                                            }
+                                            "$ref": "#/definitions/models.Refund"
                                         }
                                     }
                                 }
@@ -529,26 +599,28 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid parameters",
+                        "description": "Refund is no longer waiting for a decision",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Refund not found",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/refunds/{id}/retry": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new restaurant (requires restaurant role)",
+                "description": "Sends an approved or failed refund to the payment provider again with its attempts reset, e.g. one that used up REFUND_MAX_ATTEMPTS (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -556,23 +628,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Restaurants"
+                    "Admin"
                 ],
-                "summary": "Create restaurant",
+                "summary": "Retry refund",
                 "parameters": [
                     {
-                        "description": "Restaurant Details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.CreateRestaurantRequest"
-                        }
+                        "type": "string",
+                        "description": "Refund ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Restaurant created successfully",
+                    "200": {
+                        "description": "Refund retried successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -582,7 +652,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Restaurant"
+                                            "$ref": "#/definitions/models.Refund"
                                         }
                                     }
                                 }
@@ -590,19 +660,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - restaurant role required",
+                        "description": "Refund is not approved or failed",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Refund not found",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -610,9 +674,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/restaurants/{id}": {
-            "get": {
-                "description": "Retrieves detailed information about a specific restaurant",
+        "/admin/restaurants/{id}/suspend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Suspends a restaurant; it is hidden from listings and cannot take orders (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -620,9 +689,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Restaurants"
+                    "Admin"
                 ],
-                "summary": "Get restaurant by ID",
+                "summary": "Suspend restaurant",
                 "parameters": [
                     {
                         "type": "string",
@@ -630,29 +699,19 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Suspension Reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuspendRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Restaurant retrieved successfully",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.Restaurant"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid restaurant ID",
+                        "description": "Restaurant suspended successfully",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -666,14 +725,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/restaurants/{id}/listings": {
+        "/admin/restaurants/{id}/unsuspend": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new food listing for a restaurant (mystery box or reveal item)",
+                "description": "Reinstates a suspended restaurant (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -681,9 +740,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Listings"
+                    "Admin"
                 ],
-                "summary": "Create food listing",
+                "summary": "Unsuspend restaurant",
                 "parameters": [
                     {
                         "type": "string",
@@ -691,20 +750,77 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restaurant reinstated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
                     },
-                    {
-                        "description": "Listing Details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                    "404": {
+                        "description": "Restaurant not found",
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateListingRequest"
+                            "$ref": "#/definitions/utils.Response"
                         }
                     }
+                }
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists users with optional search by name/email, role and suspension filters (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by name or email",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by role (user, restaurant, admin)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by suspension state",
+                        "name": "suspended",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Listing created successfully",
+                    "200": {
+                        "description": "Users retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -714,7 +830,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Listing"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/utils.PaginatedData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/models.User"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -722,13 +853,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid parameters",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Admin role required",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -736,14 +867,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me": {
+        "/admin/users/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves the profile of the currently authenticated user",
+                "description": "Retrieves any user's profile (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -751,9 +882,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
+                ],
+                "summary": "Get user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get current user profile",
                 "responses": {
                     "200": {
                         "description": "User retrieved successfully",
@@ -773,8 +913,65 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/suspend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Suspends a user; their existing tokens are rejected immediately (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Suspend user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Suspension Reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuspendRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User suspended successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Cannot suspend yourself",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admins cannot be suspended",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -787,147 +984,6344 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.CreateListingRequest": {
+        },
+        "/admin/users/{id}/unsuspend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reinstates a suspended user (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Unsuspend user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User reinstated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes the current access token and, if provided, the session of the given refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "description": "Refresh Token",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Logged out successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Refresh token belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes all refresh tokens and outstanding access tokens of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout from all devices",
+                "responses": {
+                    "200": {
+                        "description": "Logged out from all devices",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchanges a refresh token for a new access token and a new refresh token. Reusing an already rotated refresh token revokes the whole session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh Token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token refreshed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.TokenPair"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Refresh token invalid, expired or reused",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Account suspended",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify": {
+            "post": {
+                "description": "Verifies a Supabase Google OAuth token and returns a backend JWT for API authentication",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Verify Supabase token",
+                "parameters": [
+                    {
+                        "description": "Supabase Token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.VerifyTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Authentication successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.VerifyTokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or malformed token",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Token expired, signature invalid or claims rejected",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Account suspended",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Identity provider keys unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the cart with totals at current listing prices. Prices are charged at checkout and may drop before then.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Get my cart",
+                "responses": {
+                    "200": {
+                        "description": "Cart retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Cart"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes every item from the cart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Clear cart",
+                "responses": {
+                    "200": {
+                        "description": "Cart cleared successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart/checkout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Orders every item in the cart as one order. Stock of every listing is decremented in one transaction; if any listing is short, inactive or past its pickup window nothing is ordered. The order's pickup window is the time all listings have in common. The cart is emptied on success. When payments are enabled the order starts as awaiting_payment, as for POST /orders.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Check out cart",
+                "responses": {
+                    "201": {
+                        "description": "Order created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Order"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Cart is empty or cannot be ordered",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Cart changed during checkout",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Purchase limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Payment could not be started",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart/items/{listingId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Puts a listing in the cart or replaces its quantity. A cart holds listings from one restaurant only; clear it to order from another. Stock is not reserved until checkout.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Set cart item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Listing ID (UUID)",
+                        "name": "listingId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Quantity",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetCartItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cart updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Cart"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or listing cannot be ordered",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Listing not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Cart holds listings from another restaurant",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Quantity exceeds the listing's purchase limit",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a listing from the cart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Remove cart item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Listing ID (UUID)",
+                        "name": "listingId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cart updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Cart"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid listing ID",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Listing not in cart",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/impact": {
+            "get": {
+                "description": "Estimates the platform-wide impact of all completed orders, with a daily, weekly (Monday-based) or monthly time series. Dates are in the platform timezone (Asia/Jakarta).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Impact"
+                ],
+                "summary": "Get platform impact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Series bucket: day, week or month (default day)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD); defaults to 30 days, 12 weeks or 12 months before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD, inclusive); defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Impact retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PlatformImpact"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period or date range",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/impact/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Estimates meals rescued, kilograms of food saved and CO2e avoided by the user's completed orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Impact"
+                ],
+                "summary": "Get my impact",
+                "responses": {
+                    "200": {
+                        "description": "Impact retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Impact"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists pending, unexpired restaurant invitations addressed to the authenticated user's email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurant Members"
+                ],
+                "summary": "Get my invitations",
+                "responses": {
+                    "200": {
+                        "description": "Invitations retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.RestaurantInvitation"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Accepts a pending invitation addressed to the authenticated user and joins the restaurant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurant Members"
+                ],
+                "summary": "Accept invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation accepted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.RestaurantMember"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invitation expired or no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Declines a pending invitation addressed to the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurant Members"
+                ],
+                "summary": "Decline invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation declined successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invitation is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/listing-templates/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a template and its overrides. Listings already created from it are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listing Templates"
+                ],
+                "summary": "Delete listing template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates a template, including pausing it with is_active=false. Listings already created from it are not changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listing Templates"
+                ],
+                "summary": "Update listing template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateListingTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ListingTemplate"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/listing-templates/{id}/occurrences/{date}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Skips the occurrence on a date or overrides its stock. Only dates from today (restaurant timezone) whose listing has not been created yet can be overridden; after that, manage the listing directly.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listing Templates"
+                ],
+                "summary": "Override template occurrence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Occurrence date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Override",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetOccurrenceOverrideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Occurrence override saved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ListingOccurrenceOverride"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Listing for this date already created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restores the template defaults for a date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listing Templates"
+                ],
+                "summary": "Remove occurrence override",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Occurrence date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Occurrence override removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Override not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/listings": {
+            "get": {
+                "description": "Retrieves all active food listings from all restaurants",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listings"
+                ],
+                "summary": "List all food offerings",
+                "responses": {
+                    "200": {
+                        "description": "Listings retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Listing"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/listings/stream": {
+            "get": {
+                "description": "Server-Sent Events stream of listing.stock_changed events, optionally for one restaurant. Each instance serves a limited number of these streams at once.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Stream listing stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only this restaurant's listings (UUID)",
+                        "name": "restaurant_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "$ref": "#/definitions/events.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid restaurant ID",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many open streams",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/listings/{id}": {
+            "get": {
+                "description": "Retrieves detailed information about a specific food listing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listings"
+                ],
+                "summary": "Get listing details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Listing ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Listing retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Listing"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid listing ID",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Listing not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/listings/{id}/max-per-customer": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the most units of the listing one customer may hold across their orders. Send null to remove the limit. Orders already placed are kept, even above a lowered limit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listings"
+                ],
+                "summary": "Set listing purchase limit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Listing ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Purchase limit",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetMaxPerCustomerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase limit updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Listing"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Listing not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/listings/{id}/price-schedule": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the markdown schedule that lowers the price as the pickup window ends, e.g. 30% off 120 minutes before pickup_end and 50% off in the final 60 minutes, never below floor_price. Send null to remove it. Orders are charged the price in effect when they are placed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listings"
+                ],
+                "summary": "Set listing price schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Listing ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Price schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetPriceScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price schedule updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Listing"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Listing not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/listings/{id}/reservations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Holds qty units of a listing while the user checks out. Held units are taken out of the listing's stock until they are ordered, released or the reservation expires after RESERVATION_TTL (default 5m), and are used first when the user orders the listing. Reserving a listing the user already holds changes its quantity but keeps its expiry. A user may hold MAX_ACTIVE_RESERVATIONS (default 10) listings at once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservations"
+                ],
+                "summary": "Reserve listing stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Listing ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Quantity",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReserveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Stock reserved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.StockReservation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, insufficient stock or listing cannot be ordered",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Listing not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Quantity exceeds the listing's purchase limit or too many active reservations",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/listings/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Activates or deactivates a listing (restaurant members with permission only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listings"
+                ],
+                "summary": "Toggle listing status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Listing ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status Update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Listing not found or restaurant deleted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/listings/{id}/stock": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the stock quantity of a listing (restaurant members with permission only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listings"
+                ],
+                "summary": "Update listing stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Listing ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock Update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/listings/{id}/waitlist": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Puts the user in line for a sold-out listing. When it comes back in stock, from a restock or a cancelled order, customers in line are notified in the order they joined over GET /waitlist/me/stream, and the first in line get their units held for WAITLIST_HOLD (default 10m). Joining again keeps the user's place and updates the quantity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Join listing waitlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Listing ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Quantity wanted",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JoinWaitlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Joined waitlist successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WaitlistEntry"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, listing in stock or listing cannot be ordered",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Listing not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Quantity exceeds the listing's purchase limit",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Takes the user out of line for a listing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Leave listing waitlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Listing ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Left waitlist successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not on the listing's waitlist",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Orders a single listing and automatically decrements stock. To order several listings of one restaurant together, use the cart and POST /cart/checkout. When payments are enabled the order starts as awaiting_payment, holding its stock until paid or PAYMENT_TIMEOUT (default 15m) passes, and its payment carries the client_secret to pay with.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Create order",
+                "parameters": [
+                    {
+                        "description": "Order Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Order created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Order"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or insufficient stock",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Purchase limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Payment could not be started",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves all orders placed by the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get user's order history",
+                "responses": {
+                    "200": {
+                        "description": "Orders retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Order"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/me/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of order.created and order.status_changed events for the authenticated user's orders. The stream closes once the session ends.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Stream my orders",
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "$ref": "#/definitions/events.Event"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves an order with its status timeline (events, oldest first). Available to the customer who placed it and to restaurant members who may view orders.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Order"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your order",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels an order placed by the authenticated user and returns its quantity to stock. Pending and ready orders may be cancelled until ORDER_CANCEL_CUTOFF (default 1h) before pickup starts; orders awaiting payment at any time. Paid orders are refunded in full.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Cancel my order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancellation Reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CancelOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order cancelled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Order can no longer be cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your order",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/pickup": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Completes a pending or ready order when restaurant staff type the customer's pickup code or scan its QR payload (restaurant members with permission only). After PICKUP_MAX_ATTEMPTS invalid codes (default 5) verification of the order is locked for PICKUP_LOCKOUT (default 15m).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Verify order pickup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pickup Code or QR Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.VerifyPickupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order picked up successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Order"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pickup code or order not awaiting pickup",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many invalid pickup codes",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/refunds": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Customers request a refund of a completed or missed order until REFUND_WINDOW (default 72h) after the pickup window ends; the request waits for the restaurant or an admin, and only one may wait at a time. Members with orders:manage issue refunds of any paid order, which are approved and sent to the payment provider at once. The amount may not exceed what is left of the payment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refunds"
+                ],
+                "summary": "Request refund",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Refund",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RefundRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Refund created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Refund"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid amount or reason, order not paid or refund window closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "A refund request is already waiting",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves an order along (restaurant members with permission only): pending → ready, pending/ready/awaiting_payment → cancelled, ready → no_show once the pickup window has ended. Orders are completed through POST /orders/{id}/pickup. Cancelling returns the quantity to stock and refunds a paid order in full. Every transition is recorded in the order's timeline.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Update order status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status Update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateOrderStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order status updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/partner-applications": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Submits an application to become a restaurant partner. Only one application can be pending at a time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner Applications"
+                ],
+                "summary": "Submit partner application",
+                "parameters": [
+                    {
+                        "description": "Application Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SubmitApplicationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Application submitted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PartnerApplication"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Already a partner or application pending",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/partner-applications/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves all partner applications of the authenticated user, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner Applications"
+                ],
+                "summary": "Get my partner applications",
+                "responses": {
+                    "200": {
+                        "description": "Applications retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.PartnerApplication"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/partner-applications/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a partner application (applicant or admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner Applications"
+                ],
+                "summary": "Get partner application",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Application ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Application retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PartnerApplication"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Application not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/partner-applications/{id}/withdraw": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Withdraws a pending partner application (applicant only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner Applications"
+                ],
+                "summary": "Withdraw partner application",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Application ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Application withdrawn successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PartnerApplication"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Application is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/fake/{intentId}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stands in for the customer paying on the client when PAYMENT_PROVIDER=fake: the fake provider authorizes the intent, or declines it, and its signed webhook is delivered to POST /payments/webhook in process. Not available with other providers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Simulate payment (fake provider only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment provider intent ID",
+                        "name": "intentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Outcome",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimulatePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment simulated",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Intent not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/webhook": {
+            "post": {
+                "description": "Receives signed notifications from the payment provider. Authorized payments are captured and their orders move from awaiting_payment to pending; failed attempts are recorded on the payment. Redelivered notifications are ignored once applied.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Payment webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook signature (header name depends on the provider)",
+                        "name": "Payment-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook processed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown payment",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/refunds/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approves a refund request of one of the restaurant's orders and sends it to the payment provider (members with orders:manage)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refunds"
+                ],
+                "summary": "Approve refund request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Refund ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Decision Note",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReviewRefundRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refund approved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Refund"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Refund is no longer waiting for a decision or exceeds the payment",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Refund not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/refunds/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rejects a refund request of one of the restaurant's orders with an optional note (members with orders:manage). Admins may still approve it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refunds"
+                ],
+                "summary": "Reject refund request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Refund ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Decision Note",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReviewRefundRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refund rejected successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Refund"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Refund is no longer waiting for a decision",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Refund not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the user's active stock reservations, soonest expiry first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservations"
+                ],
+                "summary": "Get my reservations",
+                "responses": {
+                    "200": {
+                        "description": "Reservations retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.StockReservation"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gives up an active reservation and returns its units to stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservations"
+                ],
+                "summary": "Release reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation released successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Reservation is no longer active",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your reservation",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants": {
+            "get": {
+                "description": "Retrieves all restaurants or nearby restaurants if lat/lng provided",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "summary": "List restaurants",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Latitude for nearby search",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude for nearby search",
+                        "name": "lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Max distance in km (default: 10)",
+                        "name": "distance",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restaurants retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Restaurant"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new restaurant (requires restaurant role)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "summary": "Create restaurant",
+                "parameters": [
+                    {
+                        "description": "Restaurant Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateRestaurantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Restaurant created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Restaurant"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - restaurant role required",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/mine": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves all restaurants owned by the authenticated user, including suspended ones",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "summary": "Get my restaurants",
+                "responses": {
+                    "200": {
+                        "description": "Restaurants retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Restaurant"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}": {
+            "get": {
+                "description": "Retrieves detailed information about a specific restaurant, including the estimated impact of its completed orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "summary": "Get restaurant by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restaurant retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Restaurant"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid restaurant ID",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft-deletes a restaurant and deactivates all of its listings. Refused while orders are pending or ready for pickup. (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "summary": "Delete restaurant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restaurant deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Restaurant has pending orders",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates only the provided fields of a restaurant (owner and managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "summary": "Update restaurant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateRestaurantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restaurant updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Restaurant"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/closures": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Closes the restaurant for a date range (holidays, temporary closures), inclusive, in the restaurant's timezone (owner and managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "summary": "Add closure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Closure",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateClosureRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Closure created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.RestaurantClosure"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/closures/{closureId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a closure so the weekly schedule applies again (owner and managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "summary": "Remove closure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Closure ID (UUID)",
+                        "name": "closureId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Closure removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant or closure not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/hours": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the weekly schedule. Days may have several intervals, which must not overlap; an interval closing at or before its opening time runs past midnight. An empty list falls back to open-until-closing_time. (owner and managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "summary": "Set opening hours",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Weekly schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetOpeningHoursRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Opening hours updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Restaurant"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or overlapping intervals",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/impact": {
+            "get": {
+                "description": "Estimates meals rescued, kilograms of food saved and CO2e avoided by a restaurant's completed orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Impact"
+                ],
+                "summary": "Get restaurant impact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Impact retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Impact"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid restaurant ID",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists all invitations sent by a restaurant, newest first (owner and managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurant Members"
+                ],
+                "summary": "List restaurant invitations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitations retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.RestaurantInvitation"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Invites a user by email as manager or staff. Owners can invite managers and staff; managers can invite staff. Invitations expire after 7 days.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurant Members"
+                ],
+                "summary": "Invite restaurant member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invitation Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.InviteMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Invitation sent successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.RestaurantInvitation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Already a member or invitation pending",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/invitations/{invitationId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes a pending invitation (owner and managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurant Members"
+                ],
+                "summary": "Revoke invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invitation ID (UUID)",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation revoked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invitation is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/listing-templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists a restaurant's templates with their upcoming overrides (members who manage listings only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listing Templates"
+                ],
+                "summary": "List listing templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Templates retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ListingTemplate"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a template that is turned into a listing on each selected weekday, with the pickup window in the restaurant's timezone. Occurrences are created at the start of each local day; days the restaurant is closed during the window are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listing Templates"
+                ],
+                "summary": "Create listing template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateListingTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Template created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ListingTemplate"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/listings": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new food listing for a restaurant (mystery box or reveal item)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listings"
+                ],
+                "summary": "Create food listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Listing Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateListingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Listing created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Listing"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the owner, managers and staff of a restaurant (members only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurant Members"
+                ],
+                "summary": "List restaurant members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Members retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.RestaurantMember"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/members/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a member from a restaurant. Members can remove themselves to leave; the owner cannot be removed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurant Members"
+                ],
+                "summary": "Remove member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member User ID (UUID)",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Owner cannot be removed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes a member's role between manager and staff. The requester must outrank both the member's current and new role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurant Members"
+                ],
+                "summary": "Update member role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member User ID (UUID)",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New Role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateMemberRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member role updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid role",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists a restaurant's orders sorted by pickup time, with optional status, pickup date and listing filters (restaurant members who may view orders)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get restaurant orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (pending, ready, completed, cancelled, no_show)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by pickup date in the restaurant's timezone (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by listing ID (UUID)",
+                        "name": "listing_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Orders retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/utils.PaginatedData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/models.Order"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/orders/board": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Groups a restaurant's orders for one pickup date into pending, ready and completed columns with counts, each sorted by pickup time (restaurant members who may view orders)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get restaurant order board",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pickup date in the restaurant's timezone (YYYY-MM-DD); defaults to today",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order board retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.OrderBoard"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/orders/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of order.created and order.status_changed events for a restaurant (restaurant members who may view orders). The stream closes once the session ends or the permission is lost.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Stream restaurant orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "$ref": "#/definitions/events.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid restaurant ID",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/refunds": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists refunds of the restaurant's orders, newest first (members with orders:view). Filter by status=requested for requests waiting for a decision.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refunds"
+                ],
+                "summary": "Get restaurant refunds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (requested, approved, rejected, succeeded, failed)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refunds retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/utils.PaginatedData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/models.Refund"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/savings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Totals a restaurant's completed orders: items rescued, what customers paid and what they saved (admins and members with permission to view orders only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "summary": "Get restaurant savings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Savings retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SavingsSummary"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid restaurant ID",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - missing restaurant permission",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/subscription": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Notifies the user whenever one of the restaurant's sold-out listings comes back in stock and units are left after its waitlist is served. Subscribing again keeps the existing subscription.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Subscribe to restaurant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Subscribed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.RestaurantSubscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid restaurant ID",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stops back-in-stock notifications for the restaurant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Unsubscribe from restaurant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unsubscribed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not subscribed to the restaurant",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the profile of the currently authenticated user, including savings totals from completed orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get current user profile",
+                "responses": {
+                    "200": {
+                        "description": "User retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/waitlist/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the listings the user waits for, with their place in line, the listings they were notified of, with the reservation holding their units, and the restaurants they subscribe to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Get my waitlist",
+                "responses": {
+                    "200": {
+                        "description": "Waitlist retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Waitlist"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/waitlist/me/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of listing.back_in_stock events for listings the authenticated user waits for or whose restaurant they subscribe to. reservation_id is set when units are held for the user. The stream closes once the session ends.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Stream my waitlist",
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "$ref": "#/definitions/events.Event"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "events.Event": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "listing_id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "reservation_id": {
+                    "description": "Units held for the customer on listing.back_in_stock, if any",
+                    "type": "string"
+                },
+                "restaurant_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/events.Type"
+                },
+                "user_id": {
+                    "description": "Customer of the order or notification",
+                    "type": "string"
+                }
+            }
+        },
+        "events.Type": {
+            "type": "string",
+            "enum": [
+                "order.created",
+                "order.status_changed",
+                "listing.stock_changed",
+                "listing.back_in_stock"
+            ],
+            "x-enum-varnames": [
+                "OrderCreated",
+                "OrderStatusChanged",
+                "ListingStockChanged",
+                "ListingBackInStock"
+            ]
+        },
+        "handlers.CancelOrderRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreateClosureRequest": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "description": "Format: \"YYYY-MM-DD\", inclusive",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "Format: \"YYYY-MM-DD\"",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreateListingRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "max_per_customer": {
+                    "description": "Optional limit on units per customer",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Optional for mystery box",
+                    "type": "string"
+                },
+                "original_value": {
+                    "description": "Optional retail value, above price",
+                    "type": "integer"
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "pickup_end": {
+                    "description": "RFC3339",
+                    "type": "string"
+                },
+                "pickup_start": {
+                    "description": "RFC3339, e.g. \"2026-10-16T18:00:00+07:00\"",
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "price_schedule": {
+                    "description": "Optional markdown as pickup_end approaches",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PriceSchedule"
+                        }
+                    ]
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "\"mystery_box\" or \"reveal\"",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreateListingTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "max_per_customer": {
+                    "description": "Optional limit on units per customer of each occurrence",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Optional for mystery box",
+                    "type": "string"
+                },
+                "original_value": {
+                    "description": "Optional retail value, above price",
+                    "type": "integer"
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "pickup_end": {
+                    "description": "HH:MM:SS; at or before pickup_start runs past midnight",
+                    "type": "string"
+                },
+                "pickup_start": {
+                    "description": "HH:MM:SS, restaurant local time",
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "price_schedule": {
+                    "description": "Optional markdown for each occurrence",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PriceSchedule"
+                        }
+                    ]
+                },
+                "stock": {
+                    "description": "Default stock per occurrence",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "\"mystery_box\" or \"reveal\"",
+                    "type": "string"
+                },
+                "weekdays": {
+                    "description": "0-6, Sunday = 0",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handlers.CreateOrderRequest": {
+            "type": "object",
+            "properties": {
+                "listing_id": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.CreateRestaurantRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "closing_time": {
+                    "description": "Format: \"HH:MM:SS\"",
+                    "type": "string"
+                },
+                "daily_limit_per_customer": {
+                    "description": "Optional limit on items per customer per pickup day",
+                    "type": "integer"
+                },
+                "lat": {
+                    "type": "number"
+                },
+                "lng": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA name, default \"Asia/Jakarta\"",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.InviteMemberRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "manager or staff",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.JoinWaitlistRequest": {
+            "type": "object",
+            "properties": {
+                "qty": {
+                    "description": "Defaults to 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.OpeningHoursRequest": {
+            "type": "object",
+            "properties": {
+                "closes_at": {
+                    "description": "Format: \"HH:MM:SS\"; at or before opens_at runs past midnight",
+                    "type": "string"
+                },
+                "opens_at": {
+                    "description": "Format: \"HH:MM:SS\"",
+                    "type": "string"
+                },
+                "weekday": {
+                    "description": "0 = Sunday ... 6 = Saturday",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.RefreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "device_name": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.RefundRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Smallest currency unit",
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ReserveRequest": {
+            "type": "object",
+            "properties": {
+                "qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ReviewApplicationRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ReviewRefundRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SetCartItemRequest": {
+            "type": "object",
+            "properties": {
+                "qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.SetMaxPerCustomerRequest": {
+            "type": "object",
+            "properties": {
+                "max_per_customer": {
+                    "description": "null removes the limit",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.SetOccurrenceOverrideRequest": {
+            "type": "object",
+            "properties": {
+                "skip": {
+                    "type": "boolean"
+                },
+                "stock": {
+                    "description": "Replaces the default stock for this date",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.SetOpeningHoursRequest": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OpeningHoursRequest"
+                    }
+                }
+            }
+        },
+        "handlers.SetPriceScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "price_schedule": {
+                    "description": "null removes the schedule",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PriceSchedule"
+                        }
+                    ]
+                }
+            }
+        },
+        "handlers.SimulatePaymentRequest": {
+            "type": "object",
+            "properties": {
+                "succeed": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.SubmitApplicationRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "business_name": {
+                    "type": "string"
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ApplicationDocument"
+                    }
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SuspendRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.UpdateListingTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_per_customer": {
+                    "description": "null removes the limit",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "original_value": {
+                    "type": "integer"
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "pickup_end": {
+                    "type": "string"
+                },
+                "pickup_start": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "price_schedule": {
+                    "description": "null removes the schedule",
+                    "type": "object"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "weekdays": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handlers.UpdateMemberRoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "description": "manager or staff",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.UpdateOrderStatusRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "Optional, recorded for cancellations",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"ready\", \"cancelled\", \"no_show\"; completion goes through pickup verification",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.UpdateRestaurantRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "closing_time": {
+                    "description": "Format: \"HH:MM:SS\"",
+                    "type": "string"
+                },
+                "daily_limit_per_customer": {
+                    "description": "0 removes the limit",
+                    "type": "integer"
+                },
+                "lat": {
+                    "type": "number"
+                },
+                "lng": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA name",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.UpdateStatusRequest": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.UpdateStockRequest": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "description": "Can be positive (add) or negative (reduce)",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.VerifyPickupRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Typed pickup code",
+                    "type": "string"
+                },
+                "qr_payload": {
+                    "description": "Scanned QR payload; takes precedence over code",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.VerifyTokenRequest": {
+            "type": "object",
+            "properties": {
+                "device_name": {
+                    "description": "Optional, shown in session listings",
+                    "type": "string"
+                },
+                "supabase_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.VerifyTokenResponse": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "type": "integer"
+                },
+                "refresh_expires_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                },
+                "user": {}
+            }
+        },
+        "models.ApplicationDocument": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "e.g. \"business_license\", \"id_card\", \"storefront_photo\"",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Cart": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CartItem"
+                    }
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "restaurant_id": {
+                    "description": "Nil while the cart is empty",
+                    "type": "string"
+                },
+                "savings": {
+                    "type": "integer"
+                },
+                "total_price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CartItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "listing": {
+                    "description": "Relationships",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Listing"
+                        }
+                    ]
+                },
+                "listing_id": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DateOnly": {
+            "type": "object",
+            "properties": {
+                "time.Time": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DeactivationReason": {
+            "type": "string",
+            "enum": [
+                "manual",
+                "admin",
+                "pickup_ended",
+                "restaurant_closed",
+                "restaurant_deleted"
+            ],
+            "x-enum-varnames": [
+                "DeactivationManual",
+                "DeactivationAdmin",
+                "DeactivationPickupEnded",
+                "DeactivationRestaurantClosed",
+                "DeactivationRestaurantDeleted"
+            ]
+        },
+        "models.Impact": {
+            "type": "object",
+            "properties": {
+                "co2e_avoided_kg": {
+                    "type": "number"
+                },
+                "food_saved_kg": {
+                    "type": "number"
+                },
+                "meals_rescued": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImpactPeriod": {
+            "type": "string",
+            "enum": [
+                "day",
+                "week",
+                "month"
+            ],
+            "x-enum-varnames": [
+                "ImpactPeriodDay",
+                "ImpactPeriodWeek",
+                "ImpactPeriodMonth"
+            ]
+        },
+        "models.ImpactPoint": {
+            "type": "object",
+            "properties": {
+                "co2e_avoided_kg": {
+                    "type": "number"
+                },
+                "food_saved_kg": {
+                    "type": "number"
+                },
+                "meals_rescued": {
+                    "type": "integer"
+                },
+                "period_start": {
+                    "$ref": "#/definitions/models.DateOnly"
+                }
+            }
+        },
+        "models.InvitationStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "accepted",
+                "declined",
+                "revoked"
+            ],
+            "x-enum-varnames": [
+                "InvitationStatusPending",
+                "InvitationStatusAccepted",
+                "InvitationStatusDeclined",
+                "InvitationStatusRevoked"
+            ]
+        },
+        "models.Listing": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current_price": {
+                    "description": "Computed pricing, not stored",
+                    "type": "integer"
+                },
+                "deactivated_at": {
+                    "description": "Set when the listing is deactivated, cleared when reactivated",
+                    "type": "string"
+                },
+                "deactivation_reason": {
+                    "$ref": "#/definitions/models.DeactivationReason"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_per_customer": {
+                    "description": "Most units one customer may hold across their non-cancelled orders; nil for no limit",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Nullable for mystery box",
+                    "type": "string"
+                },
+                "next_price_drop_at": {
+                    "type": "string"
+                },
+                "occurrence_date": {
+                    "$ref": "#/definitions/models.DateOnly"
+                },
+                "order_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderItem"
+                    }
+                },
+                "original_value": {
+                    "description": "Retail value of the food, above Price; nil if not provided",
+                    "type": "integer"
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "pickup_end": {
+                    "type": "string"
+                },
+                "pickup_start": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Price in smallest currency unit (e.g., cents)",
+                    "type": "integer"
+                },
+                "price_schedule": {
+                    "description": "Optional markdown as the pickup window ends; Price stays the undiscounted price",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PriceSchedule"
+                        }
+                    ]
+                },
+                "restaurant": {
+                    "description": "Relationships",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Restaurant"
+                        }
+                    ]
+                },
+                "restaurant_id": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "template_id": {
+                    "description": "Set when the listing was created from a template",
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.ListingType"
+                }
+            }
+        },
+        "models.ListingOccurrenceOverride": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "$ref": "#/definitions/models.DateOnly"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "skip": {
+                    "type": "boolean"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "template_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ListingTemplate": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
-                "name": {
-                    "description": "Optional for mystery box",
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_per_customer": {
+                    "description": "Copied to each occurrence",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "original_value": {
+                    "type": "integer"
+                },
+                "overrides": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ListingOccurrenceOverride"
+                    }
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "pickup_end": {
+                    "description": "At or before start runs past midnight",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TimeOnly"
+                        }
+                    ]
+                },
+                "pickup_start": {
+                    "description": "Restaurant local time",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TimeOnly"
+                        }
+                    ]
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "price_schedule": {
+                    "description": "Copied to each occurrence",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PriceSchedule"
+                        }
+                    ]
+                },
+                "restaurant_id": {
+                    "type": "string"
+                },
+                "stock": {
+                    "description": "Default stock per occurrence",
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.ListingType"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weekdays": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ListingType": {
+            "type": "string",
+            "enum": [
+                "mystery_box",
+                "reveal"
+            ],
+            "x-enum-varnames": [
+                "ListingTypeMysteryBox",
+                "ListingTypeReveal"
+            ]
+        },
+        "models.MarkdownStep": {
+            "type": "object",
+            "properties": {
+                "minutes_before_end": {
+                    "description": "Applies from this many minutes before pickup_end",
+                    "type": "integer"
+                },
+                "percent_off": {
+                    "description": "1-100",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MemberRole": {
+            "type": "string",
+            "enum": [
+                "owner",
+                "manager",
+                "staff"
+            ],
+            "x-enum-varnames": [
+                "MemberRoleOwner",
+                "MemberRoleManager",
+                "MemberRoleStaff"
+            ]
+        },
+        "models.OpeningHours": {
+            "type": "object",
+            "properties": {
+                "closes_at": {
+                    "$ref": "#/definitions/models.TimeOnly"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "opens_at": {
+                    "$ref": "#/definitions/models.TimeOnly"
+                },
+                "restaurant_id": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "description": "0 = Sunday ... 6 = Saturday",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Order": {
+            "type": "object",
+            "properties": {
+                "cancellation_reason": {
+                    "type": "string"
+                },
+                "cancelled_at": {
+                    "description": "Set when the order is cancelled",
+                    "type": "string"
+                },
+                "cancelled_by": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "description": "Set when the order is picked up",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "description": "Status timeline, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderStatusEvent"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderItem"
+                    }
+                },
+                "payment": {
+                    "$ref": "#/definitions/models.Payment"
+                },
+                "pickup": {
+                    "description": "Only set for the customer while the order is open",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PickupPass"
+                        }
+                    ]
+                },
+                "pickup_end": {
+                    "type": "string"
+                },
+                "pickup_start": {
+                    "description": "When every item can be collected",
+                    "type": "string"
+                },
+                "qty": {
+                    "description": "Total items across all lines",
+                    "type": "integer"
+                },
+                "refunded_amount": {
+                    "description": "Returned to the customer by succeeded refunds, in smallest currency unit",
+                    "type": "integer"
+                },
+                "refunds": {
+                    "description": "Oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Refund"
+                    }
+                },
+                "restaurant": {
+                    "$ref": "#/definitions/models.Restaurant"
+                },
+                "restaurant_id": {
+                    "type": "string"
+                },
+                "savings": {
+                    "description": "Saved against the listings' original values",
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "total_price": {
+                    "description": "Total price in smallest currency unit",
+                    "type": "integer"
+                },
+                "user": {
+                    "description": "Relationships",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderActor": {
+            "type": "string",
+            "enum": [
+                "customer",
+                "restaurant",
+                "admin",
+                "system"
+            ],
+            "x-enum-comments": {
+                "OrderActorSystem": "Background jobs"
+            },
+            "x-enum-descriptions": [
+                "",
+                "",
+                "",
+                "Background jobs"
+            ],
+            "x-enum-varnames": [
+                "OrderActorCustomer",
+                "OrderActorRestaurant",
+                "OrderActorAdmin",
+                "OrderActorSystem"
+            ]
+        },
+        "models.OrderBoard": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "$ref": "#/definitions/models.OrderBoardColumn"
+                },
+                "date": {
+                    "$ref": "#/definitions/models.DateOnly"
+                },
+                "pending": {
+                    "$ref": "#/definitions/models.OrderBoardColumn"
+                },
+                "ready": {
+                    "$ref": "#/definitions/models.OrderBoardColumn"
+                }
+            }
+        },
+        "models.OrderBoardColumn": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderBoardEntry"
+                    }
+                }
+            }
+        },
+        "models.OrderBoardEntry": {
+            "type": "object",
+            "properties": {
+                "customer_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderBoardItem"
+                    }
+                },
+                "pickup_end": {
+                    "type": "string"
+                },
+                "pickup_start": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                }
+            }
+        },
+        "models.OrderBoardItem": {
+            "type": "object",
+            "properties": {
+                "listing_id": {
+                    "type": "string"
+                },
+                "listing_name": {
+                    "type": "string"
+                },
+                "listing_type": {
+                    "$ref": "#/definitions/models.ListingType"
+                },
+                "qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrderItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "listing": {
+                    "description": "Relationships",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Listing"
+                        }
+                    ]
+                },
+                "listing_id": {
                     "type": "string"
                 },
-                "photo_url": {
+                "order_id": {
                     "type": "string"
                 },
-                "pickup_time": {
-                    "description": "Format: \"HH:MM:SS\"",
-                    "type": "string"
+                "qty": {
+                    "type": "integer"
                 },
-                "price": {
+                "savings": {
+                    "description": "Saved against the listing's original value",
                     "type": "integer"
                 },
-                "stock": {
+                "total_price": {
+                    "description": "unit_price * qty",
                     "type": "integer"
                 },
-                "type": {
-                    "description": "\"mystery_box\" or \"reveal\"",
-                    "type": "string"
+                "unit_price": {
+                    "description": "Price per item actually charged, after markdown",
+                    "type": "integer"
                 }
             }
         },
-        "handlers.CreateOrderRequest": {
+        "models.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "ready",
+                "completed",
+                "cancelled",
+                "no_show",
+                "awaiting_payment"
+            ],
+            "x-enum-comments": {
+                "OrderStatusNoShow": "Not picked up before the pickup window ended"
+            },
+            "x-enum-descriptions": [
+                "",
+                "",
+                "",
+                "",
+                "Not picked up before the pickup window ended",
+                ""
+            ],
+            "x-enum-varnames": [
+                "OrderStatusPending",
+                "OrderStatusReady",
+                "OrderStatusCompleted",
+                "OrderStatusCancelled",
+                "OrderStatusNoShow",
+                "OrderStatusAwaitingPayment"
+            ]
+        },
+        "models.OrderStatusEvent": {
             "type": "object",
             "properties": {
-                "listing_id": {
+                "actor": {
+                    "$ref": "#/definitions/models.OrderActor"
+                },
+                "actor_id": {
+                    "description": "Nil for system transitions",
                     "type": "string"
                 },
-                "qty": {
-                    "type": "integer"
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "description": "Nil when the order was placed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderStatus"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "$ref": "#/definitions/models.OrderStatus"
                 }
             }
         },
-        "handlers.CreateRestaurantRequest": {
+        "models.PartnerApplication": {
             "type": "object",
             "properties": {
                 "address": {
                     "type": "string"
                 },
-                "closing_time": {
-                    "description": "Format: \"HH:MM:SS\"",
+                "business_name": {
                     "type": "string"
                 },
-                "lat": {
-                    "type": "number"
+                "created_at": {
+                    "type": "string"
                 },
-                "lng": {
-                    "type": "number"
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ApplicationDocument"
+                    }
                 },
-                "name": {
+                "id": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.PartnerApplicationStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "description": "Relationships",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ]
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "handlers.UpdateOrderStatusRequest": {
+        "models.PartnerApplicationStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "approved",
+                "rejected",
+                "withdrawn"
+            ],
+            "x-enum-varnames": [
+                "ApplicationStatusPending",
+                "ApplicationStatusApproved",
+                "ApplicationStatusRejected",
+                "ApplicationStatusWithdrawn"
+            ]
+        },
+        "models.Payment": {
             "type": "object",
             "properties": {
+                "amount": {
+                    "description": "Smallest currency unit",
+                    "type": "integer"
+                },
+                "captured_at": {
+                    "type": "string"
+                },
+                "client_secret": {
+                    "description": "Lets the customer's client authorize the payment; only shown to the customer",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "provider_intent_id": {
+                    "type": "string"
+                },
+                "refunded_amount": {
+                    "description": "Returned by succeeded refunds",
+                    "type": "integer"
+                },
                 "status": {
-                    "description": "\"pending\", \"ready\", \"completed\", \"cancelled\"",
+                    "$ref": "#/definitions/models.PaymentStatus"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "handlers.UpdateStatusRequest": {
+        "models.PaymentStatus": {
+            "type": "string",
+            "enum": [
+                "requires_payment",
+                "failed",
+                "captured",
+                "cancelled",
+                "partially_refunded",
+                "refunded"
+            ],
+            "x-enum-comments": {
+                "PaymentStatusCancelled": "Order cancelled or expired before it was paid",
+                "PaymentStatusFailed": "Last attempt failed; the customer may retry until the order expires",
+                "PaymentStatusRefunded": "Returned in full",
+                "PaymentStatusRequiresPayment": "Waiting for the customer"
+            },
+            "x-enum-descriptions": [
+                "Waiting for the customer",
+                "Last attempt failed; the customer may retry until the order expires",
+                "",
+                "Order cancelled or expired before it was paid",
+                "",
+                "Returned in full"
+            ],
+            "x-enum-varnames": [
+                "PaymentStatusRequiresPayment",
+                "PaymentStatusFailed",
+                "PaymentStatusCaptured",
+                "PaymentStatusCancelled",
+                "PaymentStatusPartiallyRefunded",
+                "PaymentStatusRefunded"
+            ]
+        },
+        "models.PickupPass": {
             "type": "object",
             "properties": {
-                "is_active": {
-                    "type": "boolean"
+                "code": {
+                    "description": "Typed by restaurant staff",
+                    "type": "string"
+                },
+                "qr_payload": {
+                    "description": "Signed payload to render as a QR code",
+                    "type": "string"
                 }
             }
         },
-        "handlers.UpdateStockRequest": {
+        "models.PlatformImpact": {
             "type": "object",
             "properties": {
-                "quantity": {
-                    "description": "Can be positive (add) or negative (reduce)",
+                "from": {
+                    "$ref": "#/definitions/models.DateOnly"
+                },
+                "period": {
+                    "$ref": "#/definitions/models.ImpactPeriod"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImpactPoint"
+                    }
+                },
+                "to": {
+                    "$ref": "#/definitions/models.DateOnly"
+                },
+                "total": {
+                    "$ref": "#/definitions/models.Impact"
+                }
+            }
+        },
+        "models.PriceSchedule": {
+            "type": "object",
+            "properties": {
+                "floor_price": {
                     "type": "integer"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MarkdownStep"
+                    }
                 }
             }
         },
-        "handlers.VerifyTokenRequest": {
+        "models.Refund": {
             "type": "object",
             "properties": {
-                "supabase_token": {
+                "amount": {
+                    "description": "Smallest currency unit",
+                    "type": "integer"
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "decided_at": {
+                    "type": "string"
+                },
+                "decided_by": {
+                    "description": "Set when the refund is approved or rejected",
+                    "type": "string"
+                },
+                "decision_note": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "description": "Generic; provider errors are only logged",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "payment_id": {
+                    "type": "string"
+                },
+                "provider_refund_id": {
+                    "description": "Execution through the payment provider",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "$ref": "#/definitions/models.OrderActor"
+                },
+                "requester_id": {
+                    "description": "Nil for system refunds",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.RefundStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RefundStatus": {
+            "type": "string",
+            "enum": [
+                "requested",
+                "approved",
+                "rejected",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-comments": {
+                "RefundStatusApproved": "Queued for the payment provider, or pending there once provider_refund_id is set",
+                "RefundStatusFailed": "Last attempt through the provider failed; retried",
+                "RefundStatusRequested": "Asked for by the customer; waiting for the restaurant or an admin"
+            },
+            "x-enum-descriptions": [
+                "Asked for by the customer; waiting for the restaurant or an admin",
+                "Queued for the payment provider, or pending there once provider_refund_id is set",
+                "",
+                "",
+                "Last attempt through the provider failed; retried"
+            ],
+            "x-enum-varnames": [
+                "RefundStatusRequested",
+                "RefundStatusApproved",
+                "RefundStatusRejected",
+                "RefundStatusSucceeded",
+                "RefundStatusFailed"
+            ]
+        },
+        "models.ReservationStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "converted",
+                "released",
+                "expired"
+            ],
+            "x-enum-comments": {
+                "ReservationStatusConverted": "Used by an order",
+                "ReservationStatusReleased": "Given up by the customer"
+            },
+            "x-enum-descriptions": [
+                "",
+                "Used by an order",
+                "Given up by the customer",
+                ""
+            ],
+            "x-enum-varnames": [
+                "ReservationStatusActive",
+                "ReservationStatusConverted",
+                "ReservationStatusReleased",
+                "ReservationStatusExpired"
+            ]
+        },
+        "models.Restaurant": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "closing_time": {
+                    "$ref": "#/definitions/models.TimeOnly"
+                },
+                "closures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RestaurantClosure"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "daily_limit_per_customer": {
+                    "description": "Most items one customer may order for pickup on one local day; nil for no limit",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "impact": {
+                    "description": "Computed from completed orders for the detail endpoint, not stored",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Impact"
+                        }
+                    ]
+                },
+                "is_open_now": {
+                    "description": "Computed from the schedule, not stored",
+                    "type": "boolean"
+                },
+                "lat": {
+                    "type": "number"
+                },
+                "listings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Listing"
+                    }
+                },
+                "lng": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_open_at": {
+                    "type": "string"
+                },
+                "opening_hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OpeningHours"
+                    }
+                },
+                "owner": {
+                    "description": "Relationships",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ]
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "suspended_at": {
+                    "description": "Moderation",
+                    "type": "string"
+                },
+                "suspension_reason": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA name",
                     "type": "string"
                 }
             }
         },
-        "handlers.VerifyTokenResponse": {
+        "models.RestaurantClosure": {
             "type": "object",
             "properties": {
-                "token": {
+                "created_at": {
                     "type": "string"
                 },
-                "user": {}
+                "end_date": {
+                    "$ref": "#/definitions/models.DateOnly"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "restaurant_id": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "$ref": "#/definitions/models.DateOnly"
+                }
             }
         },
-        "models.Listing": {
+        "models.RestaurantInvitation": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "description": {
+                "email": {
                     "type": "string"
                 },
-                "id": {
+                "expires_at": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "name": {
-                    "description": "Nullable for mystery box",
+                "id": {
                     "type": "string"
                 },
-                "orders": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Order"
-                    }
-                },
-                "photo_url": {
+                "invited_by": {
                     "type": "string"
                 },
-                "pickup_time": {
-                    "$ref": "#/definitions/models.TimeOnly"
-                },
-                "price": {
-                    "description": "Price in smallest currency unit (e.g., cents)",
-                    "type": "integer"
+                "responded_at": {
+                    "type": "string"
                 },
                 "restaurant": {
                     "description": "Relationships",
@@ -940,26 +7334,15 @@ const docTemplate = `{
                 "restaurant_id": {
                     "type": "string"
                 },
-                "stock": {
-                    "type": "integer"
+                "role": {
+                    "$ref": "#/definitions/models.MemberRole"
                 },
-                "type": {
-                    "$ref": "#/definitions/models.ListingType"
+                "status": {
+                    "$ref": "#/definitions/models.InvitationStatus"
                 }
             }
         },
-        "models.ListingType": {
-            "type": "string",
-            "enum": [
-                "mystery_box",
-                "reveal"
-            ],
-            "x-enum-varnames": [
-                "ListingTypeMysteryBox",
-                "ListingTypeReveal"
-            ]
-        },
-        "models.Order": {
+        "models.RestaurantMember": {
             "type": "object",
             "properties": {
                 "created_at": {
@@ -968,21 +7351,14 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "listing": {
-                    "$ref": "#/definitions/models.Listing"
-                },
-                "listing_id": {
+                "invited_by": {
                     "type": "string"
                 },
-                "qty": {
-                    "type": "integer"
-                },
-                "status": {
-                    "$ref": "#/definitions/models.OrderStatus"
+                "restaurant_id": {
+                    "type": "string"
                 },
-                "total_price": {
-                    "description": "Total price in smallest currency unit",
-                    "type": "integer"
+                "role": {
+                    "$ref": "#/definitions/models.MemberRole"
                 },
                 "user": {
                     "description": "Relationships",
@@ -997,60 +7373,84 @@ const docTemplate = `{
                 }
             }
         },
-        "models.OrderStatus": {
-            "type": "string",
-            "enum": [
-                "pending",
-                "ready",
-                "completed",
-                "cancelled"
-            ],
-            "x-enum-varnames": [
-                "OrderStatusPending",
-                "OrderStatusReady",
-                "OrderStatusCompleted",
-                "OrderStatusCancelled"
-            ]
-        },
-        "models.Restaurant": {
+        "models.RestaurantSubscription": {
             "type": "object",
             "properties": {
-                "address": {
+                "created_at": {
                     "type": "string"
                 },
-                "closing_time": {
-                    "$ref": "#/definitions/models.TimeOnly"
+                "id": {
+                    "type": "string"
                 },
-                "created_at": {
+                "restaurant": {
+                    "description": "Relationships",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Restaurant"
+                        }
+                    ]
+                },
+                "restaurant_id": {
                     "type": "string"
                 },
-                "id": {
+                "user_id": {
                     "type": "string"
+                }
+            }
+        },
+        "models.SavingsSummary": {
+            "type": "object",
+            "properties": {
+                "items_rescued": {
+                    "type": "integer"
                 },
-                "lat": {
-                    "type": "number"
+                "orders_completed": {
+                    "type": "integer"
                 },
-                "listings": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Listing"
-                    }
+                "total_paid": {
+                    "description": "Smallest currency unit",
+                    "type": "integer"
                 },
-                "lng": {
-                    "type": "number"
+                "total_savings": {
+                    "description": "Smallest currency unit",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.StockReservation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "name": {
+                "expires_at": {
                     "type": "string"
                 },
-                "owner": {
+                "id": {
+                    "type": "string"
+                },
+                "listing": {
                     "description": "Relationships",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Listing"
                         }
                     ]
                 },
-                "owner_id": {
+                "listing_id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "description": "Set when the reservation is used by an order",
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.ReservationStatus"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
@@ -1093,6 +7493,21 @@ const docTemplate = `{
                 },
                 "role": {
                     "$ref": "#/definitions/models.UserRole"
+                },
+                "savings": {
+                    "description": "Computed for the profile endpoint, not stored",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SavingsSummary"
+                        }
+                    ]
+                },
+                "suspended_at": {
+                    "description": "Moderation",
+                    "type": "string"
+                },
+                "suspension_reason": {
+                    "type": "string"
                 }
             }
         },
@@ -1100,13 +7515,133 @@ const docTemplate = `{
             "type": "string",
             "enum": [
                 "user",
-                "restaurant"
+                "restaurant",
+                "admin"
             ],
             "x-enum-varnames": [
                 "RoleUser",
-                "RoleRestaurant"
+                "RoleRestaurant",
+                "RoleAdmin"
+            ]
+        },
+        "models.Waitlist": {
+            "type": "object",
+            "properties": {
+                "listings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WaitlistEntry"
+                    }
+                },
+                "restaurants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RestaurantSubscription"
+                    }
+                }
+            }
+        },
+        "models.WaitlistEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "hold_expires_at": {
+                    "description": "End of the hold while the reservation still holds the units; not stored",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "listing": {
+                    "description": "Relationships",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Listing"
+                        }
+                    ]
+                },
+                "listing_id": {
+                    "type": "string"
+                },
+                "notified_at": {
+                    "description": "Set when the customer is notified; the reservation holds units for them",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Place in line, counted from 1 while waiting; not stored",
+                    "type": "integer"
+                },
+                "qty": {
+                    "description": "Units wanted",
+                    "type": "integer"
+                },
+                "reservation_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.WaitlistStatus"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WaitlistStatus": {
+            "type": "string",
+            "enum": [
+                "waiting",
+                "notified"
+            ],
+            "x-enum-comments": {
+                "WaitlistStatusNotified": "Told the listing is back; no longer in line"
+            },
+            "x-enum-descriptions": [
+                "",
+                "Told the listing is back; no longer in line"
+            ],
+            "x-enum-varnames": [
+                "WaitlistStatusWaiting",
+                "WaitlistStatusNotified"
             ]
         },
+        "services.TokenPair": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "Access token lifetime in seconds",
+                    "type": "integer"
+                },
+                "refresh_expires_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.PaginatedData": {
+            "type": "object",
+            "properties": {
+                "items": {},
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "utils.Response": {
             "type": "object",
             "properties": {
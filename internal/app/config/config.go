@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -11,11 +13,16 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Supabase SupabaseConfig
-	JWT      JWTConfig
-	CORS     CORSConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Supabase  SupabaseConfig
+	JWT       JWTConfig
+	CORS      CORSConfig
+	Scheduler SchedulerConfig
+	Impact    ImpactConfig
+	Orders    OrderConfig
+	Events    EventsConfig
+	Payments  PaymentConfig
 }
 
 // ServerConfig holds server-specific configuration
@@ -34,12 +41,23 @@ type SupabaseConfig struct {
 	URL        string
 	Key        string
 	ServiceKey string
+
+	// JWTSecret verifies HS256-signed access tokens (legacy Supabase projects)
+	JWTSecret string
+	// JWKSURL and JWKSFile provide the public keys for RS256/ES256 access tokens
+	JWKSURL      string
+	JWKSFile     string
+	JWKSCacheTTL time.Duration
+	// Audience and Issuer are checked against the aud and iss claims
+	Audience string
+	Issuer   string
 }
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	Secret string
-	Expiry time.Duration
+	Secret        string
+	Expiry        time.Duration // Access token lifetime
+	RefreshExpiry time.Duration // Refresh token lifetime
 }
 
 // CORSConfig holds CORS configuration
@@ -47,6 +65,58 @@ type CORSConfig struct {
 	AllowedOrigins string
 }
 
+// SchedulerConfig holds background job configuration
+type SchedulerConfig struct {
+	Enabled     bool
+	Interval    time.Duration // How often each job runs
+	NoShowGrace time.Duration // How long after pickup ends an uncollected order becomes a no-show
+
+	WaitlistInterval time.Duration // How often waitlists of restocked listings are notified
+}
+
+// OrderConfig holds order configuration
+type OrderConfig struct {
+	CancelCutoff time.Duration // How long before pickup starts customers can still cancel
+
+	PickupSecret      string        // Signs pickup QR payloads; must differ from JWT_SECRET
+	PickupMaxAttempts int           // Invalid pickup codes allowed per order before a lockout
+	PickupLockout     time.Duration // How long pickup verification is locked after too many invalid codes
+
+	IdempotencyKeyTTL time.Duration // How long an Idempotency-Key replays its original response
+
+	ReservationTTL  time.Duration // How long reserved stock is held for a customer during checkout
+	MaxReservations int           // Active reservations a customer may hold at once
+	WaitlistHold    time.Duration // How long restocked units are held for customers first in line; 0 only notifies them
+}
+
+// EventsConfig holds real-time event configuration
+type EventsConfig struct {
+	PgNotify  bool   // Share events between instances with Postgres NOTIFY/LISTEN
+	ListenURL string // Connection for LISTEN; must not be a transaction-mode pooler
+
+	RecheckInterval  time.Duration // How often authenticated streams re-check the session and permissions
+	MaxPublicStreams int           // Open public stock streams allowed per instance
+}
+
+// PaymentConfig holds payment provider configuration
+type PaymentConfig struct {
+	Provider      string        // "fake" for the in-process provider, "none" to take orders without payment
+	WebhookSecret string        // Verifies webhook signatures; required with a provider and must differ from JWT_SECRET
+	Currency      string        // ISO 4217 code prices are charged in
+	Timeout       time.Duration // How long an unpaid order holds its stock before it is cancelled
+
+	RefundWindow      time.Duration // How long after the pickup window ends customers may request a refund
+	RefundMaxAttempts int           // Tries through the provider before a refund is left failed for an admin
+}
+
+// ImpactConfig holds the factors used to estimate food waste impact per item rescued
+type ImpactConfig struct {
+	MysteryBoxKg        float64 // Estimated food weight of one mystery box
+	RevealKg            float64 // Estimated food weight of one reveal item
+	MysteryBoxCO2ePerKg float64 // kg CO2e avoided per kg of mystery box food
+	RevealCO2ePerKg     float64 // kg CO2e avoided per kg of reveal item food
+}
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
 	// Load .env file if it exists (ignore error in production)
@@ -61,17 +131,68 @@ func LoadConfig() (*Config, error) {
 			URL: getEnv("DATABASE_URL", ""),
 		},
 		Supabase: SupabaseConfig{
-			URL:        getEnv("SUPABASE_URL", ""),
-			Key:        getEnv("SUPABASE_KEY", ""),
-			ServiceKey: getEnv("SUPABASE_SERVICE_KEY", ""),
+			URL:          getEnv("SUPABASE_URL", ""),
+			Key:          getEnv("SUPABASE_KEY", ""),
+			ServiceKey:   getEnv("SUPABASE_SERVICE_KEY", ""),
+			JWTSecret:    getEnv("SUPABASE_JWT_SECRET", ""),
+			JWKSURL:      getEnv("SUPABASE_JWKS_URL", ""),
+			JWKSFile:     getEnv("SUPABASE_JWKS_FILE", ""),
+			JWKSCacheTTL: parseDuration(getEnv("SUPABASE_JWKS_CACHE_TTL", "10m")),
+			Audience:     getEnv("SUPABASE_JWT_AUDIENCE", "authenticated"),
+			Issuer:       getEnv("SUPABASE_JWT_ISSUER", ""),
 		},
 		JWT: JWTConfig{
-			Secret: getEnv("JWT_SECRET", ""),
-			Expiry: parseDuration(getEnv("JWT_EXPIRY", "24h")),
+			Secret:        getEnv("JWT_SECRET", ""),
+			Expiry:        parseDuration(getEnv("JWT_EXPIRY", "15m")),
+			RefreshExpiry: parseDuration(getEnv("REFRESH_TOKEN_EXPIRY", "720h")),
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: getEnv("ALLOWED_ORIGINS", "http://localhost:4200"),
 		},
+		Scheduler: SchedulerConfig{
+			Enabled:     getEnv("SCHEDULER_ENABLED", "true") == "true",
+			Interval:    parseDuration(getEnv("SCHEDULER_INTERVAL", "1m")),
+			NoShowGrace: parseDuration(getEnv("NO_SHOW_GRACE", "30m")),
+
+			WaitlistInterval: parseDuration(getEnv("WAITLIST_INTERVAL", "15s")),
+		},
+		Orders: OrderConfig{
+			CancelCutoff:      parseDuration(getEnv("ORDER_CANCEL_CUTOFF", "1h")),
+			PickupSecret:      getEnv("PICKUP_QR_SECRET", ""),
+			PickupMaxAttempts: parseInt(getEnv("PICKUP_MAX_ATTEMPTS", "5"), 5),
+			PickupLockout:     parseDuration(getEnv("PICKUP_LOCKOUT", "15m")),
+			IdempotencyKeyTTL: parseDuration(getEnv("IDEMPOTENCY_KEY_TTL", "24h")),
+			ReservationTTL:    parseDuration(getEnv("RESERVATION_TTL", "5m")),
+			MaxReservations:   parseInt(getEnv("MAX_ACTIVE_RESERVATIONS", "10"), 10),
+			WaitlistHold:      parseDuration(getEnv("WAITLIST_HOLD", "10m")),
+		},
+		Events: EventsConfig{
+			PgNotify:  getEnv("EVENTS_PG_NOTIFY", "true") == "true",
+			ListenURL: getEnv("EVENTS_DATABASE_URL", getEnv("DATABASE_URL", "")),
+
+			RecheckInterval:  parseDuration(getEnv("EVENTS_RECHECK_INTERVAL", "1m")),
+			MaxPublicStreams: parseInt(getEnv("EVENTS_MAX_PUBLIC_STREAMS", "1000"), 1000),
+		},
+		Payments: PaymentConfig{
+			Provider:      getEnv("PAYMENT_PROVIDER", "none"),
+			WebhookSecret: getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+			Currency:      getEnv("PAYMENT_CURRENCY", "IDR"),
+			Timeout:       parseDuration(getEnv("PAYMENT_TIMEOUT", "15m")),
+
+			RefundWindow:      parseDuration(getEnv("REFUND_WINDOW", "72h")),
+			RefundMaxAttempts: parseInt(getEnv("REFUND_MAX_ATTEMPTS", "5"), 5),
+		},
+		Impact: ImpactConfig{
+			MysteryBoxKg:        parseFloat(getEnv("IMPACT_MYSTERY_BOX_KG", "1.0"), 1.0),
+			RevealKg:            parseFloat(getEnv("IMPACT_REVEAL_KG", "0.5"), 0.5),
+			MysteryBoxCO2ePerKg: parseFloat(getEnv("IMPACT_MYSTERY_BOX_CO2E_PER_KG", "2.5"), 2.5),
+			RevealCO2ePerKg:     parseFloat(getEnv("IMPACT_REVEAL_CO2E_PER_KG", "2.5"), 2.5),
+		},
+	}
+
+	// Supabase issues access tokens from its auth endpoint by default
+	if config.Supabase.Issuer == "" && config.Supabase.URL != "" {
+		config.Supabase.Issuer = strings.TrimRight(config.Supabase.URL, "/") + "/auth/v1"
 	}
 
 	// Validate required fields
@@ -94,9 +215,47 @@ func (c *Config) Validate() error {
 	if c.Supabase.Key == "" {
 		return fmt.Errorf("SUPABASE_KEY is required")
 	}
+	if c.Supabase.JWTSecret == "" && c.Supabase.JWKSURL == "" && c.Supabase.JWKSFile == "" {
+		return fmt.Errorf("SUPABASE_JWT_SECRET, SUPABASE_JWKS_URL or SUPABASE_JWKS_FILE is required")
+	}
 	if c.JWT.Secret == "" {
 		return fmt.Errorf("JWT_SECRET is required")
 	}
+	if c.Orders.PickupSecret == "" {
+		return fmt.Errorf("PICKUP_QR_SECRET is required")
+	}
+	if c.Orders.PickupSecret == c.JWT.Secret {
+		return fmt.Errorf("PICKUP_QR_SECRET must differ from JWT_SECRET")
+	}
+	if c.Scheduler.Interval <= 0 {
+		return fmt.Errorf("SCHEDULER_INTERVAL must be greater than zero")
+	}
+	if c.Scheduler.WaitlistInterval <= 0 {
+		return fmt.Errorf("WAITLIST_INTERVAL must be greater than zero")
+	}
+	if c.Orders.IdempotencyKeyTTL <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_KEY_TTL must be greater than zero")
+	}
+	if c.Events.RecheckInterval <= 0 {
+		return fmt.Errorf("EVENTS_RECHECK_INTERVAL must be greater than zero")
+	}
+	switch c.Payments.Provider {
+	case "none":
+	case "fake":
+		if c.Server.Env == "production" {
+			return fmt.Errorf("PAYMENT_PROVIDER=fake must not be used in production")
+		}
+	default:
+		return fmt.Errorf("PAYMENT_PROVIDER %q is not supported", c.Payments.Provider)
+	}
+	if c.Payments.Provider != "none" {
+		if c.Payments.WebhookSecret == "" {
+			return fmt.Errorf("PAYMENT_WEBHOOK_SECRET is required with PAYMENT_PROVIDER=%s", c.Payments.Provider)
+		}
+		if c.Payments.WebhookSecret == c.JWT.Secret || c.Payments.WebhookSecret == c.Orders.PickupSecret {
+			return fmt.Errorf("PAYMENT_WEBHOOK_SECRET must differ from JWT_SECRET and PICKUP_QR_SECRET")
+		}
+	}
 	return nil
 }
 
@@ -118,3 +277,23 @@ func parseDuration(s string) time.Duration {
 	}
 	return duration
 }
+
+// parseFloat parses a non-negative number, returns defaultValue on error
+func parseFloat(s string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil || value < 0 {
+		log.Printf("⚠️  Invalid number '%s', using default %v", s, defaultValue)
+		return defaultValue
+	}
+	return value
+}
+
+// parseInt parses a positive integer, returns defaultValue on error
+func parseInt(s string, defaultValue int) int {
+	value, err := strconv.Atoi(s)
+	if err != nil || value <= 0 {
+		log.Printf("⚠️  Invalid number '%s', using default %d", s, defaultValue)
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,100 @@
+package events
+
+import "sync"
+
+// subscriptionBuffer is how many events a subscriber may lag behind before
+// further events are dropped for it
+const subscriptionBuffer = 64
+
+// Bus delivers events to subscribers
+type Bus interface {
+	Publish(event Event)
+	Subscribe(filter Filter) *Subscription
+	Close()
+}
+
+// Filter selects the events a subscriber receives
+type Filter func(event Event) bool
+
+// Subscription receives matching events on C until it is closed
+type Subscription struct {
+	C <-chan Event
+
+	ch     chan Event
+	filter Filter
+	bus    *LocalBus
+}
+
+// Close stops the subscription and releases it from the bus
+func (s *Subscription) Close() {
+	s.bus.unsubscribe(s)
+}
+
+// LocalBus delivers events to subscribers in this process
+type LocalBus struct {
+	mu     sync.RWMutex
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+// NewLocalBus creates a new in-process bus
+func NewLocalBus() *LocalBus {
+	return &LocalBus{subs: make(map[*Subscription]struct{})}
+}
+
+// Publish delivers the event to every matching subscriber. Subscribers that
+// are too far behind miss the event rather than block the publisher.
+func (b *LocalBus) Publish(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subs {
+		if sub.filter != nil && !sub.filter(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe registers a subscriber for events matching filter (nil for all).
+// The subscription's channel is closed at once if the bus is closed.
+func (b *LocalBus) Subscribe(filter Filter) *Subscription {
+	ch := make(chan Event, subscriptionBuffer)
+	sub := &Subscription{C: ch, ch: ch, filter: filter, bus: b}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(ch)
+		return sub
+	}
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+// Close closes every subscription so open streams end, e.g. on shutdown
+func (b *LocalBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for sub := range b.subs {
+		close(sub.ch)
+		delete(b.subs, sub)
+	}
+}
+
+// unsubscribe removes a subscription and closes its channel
+func (b *LocalBus) unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[sub]; ok {
+		close(sub.ch)
+		delete(b.subs, sub)
+	}
+}
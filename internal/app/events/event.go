@@ -0,0 +1,80 @@
+package events
+
+import (
+	"time"
+
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+)
+
+// Type identifies what changed
+type Type string
+
+const (
+	OrderCreated        Type = "order.created"
+	OrderStatusChanged  Type = "order.status_changed"
+	ListingStockChanged Type = "listing.stock_changed"
+	ListingBackInStock  Type = "listing.back_in_stock"
+)
+
+// Event is a change notification. It carries identifiers and the new state
+// only; clients fetch full resources through the API.
+type Event struct {
+	Type         Type               `json:"type"`
+	RestaurantID uuid.UUID          `json:"restaurant_id"`
+	ListingID    *uuid.UUID         `json:"listing_id,omitempty"`
+	OrderID      *uuid.UUID         `json:"order_id,omitempty"`
+	UserID       *uuid.UUID         `json:"user_id,omitempty"` // Customer of the order or notification
+	Status       models.OrderStatus `json:"status,omitempty"`
+	Qty          int                `json:"qty,omitempty"`
+	Stock        *int               `json:"stock,omitempty"`
+	At           time.Time          `json:"at"`
+
+	// Units held for the customer on listing.back_in_stock, if any
+	ReservationID *uuid.UUID `json:"reservation_id,omitempty"`
+}
+
+// OrderEvent describes an order event
+func OrderEvent(eventType Type, order *models.Order) Event {
+	return Event{
+		Type:         eventType,
+		RestaurantID: order.RestaurantID,
+		OrderID:      &order.ID,
+		UserID:       &order.UserID,
+		Status:       order.Status,
+		Qty:          order.Qty,
+		At:           time.Now(),
+	}
+}
+
+// StockEvent describes a listing's new stock
+func StockEvent(listing *models.Listing) Event {
+	stock := listing.Stock
+	listingID := listing.ID
+	return Event{
+		Type:         ListingStockChanged,
+		RestaurantID: listing.RestaurantID,
+		ListingID:    &listingID,
+		Stock:        &stock,
+		At:           time.Now(),
+	}
+}
+
+// WaitlistEvent tells a customer a listing they wait for is back in stock
+func WaitlistEvent(n models.WaitlistNotification) Event {
+	listingID := n.Listing.ID
+	userID := n.UserID
+	event := Event{
+		Type:         ListingBackInStock,
+		RestaurantID: n.Listing.RestaurantID,
+		ListingID:    &listingID,
+		UserID:       &userID,
+		Qty:          n.Qty,
+		At:           time.Now(),
+	}
+	if n.Reservation != nil {
+		event.ReservationID = &n.Reservation.ID
+	}
+	return event
+}
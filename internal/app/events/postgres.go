@@ -0,0 +1,132 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+// notifyChannel is the Postgres channel events are sent on
+const notifyChannel = "eatright_events"
+
+// maxListenBackoff caps the delay between listener reconnection attempts
+const maxListenBackoff = 30 * time.Second
+
+// PostgresBus shares events between server instances with Postgres
+// NOTIFY/LISTEN. Each instance sends events with NOTIFY and delivers what its
+// listener receives, including its own events, to local subscribers. While the
+// listener is disconnected, events are delivered locally right away.
+type PostgresBus struct {
+	*LocalBus
+	db        *gorm.DB
+	listenURL string
+	listening atomic.Bool
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewPostgresBus creates a bus that publishes through db and listens on a
+// dedicated connection to listenURL, which must support session-level LISTEN
+// (a direct connection or session-mode pooler, not a transaction-mode pooler)
+func NewPostgresBus(db *gorm.DB, listenURL string) *PostgresBus {
+	return &PostgresBus{
+		LocalBus:  NewLocalBus(),
+		db:        db,
+		listenURL: listenURL,
+		done:      make(chan struct{}),
+	}
+}
+
+// Start connects the listener in the background, reconnecting on failure
+func (b *PostgresBus) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	go b.listen(ctx)
+}
+
+// Publish sends the event to every instance
+func (b *PostgresBus) Publish(event Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("⚠️  Failed to encode %s event: %v", event.Type, err)
+		return
+	}
+
+	err = b.db.Exec("SELECT pg_notify(?, ?)", notifyChannel, string(payload)).Error
+	if err != nil {
+		log.Printf("⚠️  Failed to notify %s event: %v", event.Type, err)
+	}
+	if err != nil || !b.listening.Load() {
+		b.LocalBus.Publish(event)
+	}
+}
+
+// Close stops the listener and closes every subscription
+func (b *PostgresBus) Close() {
+	if b.cancel != nil {
+		b.cancel()
+		<-b.done
+	}
+	b.LocalBus.Close()
+}
+
+// listen delivers notifications to local subscribers until ctx is cancelled
+func (b *PostgresBus) listen(ctx context.Context) {
+	defer close(b.done)
+
+	backoff := time.Second
+	for {
+		err := b.listenOnce(ctx)
+		if b.listening.Swap(false) {
+			// The connection was healthy; retry quickly
+			backoff = time.Second
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("⚠️  Event listener disconnected: %v (retrying in %s)", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < maxListenBackoff {
+			backoff *= 2
+		}
+	}
+}
+
+// listenOnce holds one LISTEN connection until it fails
+func (b *PostgresBus) listenOnce(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, b.listenURL)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return err
+	}
+	b.listening.Store(true)
+	log.Println("📡 Listening for events on Postgres channel " + notifyChannel)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Printf("⚠️  Ignoring malformed event: %v", err)
+			continue
+		}
+		b.LocalBus.Publish(event)
+	}
+}
@@ -0,0 +1,335 @@
+package handlers
+
+import (
+	"strconv"
+
+	"eatright-backend/internal/app/middlewares"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+	"eatright-backend/internal/app/services"
+	"eatright-backend/internal/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// AdminHandler handles platform moderation endpoints
+type AdminHandler struct {
+	adminService services.AdminService
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(adminService services.AdminService) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+	}
+}
+
+// SuspendRequest represents the request body for suspending a user or restaurant
+type SuspendRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ListUsers lists and searches users
+// @Summary List users
+// @Description Lists users with optional search by name/email, role and suspension filters (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Search by name or email"
+// @Param role query string false "Filter by role (user, restaurant, admin)"
+// @Param suspended query bool false "Filter by suspension state"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} utils.Response{data=utils.PaginatedData{items=[]models.User}} "Users retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid parameters"
+// @Failure 403 {object} utils.Response "Admin role required"
+// @Router /admin/users [get]
+func (h *AdminHandler) ListUsers(c *fiber.Ctx) error {
+	page, limit := parsePagination(c)
+
+	filter := repositories.UserFilter{
+		Query:  c.Query("q"),
+		Role:   models.UserRole(c.Query("role")),
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+
+	if filter.Role != "" &&
+		filter.Role != models.RoleUser &&
+		filter.Role != models.RoleRestaurant &&
+		filter.Role != models.RoleAdmin {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid role", nil)
+	}
+
+	if suspendedStr := c.Query("suspended"); suspendedStr != "" {
+		suspended, err := strconv.ParseBool(suspendedStr)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid suspended parameter", err)
+		}
+		filter.Suspended = &suspended
+	}
+
+	users, total, err := h.adminService.SearchUsers(filter)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get users", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Users retrieved successfully", utils.PaginatedData{
+		Items: users,
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	})
+}
+
+// GetUser retrieves a user by ID
+// @Summary Get user
+// @Description Retrieves any user's profile (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} utils.Response{data=models.User} "User retrieved successfully"
+// @Failure 404 {object} utils.Response "User not found"
+// @Router /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID", err)
+	}
+
+	user, err := h.adminService.GetUser(id)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "User retrieved successfully", user)
+}
+
+// SuspendUser suspends a user account
+// @Summary Suspend user
+// @Description Suspends a user; their existing tokens are rejected immediately (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Param request body SuspendRequest false "Suspension Reason"
+// @Success 200 {object} utils.Response "User suspended successfully"
+// @Failure 400 {object} utils.Response "Cannot suspend yourself"
+// @Failure 403 {object} utils.Response "Admins cannot be suspended"
+// @Failure 404 {object} utils.Response "User not found"
+// @Router /admin/users/{id}/suspend [post]
+func (h *AdminHandler) SuspendUser(c *fiber.Ctx) error {
+	adminID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID", err)
+	}
+
+	var req SuspendRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+		}
+	}
+
+	if err := h.adminService.SuspendUser(id, adminID, req.Reason); err != nil {
+		switch err {
+		case models.ErrInvalidInput:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "You cannot suspend yourself", err)
+		case models.ErrUnauthorized:
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Admins cannot be suspended", err)
+		case models.ErrNotFound:
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to suspend user", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "User suspended successfully", nil)
+}
+
+// UnsuspendUser reinstates a suspended user
+// @Summary Unsuspend user
+// @Description Reinstates a suspended user (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} utils.Response "User reinstated successfully"
+// @Failure 404 {object} utils.Response "User not found"
+// @Router /admin/users/{id}/unsuspend [post]
+func (h *AdminHandler) UnsuspendUser(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID", err)
+	}
+
+	if err := h.adminService.UnsuspendUser(id); err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to reinstate user", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "User reinstated successfully", nil)
+}
+
+// SuspendRestaurant suspends a restaurant
+// @Summary Suspend restaurant
+// @Description Suspends a restaurant; it is hidden from listings and cannot take orders (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Param request body SuspendRequest false "Suspension Reason"
+// @Success 200 {object} utils.Response "Restaurant suspended successfully"
+// @Failure 404 {object} utils.Response "Restaurant not found"
+// @Router /admin/restaurants/{id}/suspend [post]
+func (h *AdminHandler) SuspendRestaurant(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	var req SuspendRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+		}
+	}
+
+	if err := h.adminService.SuspendRestaurant(id, req.Reason); err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Restaurant not found", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to suspend restaurant", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Restaurant suspended successfully", nil)
+}
+
+// UnsuspendRestaurant reinstates a suspended restaurant
+// @Summary Unsuspend restaurant
+// @Description Reinstates a suspended restaurant (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Success 200 {object} utils.Response "Restaurant reinstated successfully"
+// @Failure 404 {object} utils.Response "Restaurant not found"
+// @Router /admin/restaurants/{id}/unsuspend [post]
+func (h *AdminHandler) UnsuspendRestaurant(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	if err := h.adminService.UnsuspendRestaurant(id); err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Restaurant not found", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to reinstate restaurant", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Restaurant reinstated successfully", nil)
+}
+
+// DeactivateListing force-deactivates a listing
+// @Summary Force-deactivate listing
+// @Description Deactivates any listing regardless of ownership (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Listing ID (UUID)"
+// @Success 200 {object} utils.Response "Listing deactivated successfully"
+// @Failure 404 {object} utils.Response "Listing not found"
+// @Router /admin/listings/{id}/deactivate [post]
+func (h *AdminHandler) DeactivateListing(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid listing ID", err)
+	}
+
+	if err := h.adminService.DeactivateListing(id); err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Listing not found", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to deactivate listing", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Listing deactivated successfully", nil)
+}
+
+// CancelOrder cancels any order
+// @Summary Cancel order
+// @Description Cancels any order regardless of ownership and returns its quantity to stock; paid orders are refunded in full (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID (UUID)"
+// @Param request body CancelOrderRequest false "Cancellation Reason"
+// @Success 200 {object} utils.Response "Order cancelled successfully"
+// @Failure 400 {object} utils.Response "Invalid status transition"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Router /admin/orders/{id}/cancel [post]
+func (h *AdminHandler) CancelOrder(c *fiber.Ctx) error {
+	adminID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID", err)
+	}
+
+	var req CancelOrderRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+		}
+	}
+
+	if err := h.adminService.CancelOrder(id, adminID, req.Reason); err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found", err)
+		}
+		if err == models.ErrInvalidStatusTransition {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid status transition", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to cancel order", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Order cancelled successfully", nil)
+}
+
+// parsePagination reads page and limit query parameters with sane bounds
+func parsePagination(c *fiber.Ctx) (int, int) {
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+
+	limit := c.QueryInt("limit", 20)
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return page, limit
+}
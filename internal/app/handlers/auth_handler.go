@@ -1,6 +1,11 @@
 package handlers
 
 import (
+	"errors"
+	"time"
+
+	"eatright-backend/internal/app/middlewares"
+	"eatright-backend/internal/app/models"
 	"eatright-backend/internal/app/services"
 	"eatright-backend/internal/app/utils"
 
@@ -22,12 +27,32 @@ func NewAuthHandler(authService services.AuthService) *AuthHandler {
 // VerifyTokenRequest represents the request body for token verification
 type VerifyTokenRequest struct {
 	SupabaseToken string `json:"supabase_token"`
+	DeviceName    string `json:"device_name"` // Optional, shown in session listings
 }
 
 // VerifyTokenResponse represents the response for token verification
 type VerifyTokenResponse struct {
-	Token string      `json:"token"`
-	User  interface{} `json:"user"`
+	Token            string      `json:"token"`
+	RefreshToken     string      `json:"refresh_token"`
+	TokenType        string      `json:"token_type"`
+	ExpiresIn        int64       `json:"expires_in"`
+	RefreshExpiresAt time.Time   `json:"refresh_expires_at"`
+	User             interface{} `json:"user"`
+}
+
+// RefreshTokenRequest represents the request body for refreshing and revoking sessions
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+	DeviceName   string `json:"device_name"`
+}
+
+// sessionMetadata collects client information stored with a refresh token
+func sessionMetadata(c *fiber.Ctx, deviceName string) models.SessionMetadata {
+	return models.SessionMetadata{
+		UserAgent:  c.Get(fiber.HeaderUserAgent),
+		DeviceName: deviceName,
+		IPAddress:  c.IP(),
+	}
 }
 
 // VerifyToken verifies a Supabase token and returns a backend JWT
@@ -38,8 +63,11 @@ type VerifyTokenResponse struct {
 // @Produce json
 // @Param request body VerifyTokenRequest true "Supabase Token"
 // @Success 200 {object} utils.Response{data=VerifyTokenResponse} "Authentication successful"
-// @Failure 400 {object} utils.Response "Invalid request body"
-// @Failure 401 {object} utils.Response "Token verification failed"
+// @Failure 400 {object} utils.Response "Invalid request body or malformed token"
+// @Failure 401 {object} utils.Response "Token expired, signature invalid or claims rejected"
+// @Failure 403 {object} utils.Response "Account suspended"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Failure 503 {object} utils.Response "Identity provider keys unavailable"
 // @Router /auth/verify [post]
 func (h *AuthHandler) VerifyToken(c *fiber.Ctx) error {
 	var req VerifyTokenRequest
@@ -55,16 +83,140 @@ func (h *AuthHandler) VerifyToken(c *fiber.Ctx) error {
 	}
 
 	// Verify token and get/create user
-	user, jwtToken, err := h.authService.VerifySupabaseToken(req.SupabaseToken)
+	user, tokens, err := h.authService.VerifySupabaseToken(req.SupabaseToken, sessionMetadata(c, req.DeviceName))
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Token verification failed", err)
+		switch {
+		case errors.Is(err, models.ErrTokenExpired):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Supabase token has expired", err)
+		case errors.Is(err, models.ErrTokenInvalidSignature):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Supabase token signature is invalid", err)
+		case errors.Is(err, models.ErrTokenMalformed):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Supabase token is malformed", err)
+		case errors.Is(err, models.ErrTokenInvalidClaims):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Supabase token claims are invalid", err)
+		case errors.Is(err, models.ErrAccountSuspended):
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Account has been suspended", err)
+		case errors.Is(err, models.ErrIdentityUnavailable):
+			return utils.ErrorResponse(c, fiber.StatusServiceUnavailable, "Identity provider is unavailable, try again later", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Token verification failed", err)
 	}
 
 	// Return JWT and user info
 	response := VerifyTokenResponse{
-		Token: jwtToken,
-		User:  user,
+		Token:            tokens.AccessToken,
+		RefreshToken:     tokens.RefreshToken,
+		TokenType:        tokens.TokenType,
+		ExpiresIn:        tokens.ExpiresIn,
+		RefreshExpiresAt: tokens.RefreshExpiresAt,
+		User:             user,
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Authentication successful", response)
 }
+
+// RefreshToken rotates a refresh token and returns a new token pair
+// @Summary Refresh access token
+// @Description Exchanges a refresh token for a new access token and a new refresh token. Reusing an already rotated refresh token revokes the whole session.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body RefreshTokenRequest true "Refresh Token"
+// @Success 200 {object} utils.Response{data=services.TokenPair} "Token refreshed successfully"
+// @Failure 400 {object} utils.Response "Invalid request body"
+// @Failure 401 {object} utils.Response "Refresh token invalid, expired or reused"
+// @Failure 403 {object} utils.Response "Account suspended"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
+	var req RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	if req.RefreshToken == "" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "refresh_token is required", nil)
+	}
+
+	tokens, err := h.authService.RefreshSession(req.RefreshToken, sessionMetadata(c, req.DeviceName))
+	if err != nil {
+		switch err {
+		case models.ErrTokenExpired:
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Refresh token has expired", err)
+		case models.ErrRefreshTokenReused:
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Refresh token reuse detected, session revoked", err)
+		case models.ErrInvalidRefreshToken:
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid refresh token", err)
+		case models.ErrAccountSuspended:
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Account has been suspended", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to refresh token", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Token refreshed successfully", tokens)
+}
+
+// Logout revokes the current access token and its refresh token session
+// @Summary Logout
+// @Description Revokes the current access token and, if provided, the session of the given refresh token
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body RefreshTokenRequest false "Refresh Token"
+// @Success 200 {object} utils.Response "Logged out successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Refresh token belongs to another user"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	tokenID, err := middlewares.GetTokenID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	var req RefreshTokenRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+		}
+	}
+
+	if err := h.authService.Logout(userID, req.RefreshToken, tokenID, middlewares.GetTokenExpiresAt(c)); err != nil {
+		if err == models.ErrInvalidRefreshToken {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid refresh token", err)
+		}
+		if err == models.ErrUnauthorized {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Refresh token belongs to another user", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to logout", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Logged out successfully", nil)
+}
+
+// LogoutAll revokes every session of the authenticated user
+// @Summary Logout from all devices
+// @Description Revokes all refresh tokens and outstanding access tokens of the authenticated user
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response "Logged out from all devices"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Router /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	if err := h.authService.LogoutAll(userID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to logout from all devices", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Logged out from all devices", nil)
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"eatright-backend/internal/app/middlewares"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/services"
+	"eatright-backend/internal/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// CartHandler handles cart endpoints
+type CartHandler struct {
+	cartService services.CartService
+}
+
+// NewCartHandler creates a new cart handler
+func NewCartHandler(cartService services.CartService) *CartHandler {
+	return &CartHandler{
+		cartService: cartService,
+	}
+}
+
+// SetCartItemRequest represents the request body for putting a listing in the cart
+type SetCartItemRequest struct {
+	Qty int `json:"qty"`
+}
+
+// GetCart retrieves the authenticated user's cart
+// @Summary Get my cart
+// @Description Retrieves the cart with totals at current listing prices. Prices are charged at checkout and may drop before then.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=models.Cart} "Cart retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Router /cart [get]
+func (h *CartHandler) GetCart(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	cart, err := h.cartService.GetCart(userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get cart", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Cart retrieved successfully", cart)
+}
+
+// SetItem puts a listing in the cart
+// @Summary Set cart item
+// @Description Puts a listing in the cart or replaces its quantity. A cart holds listings from one restaurant only; clear it to order from another. Stock is not reserved until checkout.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param listingId path string true "Listing ID (UUID)"
+// @Param request body SetCartItemRequest true "Quantity"
+// @Success 200 {object} utils.Response{data=models.Cart} "Cart updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request or listing cannot be ordered"
+// @Failure 404 {object} utils.Response "Listing not found"
+// @Failure 409 {object} utils.Response "Cart holds listings from another restaurant"
+// @Failure 422 {object} utils.Response "Quantity exceeds the listing's purchase limit"
+// @Router /cart/items/{listingId} [put]
+func (h *CartHandler) SetItem(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	listingID, err := uuid.Parse(c.Params("listingId"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid listing ID", err)
+	}
+
+	var req SetCartItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+	if req.Qty <= 0 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "qty must be greater than 0", nil)
+	}
+
+	cart, err := h.cartService.SetItem(userID, listingID, req.Qty)
+	if err != nil {
+		switch err {
+		case models.ErrNotFound:
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Listing not found", err)
+		case models.ErrCartRestaurantMismatch:
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Cart holds listings from another restaurant", err)
+		case models.ErrInsufficientStock:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Insufficient stock available", err)
+		case models.ErrInvalidInput:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Listing is not active", err)
+		case models.ErrRestaurantSuspended:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Restaurant is currently unavailable", err)
+		case models.ErrPickupWindowClosed:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Pickup window for this listing has ended", err)
+		case models.ErrListingLimitReached:
+			return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "Quantity exceeds the purchase limit for this listing", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update cart", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Cart updated successfully", cart)
+}
+
+// RemoveItem removes a listing from the cart
+// @Summary Remove cart item
+// @Description Removes a listing from the cart
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param listingId path string true "Listing ID (UUID)"
+// @Success 200 {object} utils.Response{data=models.Cart} "Cart updated successfully"
+// @Failure 400 {object} utils.Response "Invalid listing ID"
+// @Failure 404 {object} utils.Response "Listing not in cart"
+// @Router /cart/items/{listingId} [delete]
+func (h *CartHandler) RemoveItem(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	listingID, err := uuid.Parse(c.Params("listingId"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid listing ID", err)
+	}
+
+	cart, err := h.cartService.RemoveItem(userID, listingID)
+	if err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Listing not in cart", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update cart", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Cart updated successfully", cart)
+}
+
+// Clear empties the cart
+// @Summary Clear cart
+// @Description Removes every item from the cart
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response "Cart cleared successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Router /cart [delete]
+func (h *CartHandler) Clear(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	if err := h.cartService.Clear(userID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to clear cart", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Cart cleared successfully", nil)
+}
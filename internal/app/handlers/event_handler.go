@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/events"
+	"eatright-backend/internal/app/middlewares"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/services"
+	"eatright-backend/internal/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// sseHeartbeat is how often an idle stream sends a comment to keep proxies from
+// closing it and to notice disconnected clients
+const sseHeartbeat = 25 * time.Second
+
+// EventHandler handles Server-Sent Events streams
+type EventHandler struct {
+	eventService services.EventService
+	authService  services.AuthService
+	recheck      time.Duration
+	publicSlots  chan struct{} // Holds one value per open public stream
+}
+
+// NewEventHandler creates a new event handler
+func NewEventHandler(eventService services.EventService, authService services.AuthService, cfg *config.Config) *EventHandler {
+	return &EventHandler{
+		eventService: eventService,
+		authService:  authService,
+		recheck:      cfg.Events.RecheckInterval,
+		publicSlots:  make(chan struct{}, cfg.Events.MaxPublicStreams),
+	}
+}
+
+// StreamRestaurantOrders streams a restaurant's order events
+// @Summary Stream restaurant orders
+// @Description Server-Sent Events stream of order.created and order.status_changed events for a restaurant (restaurant members who may view orders). The stream closes once the session ends or the permission is lost.
+// @Tags Events
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Success 200 {object} events.Event "Event stream"
+// @Failure 400 {object} utils.Response "Invalid restaurant ID"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Router /restaurants/{id}/orders/stream [get]
+func (h *EventHandler) StreamRestaurantOrders(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	checkSession, err := h.sessionCheck(c, userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	sub, err := h.eventService.SubscribeRestaurantOrders(restaurantID, userID)
+	if err != nil {
+		if err == models.ErrUnauthorized {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to subscribe to orders", err)
+	}
+
+	return h.stream(c, sub, func() error {
+		if err := checkSession(); err != nil {
+			return err
+		}
+		return h.eventService.AuthorizeRestaurantOrders(restaurantID, userID)
+	}, nil)
+}
+
+// StreamMyOrders streams events about the authenticated user's orders
+// @Summary Stream my orders
+// @Description Server-Sent Events stream of order.created and order.status_changed events for the authenticated user's orders. The stream closes once the session ends.
+// @Tags Events
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Success 200 {object} events.Event "Event stream"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Router /orders/me/stream [get]
+func (h *EventHandler) StreamMyOrders(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	checkSession, err := h.sessionCheck(c, userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	return h.stream(c, h.eventService.SubscribeUserOrders(userID), checkSession, nil)
+}
+
+// StreamMyWaitlist streams the authenticated user's back-in-stock notifications
+// @Summary Stream my waitlist
+// @Description Server-Sent Events stream of listing.back_in_stock events for listings the authenticated user waits for or whose restaurant they subscribe to. reservation_id is set when units are held for the user. The stream closes once the session ends.
+// @Tags Events
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Success 200 {object} events.Event "Event stream"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Router /waitlist/me/stream [get]
+func (h *EventHandler) StreamMyWaitlist(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	checkSession, err := h.sessionCheck(c, userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	return h.stream(c, h.eventService.SubscribeWaitlist(userID), checkSession, nil)
+}
+
+// StreamListingStock streams listing stock changes
+// @Summary Stream listing stock
+// @Description Server-Sent Events stream of listing.stock_changed events, optionally for one restaurant. Each instance serves a limited number of these streams at once.
+// @Tags Events
+// @Produce text/event-stream
+// @Param restaurant_id query string false "Only this restaurant's listings (UUID)"
+// @Success 200 {object} events.Event "Event stream"
+// @Failure 400 {object} utils.Response "Invalid restaurant ID"
+// @Failure 429 {object} utils.Response "Too many open streams"
+// @Router /listings/stream [get]
+func (h *EventHandler) StreamListingStock(c *fiber.Ctx) error {
+	var restaurantID *uuid.UUID
+	if idStr := c.Query("restaurant_id"); idStr != "" {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+		}
+		restaurantID = &id
+	}
+
+	select {
+	case h.publicSlots <- struct{}{}:
+	default:
+		return utils.ErrorResponse(c, fiber.StatusTooManyRequests, "Too many open streams, try again later", nil)
+	}
+
+	return h.stream(c, h.eventService.SubscribeListingStock(restaurantID), nil, func() {
+		<-h.publicSlots
+	})
+}
+
+// sessionCheck returns a check that fails once the caller's access token has
+// expired or been revoked, or their account has been suspended. The token is
+// read from the request up front, as the stream outlives the request context.
+func (h *EventHandler) sessionCheck(c *fiber.Ctx, userID uuid.UUID) (func() error, error) {
+	tokenID, err := middlewares.GetTokenID(c)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := middlewares.GetTokenExpiresAt(c)
+
+	return func() error {
+		if !expiresAt.IsZero() && time.Now().After(expiresAt) {
+			return models.ErrTokenExpired
+		}
+		return h.authService.ValidateSession(userID, tokenID)
+	}, nil
+}
+
+// stream writes the subscription's events as Server-Sent Events until the
+// client disconnects, the bus closes or check, if set, fails when it runs
+// every recheck interval. release, if set, runs once the stream has ended.
+func (h *EventHandler) stream(c *fiber.Ctx, sub *events.Subscription, check func() error, release func()) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+		if release != nil {
+			defer release()
+		}
+
+		ticker := time.NewTicker(sseHeartbeat)
+		defer ticker.Stop()
+
+		var recheck <-chan time.Time
+		if check != nil {
+			recheckTicker := time.NewTicker(h.recheck)
+			defer recheckTicker.Stop()
+			recheck = recheckTicker.C
+		}
+
+		fmt.Fprint(w, ": connected\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(event)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			case <-ticker.C:
+				fmt.Fprint(w, ": ping\n\n")
+			case <-recheck:
+				// Clients reconnecting afterwards are turned away by the usual checks
+				if err := check(); err != nil {
+					fmt.Fprint(w, ": session ended\n\n")
+					w.Flush()
+					return
+				}
+				continue
+			}
+
+			// Flushing fails once the client has gone away
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"eatright-backend/internal/app/middlewares"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/services"
+	"eatright-backend/internal/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ImpactHandler handles food waste impact endpoints
+type ImpactHandler struct {
+	impactService services.ImpactService
+}
+
+// NewImpactHandler creates a new impact handler
+func NewImpactHandler(impactService services.ImpactService) *ImpactHandler {
+	return &ImpactHandler{
+		impactService: impactService,
+	}
+}
+
+// GetMyImpact retrieves the authenticated user's impact
+// @Summary Get my impact
+// @Description Estimates meals rescued, kilograms of food saved and CO2e avoided by the user's completed orders
+// @Tags Impact
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=models.Impact} "Impact retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Router /impact/me [get]
+func (h *ImpactHandler) GetMyImpact(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	impact, err := h.impactService.GetUserImpact(userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get impact", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Impact retrieved successfully", impact)
+}
+
+// GetRestaurantImpact retrieves a restaurant's impact
+// @Summary Get restaurant impact
+// @Description Estimates meals rescued, kilograms of food saved and CO2e avoided by a restaurant's completed orders
+// @Tags Impact
+// @Accept json
+// @Produce json
+// @Param id path string true "Restaurant ID (UUID)"
+// @Success 200 {object} utils.Response{data=models.Impact} "Impact retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid restaurant ID"
+// @Failure 404 {object} utils.Response "Restaurant not found"
+// @Router /restaurants/{id}/impact [get]
+func (h *ImpactHandler) GetRestaurantImpact(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	impact, err := h.impactService.GetRestaurantImpact(id)
+	if err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Restaurant not found", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get impact", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Impact retrieved successfully", impact)
+}
+
+// GetPlatformImpact retrieves the platform-wide impact
+// @Summary Get platform impact
+// @Description Estimates the platform-wide impact of all completed orders, with a daily, weekly (Monday-based) or monthly time series. Dates are in the platform timezone (Asia/Jakarta).
+// @Tags Impact
+// @Accept json
+// @Produce json
+// @Param period query string false "Series bucket: day, week or month (default day)"
+// @Param from query string false "First date (YYYY-MM-DD); defaults to 30 days, 12 weeks or 12 months before to"
+// @Param to query string false "Last date (YYYY-MM-DD, inclusive); defaults to today"
+// @Success 200 {object} utils.Response{data=models.PlatformImpact} "Impact retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid period or date range"
+// @Router /impact [get]
+func (h *ImpactHandler) GetPlatformImpact(c *fiber.Ctx) error {
+	period := models.ImpactPeriod(c.Query("period", string(models.ImpactPeriodDay)))
+	if !period.IsValid() {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid period (must be 'day', 'week' or 'month')", nil)
+	}
+
+	var from, to *models.DateOnly
+	if fromStr := c.Query("from"); fromStr != "" {
+		date, err := models.ParseDateOnly(fromStr)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid from format (expected YYYY-MM-DD)", err)
+		}
+		from = &date
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		date, err := models.ParseDateOnly(toStr)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid to format (expected YYYY-MM-DD)", err)
+		}
+		to = &date
+	}
+
+	impact, err := h.impactService.GetPlatformImpact(period, from, to)
+	if err != nil {
+		if err == models.ErrInvalidInput {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Date range must not be empty or span more than 366 periods", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get impact", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Impact retrieved successfully", impact)
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"time"
+
 	"eatright-backend/internal/app/middlewares"
 	"eatright-backend/internal/app/models"
 	"eatright-backend/internal/app/services"
@@ -30,7 +32,13 @@ type CreateListingRequest struct {
 	Price       int     `json:"price"`
 	Stock       int     `json:"stock"`
 	PhotoURL    string  `json:"photo_url"`
-	PickupTime  string  `json:"pickup_time"` // Format: "HH:MM:SS"
+	PickupStart string  `json:"pickup_start"` // RFC3339, e.g. "2026-10-16T18:00:00+07:00"
+	PickupEnd   string  `json:"pickup_end"`   // RFC3339
+
+	OriginalValue *int                  `json:"original_value"` // Optional retail value, above price
+	PriceSchedule *models.PriceSchedule `json:"price_schedule"` // Optional markdown as pickup_end approaches
+
+	MaxPerCustomer *int `json:"max_per_customer"` // Optional limit on units per customer
 }
 
 // CreateListing creates a new listing for a restaurant
@@ -66,7 +74,7 @@ func (h *ListingHandler) CreateListing(c *fiber.Ctx) error {
 	}
 
 	// Validate input
-	if req.Type == "" || req.Description == "" || req.PickupTime == "" {
+	if req.Type == "" || req.Description == "" || req.PickupStart == "" || req.PickupEnd == "" {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Missing required fields", nil)
 	}
 
@@ -76,28 +84,55 @@ func (h *ListingHandler) CreateListing(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid listing type (must be 'mystery_box' or 'reveal')", nil)
 	}
 
-	// Parse pickup time
-	pickupTime := models.TimeOnly{}
-	if err := pickupTime.UnmarshalJSON([]byte(`"` + req.PickupTime + `"`)); err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid pickup_time format (expected HH:MM:SS)", err)
+	// Parse pickup window
+	pickupStart, err := time.Parse(time.RFC3339, req.PickupStart)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid pickup_start format (expected RFC3339)", err)
+	}
+	pickupEnd, err := time.Parse(time.RFC3339, req.PickupEnd)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid pickup_end format (expected RFC3339)", err)
 	}
 
 	// Create listing
 	listing := &models.Listing{
-		RestaurantID: restaurantID,
-		Type:         listingType,
-		Name:         req.Name,
-		Description:  req.Description,
-		Price:        req.Price,
-		Stock:        req.Stock,
-		PhotoURL:     req.PhotoURL,
-		PickupTime:   pickupTime,
-		IsActive:     true,
+		RestaurantID:  restaurantID,
+		Type:          listingType,
+		Name:          req.Name,
+		Description:   req.Description,
+		Price:         req.Price,
+		Stock:         req.Stock,
+		PhotoURL:      req.PhotoURL,
+		PickupStart:   pickupStart,
+		PickupEnd:     pickupEnd,
+		IsActive:      true,
+		OriginalValue: req.OriginalValue,
+		PriceSchedule: req.PriceSchedule,
+
+		MaxPerCustomer: req.MaxPerCustomer,
 	}
 
 	if err := h.listingService.CreateListing(listing, userID); err != nil {
 		if err == models.ErrUnauthorized {
-			return utils.ErrorResponse(c, fiber.StatusForbidden, "You are not the owner of this restaurant", err)
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
+		}
+		if err == models.ErrRestaurantSuspended {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Restaurant has been suspended", err)
+		}
+		if err == models.ErrRestaurantClosed {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Pickup window starts while the restaurant is closed", err)
+		}
+		if err == models.ErrInvalidPickupWindow {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Pickup window must be in the future, end after it starts and end by closing time", err)
+		}
+		if err == models.ErrInvalidOriginalValue {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Original value must be greater than the price", err)
+		}
+		if err == models.ErrInvalidPriceSchedule {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid price schedule", err)
+		}
+		if err == models.ErrInvalidPurchaseLimit {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "max_per_customer must be greater than 0", err)
 		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create listing", err)
 	}
@@ -156,7 +191,7 @@ type UpdateStockRequest struct {
 
 // UpdateStock updates the stock of a listing
 // @Summary Update listing stock
-// @Description Updates the stock quantity of a listing (restaurant owner only)
+// @Description Updates the stock quantity of a listing (restaurant members with permission only)
 // @Tags Listings
 // @Accept json
 // @Produce json
@@ -189,7 +224,10 @@ func (h *ListingHandler) UpdateStock(c *fiber.Ctx) error {
 	// Update stock
 	if err := h.listingService.UpdateStock(id, req.Quantity, userID); err != nil {
 		if err == models.ErrUnauthorized {
-			return utils.ErrorResponse(c, fiber.StatusForbidden, "You are not the owner of this listing", err)
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
+		}
+		if err == models.ErrRestaurantSuspended {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Restaurant has been suspended", err)
 		}
 		if err == models.ErrNegativeStock {
 			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Stock cannot be negative", err)
@@ -207,7 +245,7 @@ type UpdateStatusRequest struct {
 
 // UpdateStatus toggles the active status of a listing
 // @Summary Toggle listing status
-// @Description Activates or deactivates a listing (restaurant owner only)
+// @Description Activates or deactivates a listing (restaurant members with permission only)
 // @Tags Listings
 // @Accept json
 // @Produce json
@@ -216,7 +254,8 @@ type UpdateStatusRequest struct {
 // @Param request body UpdateStatusRequest true "Status Update"
 // @Success 200 {object} utils.Response "Status updated successfully"
 // @Failure 400 {object} utils.Response "Invalid request"
-// @Failure 403 {object} utils.Response "Forbidden"
+// @Failure 403 {object} utils.Response "Forbidden, restaurant suspended or listing deactivated by an admin"
+// @Failure 404 {object} utils.Response "Listing not found or restaurant deleted"
 // @Router /listings/{id}/status [patch]
 func (h *ListingHandler) UpdateStatus(c *fiber.Ctx) error {
 	// Get user ID from context
@@ -240,10 +279,125 @@ func (h *ListingHandler) UpdateStatus(c *fiber.Ctx) error {
 	// Update status
 	if err := h.listingService.ToggleActive(id, req.IsActive, userID); err != nil {
 		if err == models.ErrUnauthorized {
-			return utils.ErrorResponse(c, fiber.StatusForbidden, "You are not the owner of this listing", err)
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
+		}
+		if err == models.ErrRestaurantSuspended {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Restaurant has been suspended", err)
+		}
+		if err == models.ErrDeactivatedByAdmin {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Listing was deactivated by an admin", err)
+		}
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Listing or restaurant not found", err)
 		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update status", err)
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Status updated successfully", nil)
 }
+
+// SetPriceScheduleRequest represents the request body for setting a listing's markdown schedule
+type SetPriceScheduleRequest struct {
+	PriceSchedule *models.PriceSchedule `json:"price_schedule"` // null removes the schedule
+}
+
+// SetPriceSchedule sets or removes a listing's markdown schedule
+// @Summary Set listing price schedule
+// @Description Sets the markdown schedule that lowers the price as the pickup window ends, e.g. 30% off 120 minutes before pickup_end and 50% off in the final 60 minutes, never below floor_price. Send null to remove it. Orders are charged the price in effect when they are placed.
+// @Tags Listings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Listing ID (UUID)"
+// @Param request body SetPriceScheduleRequest true "Price schedule"
+// @Success 200 {object} utils.Response{data=models.Listing} "Price schedule updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 403 {object} utils.Response "Forbidden"
+// @Failure 404 {object} utils.Response "Listing not found"
+// @Router /listings/{id}/price-schedule [put]
+func (h *ListingHandler) SetPriceSchedule(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid listing ID", err)
+	}
+
+	var req SetPriceScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	listing, err := h.listingService.SetPriceSchedule(id, req.PriceSchedule, userID)
+	if err != nil {
+		switch err {
+		case models.ErrNotFound:
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Listing not found", err)
+		case models.ErrUnauthorized:
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
+		case models.ErrRestaurantSuspended:
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Restaurant has been suspended", err)
+		case models.ErrInvalidPriceSchedule:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid price schedule", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update price schedule", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Price schedule updated successfully", listing)
+}
+
+// SetMaxPerCustomerRequest represents the request body for setting a listing's per-customer limit
+type SetMaxPerCustomerRequest struct {
+	MaxPerCustomer *int `json:"max_per_customer"` // null removes the limit
+}
+
+// SetMaxPerCustomer sets or removes a listing's per-customer limit
+// @Summary Set listing purchase limit
+// @Description Sets the most units of the listing one customer may hold across their orders. Send null to remove the limit. Orders already placed are kept, even above a lowered limit.
+// @Tags Listings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Listing ID (UUID)"
+// @Param request body SetMaxPerCustomerRequest true "Purchase limit"
+// @Success 200 {object} utils.Response{data=models.Listing} "Purchase limit updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 403 {object} utils.Response "Forbidden"
+// @Failure 404 {object} utils.Response "Listing not found"
+// @Router /listings/{id}/max-per-customer [put]
+func (h *ListingHandler) SetMaxPerCustomer(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid listing ID", err)
+	}
+
+	var req SetMaxPerCustomerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	listing, err := h.listingService.SetMaxPerCustomer(id, req.MaxPerCustomer, userID)
+	if err != nil {
+		switch err {
+		case models.ErrNotFound:
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Listing not found", err)
+		case models.ErrUnauthorized:
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
+		case models.ErrRestaurantSuspended:
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Restaurant has been suspended", err)
+		case models.ErrInvalidPurchaseLimit:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "max_per_customer must be greater than 0", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update purchase limit", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Purchase limit updated successfully", listing)
+}
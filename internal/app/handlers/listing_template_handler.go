@@ -0,0 +1,426 @@
+package handlers
+
+import (
+	"encoding/json"
+	"time"
+
+	"eatright-backend/internal/app/middlewares"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/services"
+	"eatright-backend/internal/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ListingTemplateHandler handles recurring listing template endpoints
+type ListingTemplateHandler struct {
+	templateService services.ListingTemplateService
+}
+
+// NewListingTemplateHandler creates a new listing template handler
+func NewListingTemplateHandler(templateService services.ListingTemplateService) *ListingTemplateHandler {
+	return &ListingTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// CreateListingTemplateRequest represents the request body for creating a listing template
+type CreateListingTemplateRequest struct {
+	Type        string  `json:"type"` // "mystery_box" or "reveal"
+	Name        *string `json:"name"` // Optional for mystery box
+	Description string  `json:"description"`
+	Price       int     `json:"price"`
+	Stock       int     `json:"stock"` // Default stock per occurrence
+	PhotoURL    string  `json:"photo_url"`
+	Weekdays    []int   `json:"weekdays"`     // 0-6, Sunday = 0
+	PickupStart string  `json:"pickup_start"` // HH:MM:SS, restaurant local time
+	PickupEnd   string  `json:"pickup_end"`   // HH:MM:SS; at or before pickup_start runs past midnight
+
+	OriginalValue *int                  `json:"original_value"` // Optional retail value, above price
+	PriceSchedule *models.PriceSchedule `json:"price_schedule"` // Optional markdown for each occurrence
+
+	MaxPerCustomer *int `json:"max_per_customer"` // Optional limit on units per customer of each occurrence
+}
+
+// UpdateListingTemplateRequest represents the request body for updating a listing template.
+// Omitted fields are left unchanged.
+type UpdateListingTemplateRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Price       *int    `json:"price"`
+	Stock       *int    `json:"stock"`
+	PhotoURL    *string `json:"photo_url"`
+	Weekdays    []int   `json:"weekdays"`
+	PickupStart *string `json:"pickup_start"`
+	PickupEnd   *string `json:"pickup_end"`
+	IsActive    *bool   `json:"is_active"`
+
+	OriginalValue *int            `json:"original_value"`
+	PriceSchedule json.RawMessage `json:"price_schedule" swaggertype:"object"` // null removes the schedule
+
+	MaxPerCustomer json.RawMessage `json:"max_per_customer" swaggertype:"integer"` // null removes the limit
+}
+
+// SetOccurrenceOverrideRequest represents the request body for overriding one occurrence
+type SetOccurrenceOverrideRequest struct {
+	Skip  bool `json:"skip"`
+	Stock *int `json:"stock"` // Replaces the default stock for this date
+}
+
+// CreateTemplate creates a recurring listing template
+// @Summary Create listing template
+// @Description Creates a template that is turned into a listing on each selected weekday, with the pickup window in the restaurant's timezone. Occurrences are created at the start of each local day; days the restaurant is closed during the window are skipped.
+// @Tags Listing Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Param request body CreateListingTemplateRequest true "Template Details"
+// @Success 201 {object} utils.Response{data=models.ListingTemplate} "Template created successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Router /restaurants/{id}/listing-templates [post]
+func (h *ListingTemplateHandler) CreateTemplate(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	var req CreateListingTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	if req.Type == "" || req.Description == "" || req.PickupStart == "" || req.PickupEnd == "" || len(req.Weekdays) == 0 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Missing required fields", nil)
+	}
+
+	weekdays, err := models.NewWeekdaySet(req.Weekdays)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid weekday (must be 0-6, Sunday = 0)", err)
+	}
+
+	pickupStart, err := parseTimeOfDay(req.PickupStart)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid pickup_start format (expected HH:MM:SS)", err)
+	}
+	pickupEnd, err := parseTimeOfDay(req.PickupEnd)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid pickup_end format (expected HH:MM:SS)", err)
+	}
+
+	template := &models.ListingTemplate{
+		RestaurantID:  restaurantID,
+		Type:          models.ListingType(req.Type),
+		Name:          req.Name,
+		Description:   req.Description,
+		Price:         req.Price,
+		Stock:         req.Stock,
+		PhotoURL:      req.PhotoURL,
+		Weekdays:      weekdays,
+		PickupStart:   pickupStart,
+		PickupEnd:     pickupEnd,
+		IsActive:      true,
+		OriginalValue: req.OriginalValue,
+		PriceSchedule: req.PriceSchedule,
+
+		MaxPerCustomer: req.MaxPerCustomer,
+	}
+
+	if err := h.templateService.CreateTemplate(template, userID); err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Restaurant not found", err)
+		}
+		if err == models.ErrRestaurantSuspended {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Restaurant has been suspended", err)
+		}
+		return templateErrorResponse(c, err, "Failed to create template")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Template created successfully", template)
+}
+
+// GetTemplates lists a restaurant's listing templates
+// @Summary List listing templates
+// @Description Lists a restaurant's templates with their upcoming overrides (members who manage listings only)
+// @Tags Listing Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Success 200 {object} utils.Response{data=[]models.ListingTemplate} "Templates retrieved successfully"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Router /restaurants/{id}/listing-templates [get]
+func (h *ListingTemplateHandler) GetTemplates(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	templates, err := h.templateService.GetTemplates(restaurantID, userID)
+	if err != nil {
+		return templateErrorResponse(c, err, "Failed to get templates")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Templates retrieved successfully", templates)
+}
+
+// UpdateTemplate partially updates a listing template
+// @Summary Update listing template
+// @Description Updates a template, including pausing it with is_active=false. Listings already created from it are not changed.
+// @Tags Listing Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID (UUID)"
+// @Param request body UpdateListingTemplateRequest true "Fields to update"
+// @Success 200 {object} utils.Response{data=models.ListingTemplate} "Template updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 404 {object} utils.Response "Template not found"
+// @Router /listing-templates/{id} [patch]
+func (h *ListingTemplateHandler) UpdateTemplate(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid template ID", err)
+	}
+
+	var req UpdateListingTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	update := models.ListingTemplateUpdate{
+		Name:          req.Name,
+		Description:   req.Description,
+		Price:         req.Price,
+		Stock:         req.Stock,
+		PhotoURL:      req.PhotoURL,
+		IsActive:      req.IsActive,
+		OriginalValue: req.OriginalValue,
+	}
+
+	if req.Weekdays != nil {
+		weekdays, err := models.NewWeekdaySet(req.Weekdays)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid weekday (must be 0-6, Sunday = 0)", err)
+		}
+		update.Weekdays = &weekdays
+	}
+	if req.PickupStart != nil {
+		pickupStart, err := parseTimeOfDay(*req.PickupStart)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid pickup_start format (expected HH:MM:SS)", err)
+		}
+		update.PickupStart = &pickupStart
+	}
+	if req.PickupEnd != nil {
+		pickupEnd, err := parseTimeOfDay(*req.PickupEnd)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid pickup_end format (expected HH:MM:SS)", err)
+		}
+		update.PickupEnd = &pickupEnd
+	}
+	if len(req.PriceSchedule) > 0 {
+		if string(req.PriceSchedule) == "null" {
+			update.ClearPriceSchedule = true
+		} else {
+			var schedule models.PriceSchedule
+			if err := json.Unmarshal(req.PriceSchedule, &schedule); err != nil {
+				return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid price schedule", err)
+			}
+			update.PriceSchedule = &schedule
+		}
+	}
+	if len(req.MaxPerCustomer) > 0 {
+		if string(req.MaxPerCustomer) == "null" {
+			update.ClearMaxPerCustomer = true
+		} else {
+			var limit int
+			if err := json.Unmarshal(req.MaxPerCustomer, &limit); err != nil {
+				return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid max_per_customer", err)
+			}
+			update.MaxPerCustomer = &limit
+		}
+	}
+
+	template, err := h.templateService.UpdateTemplate(id, update, userID)
+	if err != nil {
+		return templateErrorResponse(c, err, "Failed to update template")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Template updated successfully", template)
+}
+
+// DeleteTemplate deletes a listing template
+// @Summary Delete listing template
+// @Description Deletes a template and its overrides. Listings already created from it are kept.
+// @Tags Listing Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID (UUID)"
+// @Success 200 {object} utils.Response "Template deleted successfully"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 404 {object} utils.Response "Template not found"
+// @Router /listing-templates/{id} [delete]
+func (h *ListingTemplateHandler) DeleteTemplate(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid template ID", err)
+	}
+
+	if err := h.templateService.DeleteTemplate(id, userID); err != nil {
+		return templateErrorResponse(c, err, "Failed to delete template")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Template deleted successfully", nil)
+}
+
+// SetOccurrenceOverride skips or changes the stock of one occurrence
+// @Summary Override template occurrence
+// @Description Skips the occurrence on a date or overrides its stock. Only dates from today (restaurant timezone) whose listing has not been created yet can be overridden; after that, manage the listing directly.
+// @Tags Listing Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID (UUID)"
+// @Param date path string true "Occurrence date (YYYY-MM-DD)"
+// @Param request body SetOccurrenceOverrideRequest true "Override"
+// @Success 200 {object} utils.Response{data=models.ListingOccurrenceOverride} "Occurrence override saved successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 409 {object} utils.Response "Listing for this date already created"
+// @Router /listing-templates/{id}/occurrences/{date} [put]
+func (h *ListingTemplateHandler) SetOccurrenceOverride(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid template ID", err)
+	}
+
+	date, err := models.ParseDateOnly(c.Params("date"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format (expected YYYY-MM-DD)", err)
+	}
+
+	var req SetOccurrenceOverrideRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	if !req.Skip && req.Stock == nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Either skip or stock is required", nil)
+	}
+
+	override := &models.ListingOccurrenceOverride{
+		TemplateID: id,
+		Date:       date,
+		Skip:       req.Skip,
+		Stock:      req.Stock,
+	}
+
+	if err := h.templateService.SetOverride(override, userID); err != nil {
+		if err == models.ErrInvalidInput {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Date must not be in the past", err)
+		}
+		return templateErrorResponse(c, err, "Failed to save occurrence override")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Occurrence override saved successfully", override)
+}
+
+// DeleteOccurrenceOverride removes an occurrence override
+// @Summary Remove occurrence override
+// @Description Restores the template defaults for a date
+// @Tags Listing Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID (UUID)"
+// @Param date path string true "Occurrence date (YYYY-MM-DD)"
+// @Success 200 {object} utils.Response "Occurrence override removed successfully"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 404 {object} utils.Response "Override not found"
+// @Router /listing-templates/{id}/occurrences/{date} [delete]
+func (h *ListingTemplateHandler) DeleteOccurrenceOverride(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid template ID", err)
+	}
+
+	date, err := models.ParseDateOnly(c.Params("date"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format (expected YYYY-MM-DD)", err)
+	}
+
+	if err := h.templateService.RemoveOverride(id, date, userID); err != nil {
+		return templateErrorResponse(c, err, "Failed to remove occurrence override")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Occurrence override removed successfully", nil)
+}
+
+// templateErrorResponse maps listing template errors to HTTP responses
+func templateErrorResponse(c *fiber.Ctx, err error, message string) error {
+	switch err {
+	case models.ErrNotFound:
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Template or override not found", err)
+	case models.ErrUnauthorized:
+		return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
+	case models.ErrInvalidInput:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid type, weekdays or price", err)
+	case models.ErrNegativeStock:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Stock cannot be negative", err)
+	case models.ErrInvalidOriginalValue:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Original value must be greater than the price", err)
+	case models.ErrInvalidPriceSchedule:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid price schedule", err)
+	case models.ErrInvalidPickupWindow:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Pickup window must not start and end at the same time", err)
+	case models.ErrInvalidPurchaseLimit:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "max_per_customer must be greater than 0", err)
+	case models.ErrOccurrenceCreated:
+		return utils.ErrorResponse(c, fiber.StatusConflict, "Listing for this date has already been created; update the listing instead", err)
+	}
+	return utils.ErrorResponse(c, fiber.StatusInternalServerError, message, err)
+}
+
+// parseTimeOfDay parses a time of day in HH:MM:SS format; midnight is a valid time
+func parseTimeOfDay(s string) (models.TimeOnly, error) {
+	parsed, err := time.Parse("15:04:05", s)
+	if err != nil {
+		return models.TimeOnly{}, err
+	}
+	return models.TimeOnly{Time: parsed}, nil
+}
@@ -0,0 +1,364 @@
+package handlers
+
+import (
+	"eatright-backend/internal/app/middlewares"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/services"
+	"eatright-backend/internal/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// MembershipHandler handles restaurant staff and invitation endpoints
+type MembershipHandler struct {
+	membershipService services.MembershipService
+}
+
+// NewMembershipHandler creates a new membership handler
+func NewMembershipHandler(membershipService services.MembershipService) *MembershipHandler {
+	return &MembershipHandler{
+		membershipService: membershipService,
+	}
+}
+
+// InviteMemberRequest represents the request body for inviting a restaurant member
+type InviteMemberRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"` // manager or staff
+}
+
+// UpdateMemberRoleRequest represents the request body for changing a member's role
+type UpdateMemberRoleRequest struct {
+	Role string `json:"role"` // manager or staff
+}
+
+// GetMembers lists a restaurant's members
+// @Summary List restaurant members
+// @Description Lists the owner, managers and staff of a restaurant (members only)
+// @Tags Restaurant Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Success 200 {object} utils.Response{data=[]models.RestaurantMember} "Members retrieved successfully"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Router /restaurants/{id}/members [get]
+func (h *MembershipHandler) GetMembers(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	members, err := h.membershipService.GetMembers(restaurantID, userID)
+	if err != nil {
+		return membershipErrorResponse(c, err, "Failed to get members")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Members retrieved successfully", members)
+}
+
+// InviteMember invites a user to join a restaurant
+// @Summary Invite restaurant member
+// @Description Invites a user by email as manager or staff. Owners can invite managers and staff; managers can invite staff. Invitations expire after 7 days.
+// @Tags Restaurant Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Param request body InviteMemberRequest true "Invitation Details"
+// @Success 201 {object} utils.Response{data=models.RestaurantInvitation} "Invitation sent successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 409 {object} utils.Response "Already a member or invitation pending"
+// @Router /restaurants/{id}/invitations [post]
+func (h *MembershipHandler) InviteMember(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	var req InviteMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	if req.Email == "" || req.Role == "" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Missing required fields", nil)
+	}
+
+	invitation, err := h.membershipService.InviteMember(restaurantID, req.Email, models.MemberRole(req.Role), userID)
+	if err != nil {
+		if err == models.ErrInvalidInput {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid role (must be 'manager' or 'staff')", err)
+		}
+		if err == models.ErrDuplicateEntry {
+			return utils.ErrorResponse(c, fiber.StatusConflict, "User is already a member or has a pending invitation", err)
+		}
+		return membershipErrorResponse(c, err, "Failed to send invitation")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Invitation sent successfully", invitation)
+}
+
+// GetRestaurantInvitations lists the invitations sent by a restaurant
+// @Summary List restaurant invitations
+// @Description Lists all invitations sent by a restaurant, newest first (owner and managers only)
+// @Tags Restaurant Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Success 200 {object} utils.Response{data=[]models.RestaurantInvitation} "Invitations retrieved successfully"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Router /restaurants/{id}/invitations [get]
+func (h *MembershipHandler) GetRestaurantInvitations(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	invitations, err := h.membershipService.GetRestaurantInvitations(restaurantID, userID)
+	if err != nil {
+		return membershipErrorResponse(c, err, "Failed to get invitations")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Invitations retrieved successfully", invitations)
+}
+
+// RevokeInvitation revokes a pending invitation
+// @Summary Revoke invitation
+// @Description Revokes a pending invitation (owner and managers only)
+// @Tags Restaurant Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Param invitationId path string true "Invitation ID (UUID)"
+// @Success 200 {object} utils.Response "Invitation revoked successfully"
+// @Failure 400 {object} utils.Response "Invitation is no longer pending"
+// @Failure 404 {object} utils.Response "Invitation not found"
+// @Router /restaurants/{id}/invitations/{invitationId} [delete]
+func (h *MembershipHandler) RevokeInvitation(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	invitationID, err := uuid.Parse(c.Params("invitationId"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid invitation ID", err)
+	}
+
+	if err := h.membershipService.RevokeInvitation(restaurantID, invitationID, userID); err != nil {
+		return membershipErrorResponse(c, err, "Failed to revoke invitation")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Invitation revoked successfully", nil)
+}
+
+// UpdateMemberRole changes a member's role
+// @Summary Update member role
+// @Description Changes a member's role between manager and staff. The requester must outrank both the member's current and new role.
+// @Tags Restaurant Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Param userId path string true "Member User ID (UUID)"
+// @Param request body UpdateMemberRoleRequest true "New Role"
+// @Success 200 {object} utils.Response "Member role updated successfully"
+// @Failure 400 {object} utils.Response "Invalid role"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Router /restaurants/{id}/members/{userId} [patch]
+func (h *MembershipHandler) UpdateMemberRole(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID", err)
+	}
+
+	var req UpdateMemberRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	if err := h.membershipService.UpdateMemberRole(restaurantID, memberID, models.MemberRole(req.Role), userID); err != nil {
+		if err == models.ErrInvalidInput {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid role (must be 'manager' or 'staff')", err)
+		}
+		return membershipErrorResponse(c, err, "Failed to update member role")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Member role updated successfully", nil)
+}
+
+// RemoveMember removes a member from a restaurant
+// @Summary Remove member
+// @Description Removes a member from a restaurant. Members can remove themselves to leave; the owner cannot be removed.
+// @Tags Restaurant Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Param userId path string true "Member User ID (UUID)"
+// @Success 200 {object} utils.Response "Member removed successfully"
+// @Failure 400 {object} utils.Response "Owner cannot be removed"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Router /restaurants/{id}/members/{userId} [delete]
+func (h *MembershipHandler) RemoveMember(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID", err)
+	}
+
+	if err := h.membershipService.RemoveMember(restaurantID, memberID, userID); err != nil {
+		return membershipErrorResponse(c, err, "Failed to remove member")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Member removed successfully", nil)
+}
+
+// GetMyInvitations lists pending invitations for the authenticated user
+// @Summary Get my invitations
+// @Description Lists pending, unexpired restaurant invitations addressed to the authenticated user's email
+// @Tags Restaurant Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]models.RestaurantInvitation} "Invitations retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Router /invitations/me [get]
+func (h *MembershipHandler) GetMyInvitations(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	invitations, err := h.membershipService.GetMyInvitations(userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get invitations", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Invitations retrieved successfully", invitations)
+}
+
+// AcceptInvitation accepts an invitation
+// @Summary Accept invitation
+// @Description Accepts a pending invitation addressed to the authenticated user and joins the restaurant
+// @Tags Restaurant Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Invitation ID (UUID)"
+// @Success 200 {object} utils.Response{data=models.RestaurantMember} "Invitation accepted successfully"
+// @Failure 400 {object} utils.Response "Invitation expired or no longer pending"
+// @Failure 404 {object} utils.Response "Invitation not found"
+// @Router /invitations/{id}/accept [post]
+func (h *MembershipHandler) AcceptInvitation(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid invitation ID", err)
+	}
+
+	member, err := h.membershipService.AcceptInvitation(id, userID)
+	if err != nil {
+		if err == models.ErrDuplicateEntry {
+			return utils.ErrorResponse(c, fiber.StatusConflict, "You are already a member of this restaurant", err)
+		}
+		return membershipErrorResponse(c, err, "Failed to accept invitation")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Invitation accepted successfully", member)
+}
+
+// DeclineInvitation declines an invitation
+// @Summary Decline invitation
+// @Description Declines a pending invitation addressed to the authenticated user
+// @Tags Restaurant Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Invitation ID (UUID)"
+// @Success 200 {object} utils.Response "Invitation declined successfully"
+// @Failure 400 {object} utils.Response "Invitation is no longer pending"
+// @Failure 404 {object} utils.Response "Invitation not found"
+// @Router /invitations/{id}/decline [post]
+func (h *MembershipHandler) DeclineInvitation(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid invitation ID", err)
+	}
+
+	if err := h.membershipService.DeclineInvitation(id, userID); err != nil {
+		return membershipErrorResponse(c, err, "Failed to decline invitation")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Invitation declined successfully", nil)
+}
+
+// membershipErrorResponse maps membership errors to HTTP responses
+func membershipErrorResponse(c *fiber.Ctx, err error, message string) error {
+	switch err {
+	case models.ErrNotFound:
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Member or invitation not found", err)
+	case models.ErrUnauthorized:
+		return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
+	case models.ErrOwnerRequired:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Restaurant ownership cannot be assigned, removed or demoted", err)
+	case models.ErrInvitationExpired:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invitation has expired", err)
+	case models.ErrInvalidStatusTransition:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invitation is no longer pending", err)
+	}
+	return utils.ErrorResponse(c, fiber.StatusInternalServerError, message, err)
+}
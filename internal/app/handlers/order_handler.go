@@ -3,6 +3,7 @@ package handlers
 import (
 	"eatright-backend/internal/app/middlewares"
 	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
 	"eatright-backend/internal/app/services"
 	"eatright-backend/internal/app/utils"
 
@@ -30,7 +31,7 @@ type CreateOrderRequest struct {
 
 // CreateOrder creates a new order
 // @Summary Create order
-// @Description Creates a new food order and automatically decrements stock
+// @Description Orders a single listing and automatically decrements stock. To order several listings of one restaurant together, use the cart and POST /cart/checkout. When payments are enabled the order starts as awaiting_payment, holding its stock until paid or PAYMENT_TIMEOUT (default 15m) passes, and its payment carries the client_secret to pay with.
 // @Tags Orders
 // @Accept json
 // @Produce json
@@ -39,6 +40,8 @@ type CreateOrderRequest struct {
 // @Success 201 {object} utils.Response{data=models.Order} "Order created successfully"
 // @Failure 400 {object} utils.Response "Invalid request or insufficient stock"
 // @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 422 {object} utils.Response "Purchase limit reached"
+// @Failure 502 {object} utils.Response "Payment could not be started"
 // @Router /orders [post]
 func (h *OrderHandler) CreateOrder(c *fiber.Ctx) error {
 	// Get user ID from context
@@ -62,24 +65,100 @@ func (h *OrderHandler) CreateOrder(c *fiber.Ctx) error {
 
 	// Create order
 	order := &models.Order{
-		UserID:    userID,
-		ListingID: req.ListingID,
-		Qty:       req.Qty,
+		UserID: userID,
+		Items:  []models.OrderItem{{ListingID: req.ListingID, Qty: req.Qty}},
 	}
 
 	if err := h.orderService.CreateOrder(order); err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Listing not found", err)
+		}
 		if err == models.ErrInsufficientStock {
 			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Insufficient stock available", err)
 		}
 		if err == models.ErrInvalidInput {
 			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Listing is not active", err)
 		}
+		if err == models.ErrRestaurantSuspended {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Restaurant is currently unavailable", err)
+		}
+		if err == models.ErrRestaurantClosed {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Restaurant is closed at the pickup time", err)
+		}
+		if err == models.ErrPickupWindowClosed {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Pickup window for this listing has ended", err)
+		}
+		if err == models.ErrListingLimitReached {
+			return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "You have reached the purchase limit for this listing", err)
+		}
+		if err == models.ErrDailyLimitReached {
+			return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "You have reached today's purchase limit for this restaurant", err)
+		}
+		if err == models.ErrPaymentUnavailable {
+			return utils.ErrorResponse(c, fiber.StatusBadGateway, "Payment could not be started, please try again", err)
+		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create order", err)
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusCreated, "Order created successfully", order)
 }
 
+// Checkout orders everything in the authenticated user's cart
+// @Summary Check out cart
+// @Description Orders every item in the cart as one order. Stock of every listing is decremented in one transaction; if any listing is short, inactive or past its pickup window nothing is ordered. The order's pickup window is the time all listings have in common. The cart is emptied on success. When payments are enabled the order starts as awaiting_payment, as for POST /orders.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} utils.Response{data=models.Order} "Order created successfully"
+// @Failure 400 {object} utils.Response "Cart is empty or cannot be ordered"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 409 {object} utils.Response "Cart changed during checkout"
+// @Failure 422 {object} utils.Response "Purchase limit reached"
+// @Failure 502 {object} utils.Response "Payment could not be started"
+// @Router /cart/checkout [post]
+func (h *OrderHandler) Checkout(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	order, err := h.orderService.Checkout(userID)
+	if err != nil {
+		switch err {
+		case models.ErrCartEmpty:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Cart is empty", err)
+		case models.ErrCartChanged:
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Cart changed during checkout, please review it and try again", err)
+		case models.ErrNotFound:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "A listing in the cart no longer exists", err)
+		case models.ErrInsufficientStock:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Insufficient stock available for an item in the cart", err)
+		case models.ErrInvalidInput:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "A listing in the cart is not active", err)
+		case models.ErrCartRestaurantMismatch:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Cart can only hold listings from one restaurant", err)
+		case models.ErrPickupWindowsDisjoint:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Listings in the cart have no pickup time in common", err)
+		case models.ErrRestaurantSuspended:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Restaurant is currently unavailable", err)
+		case models.ErrRestaurantClosed:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Restaurant is closed at the pickup time", err)
+		case models.ErrPickupWindowClosed:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Pickup window for a listing in the cart has ended", err)
+		case models.ErrListingLimitReached:
+			return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "You have reached the purchase limit for a listing in the cart", err)
+		case models.ErrDailyLimitReached:
+			return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "You have reached today's purchase limit for this restaurant", err)
+		case models.ErrPaymentUnavailable:
+			return utils.ErrorResponse(c, fiber.StatusBadGateway, "Payment could not be started, please try again", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to check out", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Order created successfully", order)
+}
+
 // GetMyOrders retrieves all orders for the authenticated user
 // @Summary Get user's order history
 // @Description Retrieves all orders placed by the authenticated user
@@ -106,14 +185,186 @@ func (h *OrderHandler) GetMyOrders(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, fiber.StatusOK, "Orders retrieved successfully", orders)
 }
 
+// GetRestaurantOrders retrieves a restaurant's order queue
+// @Summary Get restaurant orders
+// @Description Lists a restaurant's orders sorted by pickup time, with optional status, pickup date and listing filters (restaurant members who may view orders)
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Param status query string false "Filter by status (pending, ready, completed, cancelled, no_show)"
+// @Param date query string false "Filter by pickup date in the restaurant's timezone (YYYY-MM-DD)"
+// @Param listing_id query string false "Filter by listing ID (UUID)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} utils.Response{data=utils.PaginatedData{items=[]models.Order}} "Orders retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid parameters"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Router /restaurants/{id}/orders [get]
+func (h *OrderHandler) GetRestaurantOrders(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	page, limit := parsePagination(c)
+	filter := repositories.OrderFilter{
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := models.OrderStatus(statusStr)
+		if status != models.OrderStatusPending &&
+			status != models.OrderStatusReady &&
+			status != models.OrderStatusCompleted &&
+			status != models.OrderStatusCancelled &&
+			status != models.OrderStatusNoShow {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid status", nil)
+		}
+		filter.Statuses = []models.OrderStatus{status}
+	}
+
+	if dateStr := c.Query("date"); dateStr != "" {
+		date, err := models.ParseDateOnly(dateStr)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format (expected YYYY-MM-DD)", err)
+		}
+		filter.PickupDate = &date
+	}
+
+	if listingStr := c.Query("listing_id"); listingStr != "" {
+		listingID, err := uuid.Parse(listingStr)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid listing ID", err)
+		}
+		filter.ListingID = &listingID
+	}
+
+	orders, total, err := h.orderService.GetRestaurantOrders(restaurantID, filter, userID)
+	if err != nil {
+		if err == models.ErrUnauthorized {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get orders", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Orders retrieved successfully", utils.PaginatedData{
+		Items: orders,
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	})
+}
+
+// GetOrderBoard retrieves a restaurant's order board for a day
+// @Summary Get restaurant order board
+// @Description Groups a restaurant's orders for one pickup date into pending, ready and completed columns with counts, each sorted by pickup time (restaurant members who may view orders)
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Param date query string false "Pickup date in the restaurant's timezone (YYYY-MM-DD); defaults to today"
+// @Success 200 {object} utils.Response{data=models.OrderBoard} "Order board retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid parameters"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Router /restaurants/{id}/orders/board [get]
+func (h *OrderHandler) GetOrderBoard(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	var date *models.DateOnly
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := models.ParseDateOnly(dateStr)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format (expected YYYY-MM-DD)", err)
+		}
+		date = &parsed
+	}
+
+	board, err := h.orderService.GetOrderBoard(restaurantID, date, userID)
+	if err != nil {
+		if err == models.ErrUnauthorized {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get order board", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Order board retrieved successfully", board)
+}
+
+// GetOrder retrieves an order with its status timeline
+// @Summary Get order details
+// @Description Retrieves an order with its status timeline (events, oldest first). Available to the customer who placed it and to restaurant members who may view orders.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID (UUID)"
+// @Success 200 {object} utils.Response{data=models.Order} "Order retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid order ID"
+// @Failure 403 {object} utils.Response "Not your order"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Router /orders/{id} [get]
+func (h *OrderHandler) GetOrder(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID", err)
+	}
+
+	order, err := h.orderService.GetOrderForUser(id, userID)
+	if err != nil {
+		switch err {
+		case models.ErrNotFound:
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found", err)
+		case models.ErrUnauthorized:
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have access to this order", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get order", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Order retrieved successfully", order)
+}
+
 // UpdateOrderStatusRequest represents the request body for updating order status
 type UpdateOrderStatusRequest struct {
-	Status string `json:"status"` // "pending", "ready", "completed", "cancelled"
+	Status string `json:"status"` // "ready", "cancelled", "no_show"; completion goes through pickup verification
+	Reason string `json:"reason"` // Optional, recorded for cancellations
+}
+
+// CancelOrderRequest represents the request body for cancelling an order
+type CancelOrderRequest struct {
+	Reason string `json:"reason"`
+}
+
+// VerifyPickupRequest represents the request body for verifying an order pickup
+type VerifyPickupRequest struct {
+	Code      string `json:"code"`       // Typed pickup code
+	QRPayload string `json:"qr_payload"` // Scanned QR payload; takes precedence over code
 }
 
 // UpdateOrderStatus updates the status of an order
 // @Summary Update order status
-// @Description Updates order status - pending/ready/completed/cancelled (restaurant owner only)
+// @Description Moves an order along (restaurant members with permission only): pending → ready, pending/ready/awaiting_payment → cancelled, ready → no_show once the pickup window has ended. Orders are completed through POST /orders/{id}/pickup. Cancelling returns the quantity to stock and refunds a paid order in full. Every transition is recorded in the order's timeline.
 // @Tags Orders
 // @Accept json
 // @Produce json
@@ -122,10 +373,11 @@ type UpdateOrderStatusRequest struct {
 // @Param request body UpdateOrderStatusRequest true "Status Update"
 // @Success 200 {object} utils.Response "Order status updated successfully"
 // @Failure 400 {object} utils.Response "Invalid request"
-// @Failure 403 {object} utils.Response "Forbidden - not restaurant owner"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 404 {object} utils.Response "Order not found"
 // @Router /orders/{id}/status [patch]
 func (h *OrderHandler) UpdateOrderStatus(c *fiber.Ctx) error {
-	// Get user ID from context (must be restaurant owner)
+	// Get user ID from context (must be a restaurant member)
 	userID, err := middlewares.GetUserID(c)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
@@ -145,23 +397,132 @@ func (h *OrderHandler) UpdateOrderStatus(c *fiber.Ctx) error {
 
 	// Validate status
 	status := models.OrderStatus(req.Status)
-	if status != models.OrderStatusPending &&
-		status != models.OrderStatusReady &&
+	if status != models.OrderStatusReady &&
 		status != models.OrderStatusCompleted &&
-		status != models.OrderStatusCancelled {
+		status != models.OrderStatusCancelled &&
+		status != models.OrderStatusNoShow {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid status", nil)
 	}
 
 	// Update status
-	if err := h.orderService.UpdateOrderStatus(id, status, userID); err != nil {
+	if err := h.orderService.UpdateOrderStatus(id, status, req.Reason, userID); err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found", err)
+		}
 		if err == models.ErrUnauthorized {
-			return utils.ErrorResponse(c, fiber.StatusForbidden, "You are not the owner of this restaurant", err)
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
 		}
 		if err == models.ErrInvalidStatusTransition {
 			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid status transition", err)
 		}
+		if err == models.ErrPickupCodeRequired {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Verify the customer's pickup code to complete the order", err)
+		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update order status", err)
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Order status updated successfully", nil)
 }
+
+// CancelOrder cancels the customer's own order
+// @Summary Cancel my order
+// @Description Cancels an order placed by the authenticated user and returns its quantity to stock. Pending and ready orders may be cancelled until ORDER_CANCEL_CUTOFF (default 1h) before pickup starts; orders awaiting payment at any time. Paid orders are refunded in full.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID (UUID)"
+// @Param request body CancelOrderRequest false "Cancellation Reason"
+// @Success 200 {object} utils.Response "Order cancelled successfully"
+// @Failure 400 {object} utils.Response "Order can no longer be cancelled"
+// @Failure 403 {object} utils.Response "Not your order"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Router /orders/{id}/cancel [post]
+func (h *OrderHandler) CancelOrder(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID", err)
+	}
+
+	var req CancelOrderRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+		}
+	}
+
+	if err := h.orderService.CancelOrder(id, req.Reason, userID); err != nil {
+		switch err {
+		case models.ErrNotFound:
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found", err)
+		case models.ErrUnauthorized:
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You can only cancel your own orders", err)
+		case models.ErrInvalidStatusTransition:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Order can no longer be cancelled", err)
+		case models.ErrCancellationClosed:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Cancellation window has closed", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to cancel order", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Order cancelled successfully", nil)
+}
+
+// VerifyPickup completes an order when the customer's pickup code is valid
+// @Summary Verify order pickup
+// @Description Completes a pending or ready order when restaurant staff type the customer's pickup code or scan its QR payload (restaurant members with permission only). After PICKUP_MAX_ATTEMPTS invalid codes (default 5) verification of the order is locked for PICKUP_LOCKOUT (default 15m).
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID (UUID)"
+// @Param request body VerifyPickupRequest true "Pickup Code or QR Payload"
+// @Success 200 {object} utils.Response{data=models.Order} "Order picked up successfully"
+// @Failure 400 {object} utils.Response "Invalid pickup code or order not awaiting pickup"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Failure 429 {object} utils.Response "Too many invalid pickup codes"
+// @Router /orders/{id}/pickup [post]
+func (h *OrderHandler) VerifyPickup(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID", err)
+	}
+
+	var req VerifyPickupRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+	if req.Code == "" && req.QRPayload == "" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "code or qr_payload is required", nil)
+	}
+
+	order, err := h.orderService.VerifyPickup(id, req.Code, req.QRPayload, userID)
+	if err != nil {
+		switch err {
+		case models.ErrNotFound:
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found", err)
+		case models.ErrUnauthorized:
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
+		case models.ErrInvalidStatusTransition:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Order is not awaiting pickup", err)
+		case models.ErrInvalidPickupCode:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid pickup code", err)
+		case models.ErrPickupLocked:
+			return utils.ErrorResponse(c, fiber.StatusTooManyRequests, "Too many invalid pickup codes, try again later", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to verify pickup", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Order picked up successfully", order)
+}
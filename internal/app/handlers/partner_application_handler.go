@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"eatright-backend/internal/app/middlewares"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/services"
+	"eatright-backend/internal/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// PartnerApplicationHandler handles restaurant partner onboarding endpoints
+type PartnerApplicationHandler struct {
+	applicationService services.PartnerApplicationService
+}
+
+// NewPartnerApplicationHandler creates a new partner application handler
+func NewPartnerApplicationHandler(applicationService services.PartnerApplicationService) *PartnerApplicationHandler {
+	return &PartnerApplicationHandler{
+		applicationService: applicationService,
+	}
+}
+
+// SubmitApplicationRequest represents the request body for submitting a partner application
+type SubmitApplicationRequest struct {
+	BusinessName string                       `json:"business_name"`
+	Address      string                       `json:"address"`
+	Phone        string                       `json:"phone"`
+	Documents    []models.ApplicationDocument `json:"documents"`
+}
+
+// ReviewApplicationRequest represents the request body for approving or rejecting an application
+type ReviewApplicationRequest struct {
+	Note string `json:"note"`
+}
+
+// SubmitApplication submits a partner application for the authenticated user
+// @Summary Submit partner application
+// @Description Submits an application to become a restaurant partner. Only one application can be pending at a time.
+// @Tags Partner Applications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SubmitApplicationRequest true "Application Details"
+// @Success 201 {object} utils.Response{data=models.PartnerApplication} "Application submitted successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 409 {object} utils.Response "Already a partner or application pending"
+// @Router /partner-applications [post]
+func (h *PartnerApplicationHandler) SubmitApplication(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	var req SubmitApplicationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	// Validate input
+	if req.BusinessName == "" || req.Address == "" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Missing required fields", nil)
+	}
+	for _, doc := range req.Documents {
+		if doc.Type == "" || doc.URL == "" {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Each document requires type and url", nil)
+		}
+	}
+
+	application := &models.PartnerApplication{
+		UserID:       userID,
+		BusinessName: req.BusinessName,
+		Address:      req.Address,
+		Phone:        req.Phone,
+		Documents:    req.Documents,
+	}
+
+	if err := h.applicationService.Submit(application); err != nil {
+		if err == models.ErrAlreadyPartner {
+			return utils.ErrorResponse(c, fiber.StatusConflict, "You are already a restaurant partner", err)
+		}
+		if err == models.ErrDuplicateEntry {
+			return utils.ErrorResponse(c, fiber.StatusConflict, "You already have a pending application", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to submit application", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Application submitted successfully", application)
+}
+
+// GetMyApplications retrieves the authenticated user's applications
+// @Summary Get my partner applications
+// @Description Retrieves all partner applications of the authenticated user, newest first
+// @Tags Partner Applications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]models.PartnerApplication} "Applications retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Router /partner-applications/me [get]
+func (h *PartnerApplicationHandler) GetMyApplications(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	applications, err := h.applicationService.GetUserApplications(userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get applications", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Applications retrieved successfully", applications)
+}
+
+// GetApplicationByID retrieves a single application
+// @Summary Get partner application
+// @Description Retrieves a partner application (applicant or admin only)
+// @Tags Partner Applications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Application ID (UUID)"
+// @Success 200 {object} utils.Response{data=models.PartnerApplication} "Application retrieved successfully"
+// @Failure 403 {object} utils.Response "Forbidden"
+// @Failure 404 {object} utils.Response "Application not found"
+// @Router /partner-applications/{id} [get]
+func (h *PartnerApplicationHandler) GetApplicationByID(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	role, err := middlewares.GetUserRole(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid application ID", err)
+	}
+
+	application, err := h.applicationService.GetApplicationByID(id, userID, models.UserRole(role))
+	if err != nil {
+		return applicationErrorResponse(c, err, "Failed to get application")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Application retrieved successfully", application)
+}
+
+// WithdrawApplication withdraws a pending application
+// @Summary Withdraw partner application
+// @Description Withdraws a pending partner application (applicant only)
+// @Tags Partner Applications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Application ID (UUID)"
+// @Success 200 {object} utils.Response{data=models.PartnerApplication} "Application withdrawn successfully"
+// @Failure 400 {object} utils.Response "Application is no longer pending"
+// @Failure 403 {object} utils.Response "Forbidden"
+// @Router /partner-applications/{id}/withdraw [post]
+func (h *PartnerApplicationHandler) WithdrawApplication(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid application ID", err)
+	}
+
+	application, err := h.applicationService.Withdraw(id, userID)
+	if err != nil {
+		return applicationErrorResponse(c, err, "Failed to withdraw application")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Application withdrawn successfully", application)
+}
+
+// ListApplications lists partner applications for admin review
+// @Summary List partner applications
+// @Description Lists partner applications, optionally filtered by status (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status (pending, approved, rejected, withdrawn)"
+// @Success 200 {object} utils.Response{data=[]models.PartnerApplication} "Applications retrieved successfully"
+// @Failure 403 {object} utils.Response "Admin role required"
+// @Router /admin/partner-applications [get]
+func (h *PartnerApplicationHandler) ListApplications(c *fiber.Ctx) error {
+	status := models.PartnerApplicationStatus(c.Query("status"))
+	if status != "" &&
+		status != models.ApplicationStatusPending &&
+		status != models.ApplicationStatusApproved &&
+		status != models.ApplicationStatusRejected &&
+		status != models.ApplicationStatusWithdrawn {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid status", nil)
+	}
+
+	applications, err := h.applicationService.GetApplications(status)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get applications", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Applications retrieved successfully", applications)
+}
+
+// ApproveApplication approves an application and upgrades the applicant's role
+// @Summary Approve partner application
+// @Description Approves a pending application and grants the applicant the restaurant role. The applicant's current access tokens are revoked in the same transaction; their sessions are kept, and their next /auth/refresh returns a fresh token with the new role. (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Application ID (UUID)"
+// @Param request body ReviewApplicationRequest false "Review Note"
+// @Success 200 {object} utils.Response{data=models.PartnerApplication} "Application approved successfully"
+// @Failure 400 {object} utils.Response "Application is no longer pending"
+// @Failure 404 {object} utils.Response "Application not found"
+// @Router /admin/partner-applications/{id}/approve [post]
+func (h *PartnerApplicationHandler) ApproveApplication(c *fiber.Ctx) error {
+	return h.reviewApplication(c, true)
+}
+
+// RejectApplication rejects an application
+// @Summary Reject partner application
+// @Description Rejects a pending application with an optional note (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Application ID (UUID)"
+// @Param request body ReviewApplicationRequest false "Review Note"
+// @Success 200 {object} utils.Response{data=models.PartnerApplication} "Application rejected successfully"
+// @Failure 400 {object} utils.Response "Application is no longer pending"
+// @Failure 404 {object} utils.Response "Application not found"
+// @Router /admin/partner-applications/{id}/reject [post]
+func (h *PartnerApplicationHandler) RejectApplication(c *fiber.Ctx) error {
+	return h.reviewApplication(c, false)
+}
+
+// reviewApplication applies an admin decision to an application
+func (h *PartnerApplicationHandler) reviewApplication(c *fiber.Ctx, approve bool) error {
+	adminID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid application ID", err)
+	}
+
+	var req ReviewApplicationRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+		}
+	}
+
+	if approve {
+		application, err := h.applicationService.Approve(id, adminID, req.Note)
+		if err != nil {
+			return applicationErrorResponse(c, err, "Failed to approve application")
+		}
+		return utils.SuccessResponse(c, fiber.StatusOK, "Application approved successfully", application)
+	}
+
+	application, err := h.applicationService.Reject(id, adminID, req.Note)
+	if err != nil {
+		return applicationErrorResponse(c, err, "Failed to reject application")
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, "Application rejected successfully", application)
+}
+
+// applicationErrorResponse maps partner application errors to HTTP responses
+func applicationErrorResponse(c *fiber.Ctx, err error, message string) error {
+	switch err {
+	case models.ErrNotFound:
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Application not found", err)
+	case models.ErrUnauthorized:
+		return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have access to this application", err)
+	case models.ErrInvalidStatusTransition:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Application is no longer pending", err)
+	}
+	return utils.ErrorResponse(c, fiber.StatusInternalServerError, message, err)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/payments"
+	"eatright-backend/internal/app/services"
+	"eatright-backend/internal/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PaymentHandler handles payment provider endpoints
+type PaymentHandler struct {
+	paymentService services.PaymentService
+	fake           *payments.FakeProvider // Set when the fake provider is in use
+}
+
+// NewPaymentHandler creates a new payment handler; fake is the fake provider
+// in use, or nil
+func NewPaymentHandler(paymentService services.PaymentService, fake *payments.FakeProvider) *PaymentHandler {
+	return &PaymentHandler{
+		paymentService: paymentService,
+		fake:           fake,
+	}
+}
+
+// Webhook receives payment provider notifications
+// @Summary Payment webhook
+// @Description Receives signed notifications from the payment provider. Authorized payments are captured and their orders move from awaiting_payment to pending; failed attempts are recorded on the payment. Redelivered notifications are ignored once applied.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param Payment-Signature header string true "Webhook signature (header name depends on the provider)"
+// @Success 200 {object} utils.Response "Webhook processed"
+// @Failure 400 {object} utils.Response "Invalid signature"
+// @Failure 404 {object} utils.Response "Unknown payment"
+// @Router /payments/webhook [post]
+func (h *PaymentHandler) Webhook(c *fiber.Ctx) error {
+	signature := c.Get(h.paymentService.SignatureHeader())
+	if err := h.paymentService.HandleWebhook(c.Body(), signature); err != nil {
+		switch err {
+		case models.ErrInvalidWebhook:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid webhook signature", err)
+		case models.ErrNotFound:
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to process webhook", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Webhook processed", nil)
+}
+
+// SimulatePaymentRequest represents the request body for simulating a payment
+type SimulatePaymentRequest struct {
+	Succeed bool `json:"succeed"`
+}
+
+// SimulatePayment pays, or fails to pay, a fake payment intent
+// @Summary Simulate payment (fake provider only)
+// @Description Stands in for the customer paying on the client when PAYMENT_PROVIDER=fake: the fake provider authorizes the intent, or declines it, and its signed webhook is delivered to POST /payments/webhook in process. Not available with other providers.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param intentId path string true "Payment provider intent ID"
+// @Param request body SimulatePaymentRequest true "Outcome"
+// @Success 200 {object} utils.Response "Payment simulated"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 404 {object} utils.Response "Intent not found"
+// @Router /payments/fake/{intentId} [post]
+func (h *PaymentHandler) SimulatePayment(c *fiber.Ctx) error {
+	var req SimulatePaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	payload, signature, err := h.fake.Simulate(c.Params("intentId"), req.Succeed)
+	if err != nil {
+		if err == payments.ErrIntentNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment intent not found", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to simulate payment", err)
+	}
+
+	if err := h.paymentService.HandleWebhook(payload, signature); err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to process payment", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Payment simulated", nil)
+}
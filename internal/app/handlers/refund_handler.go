@@ -0,0 +1,372 @@
+package handlers
+
+import (
+	"eatright-backend/internal/app/middlewares"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+	"eatright-backend/internal/app/services"
+	"eatright-backend/internal/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// RefundHandler handles refund HTTP requests
+type RefundHandler struct {
+	refundService services.RefundService
+}
+
+// NewRefundHandler creates a new refund handler
+func NewRefundHandler(refundService services.RefundService) *RefundHandler {
+	return &RefundHandler{
+		refundService: refundService,
+	}
+}
+
+// RefundRequest represents the request body for requesting or issuing a refund
+type RefundRequest struct {
+	Amount int    `json:"amount"` // Smallest currency unit
+	Reason string `json:"reason"`
+}
+
+// ReviewRefundRequest represents the request body for approving or rejecting a refund
+type ReviewRefundRequest struct {
+	Note string `json:"note"`
+}
+
+// RequestRefund requests or issues a refund of an order
+// @Summary Request refund
+// @Description Customers request a refund of a completed or missed order until REFUND_WINDOW (default 72h) after the pickup window ends; the request waits for the restaurant or an admin, and only one may wait at a time. Members with orders:manage issue refunds of any paid order, which are approved and sent to the payment provider at once. The amount may not exceed what is left of the payment.
+// @Tags Refunds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID (UUID)"
+// @Param request body RefundRequest true "Refund"
+// @Success 201 {object} utils.Response{data=models.Refund} "Refund created successfully"
+// @Failure 400 {object} utils.Response "Invalid amount or reason, order not paid or refund window closed"
+// @Failure 403 {object} utils.Response "Forbidden"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Failure 409 {object} utils.Response "A refund request is already waiting"
+// @Router /orders/{id}/refunds [post]
+func (h *RefundHandler) RequestRefund(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	orderID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID", err)
+	}
+
+	var req RefundRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	refund, err := h.refundService.RequestRefund(orderID, req.Amount, req.Reason, userID)
+	if err != nil {
+		if err == models.ErrInvalidStatusTransition {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Refunds can only be requested for completed or missed orders; cancel open orders instead", err)
+		}
+		return refundErrorResponse(c, err, "Order not found", "Failed to create refund")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Refund created successfully", refund)
+}
+
+// GetRestaurantRefunds lists refunds of a restaurant's orders
+// @Summary Get restaurant refunds
+// @Description Lists refunds of the restaurant's orders, newest first (members with orders:view). Filter by status=requested for requests waiting for a decision.
+// @Tags Refunds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Param status query string false "Filter by status (requested, approved, rejected, succeeded, failed)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} utils.Response{data=utils.PaginatedData{items=[]models.Refund}} "Refunds retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid status"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Router /restaurants/{id}/refunds [get]
+func (h *RefundHandler) GetRestaurantRefunds(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	filter, page, limit, err := parseRefundFilter(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid status", nil)
+	}
+
+	refunds, total, err := h.refundService.GetRestaurantRefunds(restaurantID, filter, userID)
+	if err != nil {
+		if err == models.ErrUnauthorized {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get refunds", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Refunds retrieved successfully", utils.PaginatedData{
+		Items: refunds,
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	})
+}
+
+// ApproveRefund approves a customer's refund request
+// @Summary Approve refund request
+// @Description Approves a refund request of one of the restaurant's orders and sends it to the payment provider (members with orders:manage)
+// @Tags Refunds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Refund ID (UUID)"
+// @Param request body ReviewRefundRequest false "Decision Note"
+// @Success 200 {object} utils.Response{data=models.Refund} "Refund approved successfully"
+// @Failure 400 {object} utils.Response "Refund is no longer waiting for a decision or exceeds the payment"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 404 {object} utils.Response "Refund not found"
+// @Router /refunds/{id}/approve [post]
+func (h *RefundHandler) ApproveRefund(c *fiber.Ctx) error {
+	return h.decideRefund(c, true, h.refundService.DecideRefund)
+}
+
+// RejectRefund rejects a customer's refund request
+// @Summary Reject refund request
+// @Description Rejects a refund request of one of the restaurant's orders with an optional note (members with orders:manage). Admins may still approve it.
+// @Tags Refunds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Refund ID (UUID)"
+// @Param request body ReviewRefundRequest false "Decision Note"
+// @Success 200 {object} utils.Response{data=models.Refund} "Refund rejected successfully"
+// @Failure 400 {object} utils.Response "Refund is no longer waiting for a decision"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 404 {object} utils.Response "Refund not found"
+// @Router /refunds/{id}/reject [post]
+func (h *RefundHandler) RejectRefund(c *fiber.Ctx) error {
+	return h.decideRefund(c, false, h.refundService.DecideRefund)
+}
+
+// IssueRefund refunds an order on behalf of an admin
+// @Summary Issue refund
+// @Description Refunds part or all of any paid order and sends it to the payment provider at once (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID (UUID)"
+// @Param request body RefundRequest true "Refund"
+// @Success 201 {object} utils.Response{data=models.Refund} "Refund created successfully"
+// @Failure 400 {object} utils.Response "Invalid amount or reason, or order not paid"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Router /admin/orders/{id}/refunds [post]
+func (h *RefundHandler) IssueRefund(c *fiber.Ctx) error {
+	adminID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	orderID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID", err)
+	}
+
+	var req RefundRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	refund, err := h.refundService.IssueRefund(orderID, req.Amount, req.Reason, adminID)
+	if err != nil {
+		return refundErrorResponse(c, err, "Order not found", "Failed to create refund")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Refund created successfully", refund)
+}
+
+// ListRefunds lists refunds across all restaurants
+// @Summary List refunds
+// @Description Lists refunds across all restaurants, newest first, optionally filtered by status (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status (requested, approved, rejected, succeeded, failed)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} utils.Response{data=utils.PaginatedData{items=[]models.Refund}} "Refunds retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid status"
+// @Failure 403 {object} utils.Response "Admin role required"
+// @Router /admin/refunds [get]
+func (h *RefundHandler) ListRefunds(c *fiber.Ctx) error {
+	filter, page, limit, err := parseRefundFilter(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid status", nil)
+	}
+
+	refunds, total, err := h.refundService.GetRefunds(filter)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get refunds", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Refunds retrieved successfully", utils.PaginatedData{
+		Items: refunds,
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	})
+}
+
+// ReviewApproveRefund approves a refund request on behalf of an admin
+// @Summary Approve refund request (admin)
+// @Description Approves a refund request, including one the restaurant rejected, and sends it to the payment provider (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Refund ID (UUID)"
+// @Param request body ReviewRefundRequest false "Decision Note"
+// @Success 200 {object} utils.Response{data=models.Refund} "Refund approved successfully"
+// @Failure 400 {object} utils.Response "Refund can no longer be approved or exceeds the payment"
+// @Failure 404 {object} utils.Response "Refund not found"
+// @Router /admin/refunds/{id}/approve [post]
+func (h *RefundHandler) ReviewApproveRefund(c *fiber.Ctx) error {
+	return h.decideRefund(c, true, h.refundService.ReviewRefund)
+}
+
+// ReviewRejectRefund rejects a refund request on behalf of an admin
+// @Summary Reject refund request (admin)
+// @Description Rejects a refund request waiting for a decision with an optional note (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Refund ID (UUID)"
+// @Param request body ReviewRefundRequest false "Decision Note"
+// @Success 200 {object} utils.Response{data=models.Refund} "Refund rejected successfully"
+// @Failure 400 {object} utils.Response "Refund is no longer waiting for a decision"
+// @Failure 404 {object} utils.Response "Refund not found"
+// @Router /admin/refunds/{id}/reject [post]
+func (h *RefundHandler) ReviewRejectRefund(c *fiber.Ctx) error {
+	return h.decideRefund(c, false, h.refundService.ReviewRefund)
+}
+
+// RetryRefund handles POST /api/admin/refunds/:id/retry
+// @Summary Retry refund
+// @Description Sends an approved or failed refund to the payment provider again with its attempts reset, e.g. one that used up REFUND_MAX_ATTEMPTS (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Refund ID (UUID)"
+// @Success 200 {object} utils.Response{data=models.Refund} "Refund retried successfully"
+// @Failure 400 {object} utils.Response "Refund is not approved or failed"
+// @Failure 404 {object} utils.Response "Refund not found"
+// @Router /admin/refunds/{id}/retry [post]
+func (h *RefundHandler) RetryRefund(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid refund ID", err)
+	}
+
+	refund, err := h.refundService.RetryRefund(id)
+	if err != nil {
+		if err == models.ErrInvalidStatusTransition {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Only approved or failed refunds can be retried", err)
+		}
+		return refundErrorResponse(c, err, "Refund not found", "Failed to retry refund")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Refund retried successfully", refund)
+}
+
+// decideRefund applies a decision to a refund through decide
+func (h *RefundHandler) decideRefund(c *fiber.Ctx, approve bool,
+	decide func(id uuid.UUID, approve bool, note string, deciderID uuid.UUID) (*models.Refund, error)) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid refund ID", err)
+	}
+
+	var req ReviewRefundRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+		}
+	}
+
+	refund, err := decide(id, approve, req.Note, userID)
+	if err != nil {
+		if approve {
+			return refundErrorResponse(c, err, "Refund not found", "Failed to approve refund")
+		}
+		return refundErrorResponse(c, err, "Refund not found", "Failed to reject refund")
+	}
+
+	if approve {
+		return utils.SuccessResponse(c, fiber.StatusOK, "Refund approved successfully", refund)
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, "Refund rejected successfully", refund)
+}
+
+// parseRefundFilter reads the status and pagination query parameters
+func parseRefundFilter(c *fiber.Ctx) (repositories.RefundFilter, int, int, error) {
+	page, limit := parsePagination(c)
+	filter := repositories.RefundFilter{
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := models.RefundStatus(statusStr)
+		if status != models.RefundStatusRequested &&
+			status != models.RefundStatusApproved &&
+			status != models.RefundStatusRejected &&
+			status != models.RefundStatusSucceeded &&
+			status != models.RefundStatusFailed {
+			return filter, 0, 0, models.ErrInvalidInput
+		}
+		filter.Statuses = []models.RefundStatus{status}
+	}
+	return filter, page, limit, nil
+}
+
+// refundErrorResponse maps refund errors to HTTP responses
+func refundErrorResponse(c *fiber.Ctx, err error, notFound, message string) error {
+	switch err {
+	case models.ErrNotFound:
+		return utils.ErrorResponse(c, fiber.StatusNotFound, notFound, err)
+	case models.ErrUnauthorized:
+		return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this order", err)
+	case models.ErrInvalidInput:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Amount must be greater than zero and a reason is required", err)
+	case models.ErrNotRefundable:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Order has no captured payment to refund", err)
+	case models.ErrRefundExceedsPayment:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Refund amount exceeds what is left of the payment", err)
+	case models.ErrRefundWindowClosed:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Refunds can no longer be requested for this order", err)
+	case models.ErrRefundAlreadyRequested:
+		return utils.ErrorResponse(c, fiber.StatusConflict, "A refund request for this order is already waiting for a decision", err)
+	case models.ErrInvalidStatusTransition:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Refund is no longer waiting for a decision", err)
+	}
+	return utils.ErrorResponse(c, fiber.StatusInternalServerError, message, err)
+}
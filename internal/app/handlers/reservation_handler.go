@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"eatright-backend/internal/app/middlewares"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/services"
+	"eatright-backend/internal/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ReservationHandler handles stock reservation endpoints
+type ReservationHandler struct {
+	reservationService services.ReservationService
+}
+
+// NewReservationHandler creates a new reservation handler
+func NewReservationHandler(reservationService services.ReservationService) *ReservationHandler {
+	return &ReservationHandler{
+		reservationService: reservationService,
+	}
+}
+
+// ReserveRequest represents the request body for reserving stock
+type ReserveRequest struct {
+	Qty int `json:"qty"`
+}
+
+// Reserve holds units of a listing for the authenticated user
+// @Summary Reserve listing stock
+// @Description Holds qty units of a listing while the user checks out. Held units are taken out of the listing's stock until they are ordered, released or the reservation expires after RESERVATION_TTL (default 5m), and are used first when the user orders the listing. Reserving a listing the user already holds changes its quantity but keeps its expiry. A user may hold MAX_ACTIVE_RESERVATIONS (default 10) listings at once.
+// @Tags Reservations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Listing ID (UUID)"
+// @Param request body ReserveRequest true "Quantity"
+// @Success 201 {object} utils.Response{data=models.StockReservation} "Stock reserved successfully"
+// @Failure 400 {object} utils.Response "Invalid request, insufficient stock or listing cannot be ordered"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Listing not found"
+// @Failure 422 {object} utils.Response "Quantity exceeds the listing's purchase limit or too many active reservations"
+// @Router /listings/{id}/reservations [post]
+func (h *ReservationHandler) Reserve(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	listingID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid listing ID", err)
+	}
+
+	var req ReserveRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+	if req.Qty <= 0 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "qty must be greater than 0", nil)
+	}
+
+	reservation, err := h.reservationService.Reserve(userID, listingID, req.Qty)
+	if err != nil {
+		switch err {
+		case models.ErrNotFound:
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Listing not found", err)
+		case models.ErrInsufficientStock:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Insufficient stock available", err)
+		case models.ErrInvalidInput:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Listing is not active", err)
+		case models.ErrRestaurantSuspended:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Restaurant is currently unavailable", err)
+		case models.ErrPickupWindowClosed:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Pickup window for this listing has ended", err)
+		case models.ErrListingLimitReached:
+			return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "Quantity exceeds the purchase limit for this listing", err)
+		case models.ErrReservationLimitReached:
+			return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "You already hold the maximum number of reservations", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to reserve stock", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Stock reserved successfully", reservation)
+}
+
+// GetMyReservations retrieves the authenticated user's active reservations
+// @Summary Get my reservations
+// @Description Lists the user's active stock reservations, soonest expiry first
+// @Tags Reservations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]models.StockReservation} "Reservations retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Router /reservations/me [get]
+func (h *ReservationHandler) GetMyReservations(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	reservations, err := h.reservationService.GetMyReservations(userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get reservations", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Reservations retrieved successfully", reservations)
+}
+
+// Release gives up one of the authenticated user's reservations
+// @Summary Release reservation
+// @Description Gives up an active reservation and returns its units to stock
+// @Tags Reservations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reservation ID (UUID)"
+// @Success 200 {object} utils.Response "Reservation released successfully"
+// @Failure 400 {object} utils.Response "Reservation is no longer active"
+// @Failure 403 {object} utils.Response "Not your reservation"
+// @Failure 404 {object} utils.Response "Reservation not found"
+// @Router /reservations/{id} [delete]
+func (h *ReservationHandler) Release(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid reservation ID", err)
+	}
+
+	if err := h.reservationService.Release(id, userID); err != nil {
+		switch err {
+		case models.ErrNotFound:
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Reservation not found", err)
+		case models.ErrUnauthorized:
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "You can only release your own reservations", err)
+		case models.ErrReservationNotActive:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Reservation is no longer active", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to release reservation", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Reservation released successfully", nil)
+}
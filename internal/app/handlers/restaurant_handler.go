@@ -3,6 +3,7 @@ package handlers
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"eatright-backend/internal/app/middlewares"
 	"eatright-backend/internal/app/models"
@@ -32,6 +33,40 @@ type CreateRestaurantRequest struct {
 	Lat         float64 `json:"lat"`
 	Lng         float64 `json:"lng"`
 	ClosingTime string  `json:"closing_time"` // Format: "HH:MM:SS"
+	Timezone    string  `json:"timezone"`     // IANA name, default "Asia/Jakarta"
+
+	DailyLimitPerCustomer *int `json:"daily_limit_per_customer"` // Optional limit on items per customer per pickup day
+}
+
+// UpdateRestaurantRequest represents the request body for a partial restaurant update
+type UpdateRestaurantRequest struct {
+	Name        *string  `json:"name"`
+	Address     *string  `json:"address"`
+	Lat         *float64 `json:"lat"`
+	Lng         *float64 `json:"lng"`
+	ClosingTime *string  `json:"closing_time"` // Format: "HH:MM:SS"
+	Timezone    *string  `json:"timezone"`     // IANA name
+
+	DailyLimitPerCustomer *int `json:"daily_limit_per_customer"` // 0 removes the limit
+}
+
+// OpeningHoursRequest represents one weekly open interval
+type OpeningHoursRequest struct {
+	Weekday  int    `json:"weekday"`   // 0 = Sunday ... 6 = Saturday
+	OpensAt  string `json:"opens_at"`  // Format: "HH:MM:SS"
+	ClosesAt string `json:"closes_at"` // Format: "HH:MM:SS"; at or before opens_at runs past midnight
+}
+
+// SetOpeningHoursRequest represents the request body for replacing the weekly schedule
+type SetOpeningHoursRequest struct {
+	Hours []OpeningHoursRequest `json:"hours"`
+}
+
+// CreateClosureRequest represents the request body for closing a restaurant on specific dates
+type CreateClosureRequest struct {
+	StartDate string `json:"start_date"` // Format: "YYYY-MM-DD"
+	EndDate   string `json:"end_date"`   // Format: "YYYY-MM-DD", inclusive
+	Reason    string `json:"reason"`
 }
 
 // CreateRestaurant creates a new restaurant
@@ -71,6 +106,18 @@ func (h *RestaurantHandler) CreateRestaurant(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Missing required fields", nil)
 	}
 
+	if !validLatitude(req.Lat) || !validLongitude(req.Lng) {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid coordinates (lat must be -90..90, lng -180..180)", nil)
+	}
+
+	if req.Timezone != "" && !validTimezone(req.Timezone) {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid timezone (expected IANA name, e.g. Asia/Jakarta)", nil)
+	}
+
+	if req.DailyLimitPerCustomer != nil && *req.DailyLimitPerCustomer <= 0 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "daily_limit_per_customer must be greater than 0", nil)
+	}
+
 	// Parse closing time
 	closingTime := models.TimeOnly{}
 	if err := closingTime.UnmarshalJSON([]byte(`"` + req.ClosingTime + `"`)); err != nil {
@@ -84,6 +131,9 @@ func (h *RestaurantHandler) CreateRestaurant(c *fiber.Ctx) error {
 		Lat:         req.Lat,
 		Lng:         req.Lng,
 		ClosingTime: closingTime,
+		Timezone:    req.Timezone,
+
+		DailyLimitPerCustomer: req.DailyLimitPerCustomer,
 	}
 
 	if err := h.restaurantService.CreateRestaurant(restaurant, userID); err != nil {
@@ -147,14 +197,14 @@ func (h *RestaurantHandler) GetRestaurants(c *fiber.Ctx) error {
 
 // GetRestaurantByID retrieves a restaurant by ID
 // @Summary Get restaurant by ID
-// @Description Retrieves detailed information about a specific restaurant
+// @Description Retrieves detailed information about a specific restaurant, including the estimated impact of its completed orders. Suspended restaurants are not found.
 // @Tags Restaurants
 // @Accept json
 // @Produce json
 // @Param id path string true "Restaurant ID (UUID)"
 // @Success 200 {object} utils.Response{data=models.Restaurant} "Restaurant retrieved successfully"
 // @Failure 400 {object} utils.Response "Invalid restaurant ID"
-// @Failure 404 {object} utils.Response "Restaurant not found"
+// @Failure 404 {object} utils.Response "Restaurant not found or suspended"
 // @Router /restaurants/{id} [get]
 func (h *RestaurantHandler) GetRestaurantByID(c *fiber.Ctx) error {
 	idStr := c.Params("id")
@@ -165,8 +215,359 @@ func (h *RestaurantHandler) GetRestaurantByID(c *fiber.Ctx) error {
 
 	restaurant, err := h.restaurantService.GetRestaurantByID(id)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusNotFound, "Restaurant not found", err)
+		return restaurantErrorResponse(c, err, "Failed to get restaurant")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Restaurant retrieved successfully", restaurant)
 }
+
+// GetRestaurantSavings retrieves a restaurant's order totals
+// @Summary Get restaurant savings
+// @Description Totals a restaurant's completed orders: items rescued, what customers paid and what they saved (admins and members with permission to view orders only)
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Success 200 {object} utils.Response{data=models.SavingsSummary} "Savings retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid restaurant ID"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 404 {object} utils.Response "Restaurant not found"
+// @Router /restaurants/{id}/savings [get]
+func (h *RestaurantHandler) GetRestaurantSavings(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	role, err := middlewares.GetUserRole(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	savings, err := h.restaurantService.GetRestaurantSavings(id, userID, models.UserRole(role))
+	if err != nil {
+		return restaurantErrorResponse(c, err, "Failed to get savings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Savings retrieved successfully", savings)
+}
+
+// GetMyRestaurants retrieves the restaurants owned by the authenticated user
+// @Summary Get my restaurants
+// @Description Retrieves all restaurants owned by the authenticated user, including suspended ones
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]models.Restaurant} "Restaurants retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Router /restaurants/mine [get]
+func (h *RestaurantHandler) GetMyRestaurants(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurants, err := h.restaurantService.GetOwnedRestaurants(userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get restaurants", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Restaurants retrieved successfully", restaurants)
+}
+
+// UpdateRestaurant partially updates a restaurant
+// @Summary Update restaurant
+// @Description Updates only the provided fields of a restaurant (owner and managers only)
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Param request body UpdateRestaurantRequest true "Fields to update"
+// @Success 200 {object} utils.Response{data=models.Restaurant} "Restaurant updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 404 {object} utils.Response "Restaurant not found"
+// @Router /restaurants/{id} [patch]
+func (h *RestaurantHandler) UpdateRestaurant(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	var req UpdateRestaurantRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	// Validate provided fields
+	update := models.RestaurantUpdate{
+		Name:     req.Name,
+		Address:  req.Address,
+		Lat:      req.Lat,
+		Lng:      req.Lng,
+		Timezone: req.Timezone,
+
+		DailyLimitPerCustomer: req.DailyLimitPerCustomer,
+	}
+	if (req.Name != nil && *req.Name == "") || (req.Address != nil && *req.Address == "") {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Name and address cannot be empty", nil)
+	}
+	if (req.Lat != nil && !validLatitude(*req.Lat)) || (req.Lng != nil && !validLongitude(*req.Lng)) {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid coordinates (lat must be -90..90, lng -180..180)", nil)
+	}
+	if req.ClosingTime != nil {
+		closingTime := models.TimeOnly{}
+		if err := closingTime.UnmarshalJSON([]byte(`"` + *req.ClosingTime + `"`)); err != nil || closingTime.IsZero() {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid closing_time format (expected HH:MM:SS)", err)
+		}
+		update.ClosingTime = &closingTime
+	}
+	if req.Timezone != nil && !validTimezone(*req.Timezone) {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid timezone (expected IANA name, e.g. Asia/Jakarta)", nil)
+	}
+	if req.DailyLimitPerCustomer != nil && *req.DailyLimitPerCustomer < 0 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "daily_limit_per_customer cannot be negative (0 removes the limit)", nil)
+	}
+
+	restaurant, err := h.restaurantService.UpdateRestaurant(id, update, userID)
+	if err != nil {
+		return restaurantErrorResponse(c, err, "Failed to update restaurant")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Restaurant updated successfully", restaurant)
+}
+
+// DeleteRestaurant soft-deletes a restaurant
+// @Summary Delete restaurant
+// @Description Soft-deletes a restaurant and deactivates all of its listings. Refused while orders are pending or ready for pickup. (owner only)
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Success 200 {object} utils.Response "Restaurant deleted successfully"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 404 {object} utils.Response "Restaurant not found"
+// @Failure 409 {object} utils.Response "Restaurant has pending orders"
+// @Router /restaurants/{id} [delete]
+func (h *RestaurantHandler) DeleteRestaurant(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	if err := h.restaurantService.DeleteRestaurant(id, userID); err != nil {
+		return restaurantErrorResponse(c, err, "Failed to delete restaurant")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Restaurant deleted successfully", nil)
+}
+
+// SetOpeningHours replaces a restaurant's weekly schedule
+// @Summary Set opening hours
+// @Description Replaces the weekly schedule. Days may have several intervals, which must not overlap; an interval closing at or before its opening time runs past midnight. An empty list falls back to open-until-closing_time. (owner and managers only)
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Param request body SetOpeningHoursRequest true "Weekly schedule"
+// @Success 200 {object} utils.Response{data=models.Restaurant} "Opening hours updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request or overlapping intervals"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 404 {object} utils.Response "Restaurant not found"
+// @Router /restaurants/{id}/hours [put]
+func (h *RestaurantHandler) SetOpeningHours(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	var req SetOpeningHoursRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	hours := make([]models.OpeningHours, 0, len(req.Hours))
+	for _, interval := range req.Hours {
+		if interval.Weekday < 0 || interval.Weekday > 6 {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid weekday (must be 0-6, Sunday = 0)", nil)
+		}
+
+		opensAt, closesAt := models.TimeOnly{}, models.TimeOnly{}
+		if err := opensAt.UnmarshalJSON([]byte(`"` + interval.OpensAt + `"`)); err != nil || opensAt.IsZero() {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid opens_at format (expected HH:MM:SS)", err)
+		}
+		if err := closesAt.UnmarshalJSON([]byte(`"` + interval.ClosesAt + `"`)); err != nil || closesAt.IsZero() {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid closes_at format (expected HH:MM:SS)", err)
+		}
+
+		hours = append(hours, models.OpeningHours{
+			Weekday:  time.Weekday(interval.Weekday),
+			OpensAt:  opensAt,
+			ClosesAt: closesAt,
+		})
+	}
+
+	restaurant, err := h.restaurantService.SetOpeningHours(id, hours, userID)
+	if err != nil {
+		if err == models.ErrOverlappingHours {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Opening hours intervals must not overlap", err)
+		}
+		return restaurantErrorResponse(c, err, "Failed to update opening hours")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Opening hours updated successfully", restaurant)
+}
+
+// CreateClosure closes a restaurant on specific dates
+// @Summary Add closure
+// @Description Closes the restaurant for a date range (holidays, temporary closures), inclusive, in the restaurant's timezone (owner and managers only)
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Param request body CreateClosureRequest true "Closure"
+// @Success 201 {object} utils.Response{data=models.RestaurantClosure} "Closure created successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Router /restaurants/{id}/closures [post]
+func (h *RestaurantHandler) CreateClosure(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	var req CreateClosureRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+	}
+
+	startDate, err := models.ParseDateOnly(req.StartDate)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid start_date format (expected YYYY-MM-DD)", err)
+	}
+	endDate := startDate
+	if req.EndDate != "" {
+		endDate, err = models.ParseDateOnly(req.EndDate)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid end_date format (expected YYYY-MM-DD)", err)
+		}
+	}
+
+	closure := &models.RestaurantClosure{
+		RestaurantID: id,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		Reason:       req.Reason,
+	}
+
+	if err := h.restaurantService.AddClosure(closure, userID); err != nil {
+		if err == models.ErrInvalidInput {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "end_date must not be before start_date", err)
+		}
+		return restaurantErrorResponse(c, err, "Failed to create closure")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Closure created successfully", closure)
+}
+
+// DeleteClosure removes a closure
+// @Summary Remove closure
+// @Description Removes a closure so the weekly schedule applies again (owner and managers only)
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Param closureId path string true "Closure ID (UUID)"
+// @Success 200 {object} utils.Response "Closure removed successfully"
+// @Failure 403 {object} utils.Response "Forbidden - missing restaurant permission"
+// @Failure 404 {object} utils.Response "Restaurant or closure not found"
+// @Router /restaurants/{id}/closures/{closureId} [delete]
+func (h *RestaurantHandler) DeleteClosure(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	closureID, err := uuid.Parse(c.Params("closureId"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid closure ID", err)
+	}
+
+	if err := h.restaurantService.RemoveClosure(id, closureID, userID); err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Restaurant or closure not found", err)
+		}
+		return restaurantErrorResponse(c, err, "Failed to remove closure")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Closure removed successfully", nil)
+}
+
+// restaurantErrorResponse maps restaurant management errors to HTTP responses
+func restaurantErrorResponse(c *fiber.Ctx, err error, message string) error {
+	switch err {
+	case models.ErrNotFound:
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Restaurant not found", err)
+	case models.ErrUnauthorized:
+		return utils.ErrorResponse(c, fiber.StatusForbidden, "You do not have permission for this restaurant", err)
+	case models.ErrHasPendingOrders:
+		return utils.ErrorResponse(c, fiber.StatusConflict, "Restaurant has orders waiting for payment or to be picked up", err)
+	}
+	return utils.ErrorResponse(c, fiber.StatusInternalServerError, message, err)
+}
+
+// validLatitude checks that a latitude is within -90..90
+func validLatitude(lat float64) bool {
+	return lat >= -90 && lat <= 90
+}
+
+// validLongitude checks that a longitude is within -180..180
+func validLongitude(lng float64) bool {
+	return lng >= -180 && lng <= 180
+}
+
+// validTimezone checks that a timezone is a known IANA name
+func validTimezone(name string) bool {
+	if name == "" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
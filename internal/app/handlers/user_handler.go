@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"eatright-backend/internal/app/middlewares"
+	"eatright-backend/internal/app/models"
 	"eatright-backend/internal/app/services"
 	"eatright-backend/internal/app/utils"
 
@@ -22,7 +23,7 @@ func NewUserHandler(userService services.UserService) *UserHandler {
 
 // GetMe retrieves the authenticated user's profile
 // @Summary Get current user profile
-// @Description Retrieves the profile of the currently authenticated user
+// @Description Retrieves the profile of the currently authenticated user, including savings totals from completed orders
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -39,9 +40,12 @@ func (h *UserHandler) GetMe(c *fiber.Ctx) error {
 	}
 
 	// Get user
-	user, err := h.userService.GetUserByID(userID)
+	user, err := h.userService.GetProfile(userID)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found", err)
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get user", err)
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "User retrieved successfully", user)
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"eatright-backend/internal/app/middlewares"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/services"
+	"eatright-backend/internal/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// WaitlistHandler handles waitlist and back-in-stock subscription endpoints
+type WaitlistHandler struct {
+	waitlistService services.WaitlistService
+}
+
+// NewWaitlistHandler creates a new waitlist handler
+func NewWaitlistHandler(waitlistService services.WaitlistService) *WaitlistHandler {
+	return &WaitlistHandler{
+		waitlistService: waitlistService,
+	}
+}
+
+// JoinWaitlistRequest represents the request body for joining a listing's waitlist
+type JoinWaitlistRequest struct {
+	Qty int `json:"qty"` // Defaults to 1
+}
+
+// JoinWaitlist puts the authenticated user in line for a sold-out listing
+// @Summary Join listing waitlist
+// @Description Puts the user in line for a sold-out listing. When it comes back in stock, from a restock or a cancelled order, customers in line are notified in the order they joined over GET /waitlist/me/stream, and the first in line get their units held for WAITLIST_HOLD (default 10m). Joining again keeps the user's place and updates the quantity.
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Listing ID (UUID)"
+// @Param request body JoinWaitlistRequest false "Quantity wanted"
+// @Success 201 {object} utils.Response{data=models.WaitlistEntry} "Joined waitlist successfully"
+// @Failure 400 {object} utils.Response "Invalid request, listing in stock or listing cannot be ordered"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Listing not found"
+// @Failure 422 {object} utils.Response "Quantity exceeds the listing's purchase limit"
+// @Router /listings/{id}/waitlist [post]
+func (h *WaitlistHandler) JoinWaitlist(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	listingID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid listing ID", err)
+	}
+
+	req := JoinWaitlistRequest{Qty: 1}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err)
+		}
+	}
+	if req.Qty <= 0 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "qty must be greater than 0", nil)
+	}
+
+	entry, err := h.waitlistService.JoinWaitlist(userID, listingID, req.Qty)
+	if err != nil {
+		switch err {
+		case models.ErrNotFound:
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Listing not found", err)
+		case models.ErrListingInStock:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Listing is in stock and can be ordered now", err)
+		case models.ErrInvalidInput:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Listing is not active", err)
+		case models.ErrRestaurantSuspended:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Restaurant is currently unavailable", err)
+		case models.ErrPickupWindowClosed:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Pickup window for this listing has ended", err)
+		case models.ErrListingLimitReached:
+			return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "Quantity exceeds the purchase limit for this listing", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to join waitlist", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Joined waitlist successfully", entry)
+}
+
+// LeaveWaitlist takes the authenticated user out of line for a listing
+// @Summary Leave listing waitlist
+// @Description Takes the user out of line for a listing
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Listing ID (UUID)"
+// @Success 200 {object} utils.Response "Left waitlist successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Not on the listing's waitlist"
+// @Router /listings/{id}/waitlist [delete]
+func (h *WaitlistHandler) LeaveWaitlist(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	listingID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid listing ID", err)
+	}
+
+	if err := h.waitlistService.LeaveWaitlist(userID, listingID); err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "You are not on this listing's waitlist", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to leave waitlist", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Left waitlist successfully", nil)
+}
+
+// Subscribe subscribes the authenticated user to a restaurant's back-in-stock notifications
+// @Summary Subscribe to restaurant
+// @Description Notifies the user whenever one of the restaurant's sold-out listings comes back in stock and units are left after its waitlist is served. Subscribing again keeps the existing subscription.
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Success 201 {object} utils.Response{data=models.RestaurantSubscription} "Subscribed successfully"
+// @Failure 400 {object} utils.Response "Invalid restaurant ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Restaurant not found"
+// @Router /restaurants/{id}/subscription [post]
+func (h *WaitlistHandler) Subscribe(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	subscription, err := h.waitlistService.Subscribe(userID, restaurantID)
+	if err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Restaurant not found", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to subscribe", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Subscribed successfully", subscription)
+}
+
+// Unsubscribe stops the authenticated user's back-in-stock notifications for a restaurant
+// @Summary Unsubscribe from restaurant
+// @Description Stops back-in-stock notifications for the restaurant
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Restaurant ID (UUID)"
+// @Success 200 {object} utils.Response "Unsubscribed successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Not subscribed to the restaurant"
+// @Router /restaurants/{id}/subscription [delete]
+func (h *WaitlistHandler) Unsubscribe(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	restaurantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid restaurant ID", err)
+	}
+
+	if err := h.waitlistService.Unsubscribe(userID, restaurantID); err != nil {
+		if err == models.ErrNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "You are not subscribed to this restaurant", err)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to unsubscribe", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Unsubscribed successfully", nil)
+}
+
+// GetMyWaitlist retrieves what the authenticated user is waiting for
+// @Summary Get my waitlist
+// @Description Lists the listings the user waits for, with their place in line, the listings they were notified of, with the reservation holding their units, and the restaurants they subscribe to
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=models.Waitlist} "Waitlist retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Router /waitlist/me [get]
+func (h *WaitlistHandler) GetMyWaitlist(c *fiber.Ctx) error {
+	userID, err := middlewares.GetUserID(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+	}
+
+	waitlist, err := h.waitlistService.GetMyWaitlist(userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get waitlist", err)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Waitlist retrieved successfully", waitlist)
+}
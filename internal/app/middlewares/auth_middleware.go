@@ -2,15 +2,19 @@ package middlewares
 
 import (
 	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/services"
 	"eatright-backend/internal/app/utils"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
-// AuthMiddleware validates JWT token and attaches user claims to context
-func AuthMiddleware(cfg *config.Config) fiber.Handler {
+// AuthMiddleware validates JWT token, rejects revoked tokens and suspended users, and attaches user claims to context
+func AuthMiddleware(cfg *config.Config, authService services.AuthService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Get Authorization header
 		authHeader := c.Get("Authorization")
@@ -35,12 +39,30 @@ func AuthMiddleware(cfg *config.Config) fiber.Handler {
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid or expired token", err)
 		}
 
+		// Reject revoked tokens and suspended accounts
+		if err := authService.ValidateAccessToken(claims); err != nil {
+			switch {
+			case errors.Is(err, models.ErrAccountSuspended):
+				return utils.ErrorResponse(c, fiber.StatusForbidden, "Account has been suspended", err)
+			case errors.Is(err, models.ErrTokenRevoked):
+				return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Token has been revoked", err)
+			case errors.Is(err, models.ErrTokenInvalidClaims), errors.Is(err, models.ErrNotFound):
+				return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid or expired token", err)
+			default:
+				return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to validate session", err)
+			}
+		}
+
 		fmt.Println("DEBUG [Middleware] - Token Valid. UserID:", claims.UserID, "Role:", claims.Role)
 
 		// Store claims in context
 		c.Locals("userID", claims.UserID)
 		c.Locals("userEmail", claims.Email)
-		c.Locals("userRole", claims.Role)
+		c.Locals("userRole", string(claims.Role))
+		c.Locals("tokenID", claims.ID)
+		if claims.ExpiresAt != nil {
+			c.Locals("tokenExpiresAt", claims.ExpiresAt.Time)
+		}
 
 		return c.Next()
 	}
@@ -64,6 +86,21 @@ func GetUserEmail(c *fiber.Ctx) (string, error) {
 	return email, nil
 }
 
+// GetTokenID retrieves the access token ID (jti) from context
+func GetTokenID(c *fiber.Ctx) (uuid.UUID, error) {
+	tokenID, ok := c.Locals("tokenID").(string)
+	if !ok {
+		return uuid.Nil, fiber.NewError(fiber.StatusUnauthorized, "user not authenticated")
+	}
+	return uuid.Parse(tokenID)
+}
+
+// GetTokenExpiresAt retrieves the access token expiry from context
+func GetTokenExpiresAt(c *fiber.Ctx) time.Time {
+	expiresAt, _ := c.Locals("tokenExpiresAt").(time.Time)
+	return expiresAt
+}
+
 // GetUserRole retrieves user role from context
 func GetUserRole(c *fiber.Ctx) (string, error) {
 	role, ok := c.Locals("userRole").(string)
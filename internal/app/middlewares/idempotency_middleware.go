@@ -0,0 +1,77 @@
+package middlewares
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/services"
+	"eatright-backend/internal/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// IdempotencyKeyHeader is the request header carrying the client's idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// Idempotency replays the stored response when an authenticated user retries a
+// request with the same Idempotency-Key header, instead of running the handler
+// again. Reusing a key for a different request is rejected. Requests without
+// the header are passed through. Must run after AuthMiddleware.
+func Idempotency(idempotencyService services.IdempotencyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(IdempotencyKeyHeader)
+		if key == "" {
+			return c.Next()
+		}
+
+		userID, err := GetUserID(c)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
+		}
+
+		// The same key may only be replayed for the same request
+		hash := sha256.New()
+		hash.Write([]byte(c.Method() + " " + c.Path() + "\n"))
+		hash.Write(c.Body())
+		fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+		record, err := idempotencyService.Begin(userID, key, fingerprint)
+		if err != nil {
+			switch err {
+			case models.ErrInvalidInput:
+				return utils.ErrorResponse(c, fiber.StatusBadRequest, "Idempotency-Key must be 1-255 characters", err)
+			case models.ErrIdempotencyKeyReused:
+				return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request", err)
+			case models.ErrIdempotencyKeyInUse:
+				return utils.ErrorResponse(c, fiber.StatusConflict, "A request with this Idempotency-Key is still in progress", err)
+			case models.ErrIdempotencyKeyUnknown:
+				return utils.ErrorResponse(c, fiber.StatusConflict, "The outcome of the request with this Idempotency-Key is unknown; check before retrying with a new key", err)
+			}
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to check Idempotency-Key", err)
+		}
+
+		if record.IsCompleted() {
+			c.Set("Idempotent-Replayed", "true")
+			c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			return c.Status(record.StatusCode).Send(record.Response)
+		}
+
+		// Server errors are not stored so the client can retry with the same key
+		err = c.Next()
+		status := c.Response().StatusCode()
+		if err != nil || status >= fiber.StatusInternalServerError {
+			if releaseErr := idempotencyService.Release(record); releaseErr != nil {
+				log.Printf("❌ Failed to release idempotency key: %v", releaseErr)
+			}
+			return err
+		}
+
+		response := append([]byte(nil), c.Response().Body()...)
+		if err := idempotencyService.Complete(record, status, response); err != nil {
+			log.Printf("❌ Failed to store idempotent response: %v", err)
+		}
+		return nil
+	}
+}
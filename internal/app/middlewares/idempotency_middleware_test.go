@@ -0,0 +1,253 @@
+package middlewares
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// memoryIdempotencyRepository keeps idempotency keys in memory, taking over
+// keys the way the Postgres repository does
+type memoryIdempotencyRepository struct {
+	mu   sync.Mutex
+	keys map[string]models.IdempotencyKey
+}
+
+func newMemoryIdempotencyRepository() *memoryIdempotencyRepository {
+	return &memoryIdempotencyRepository{keys: make(map[string]models.IdempotencyKey)}
+}
+
+func (r *memoryIdempotencyRepository) Reserve(key *models.IdempotencyKey, staleBefore time.Time) (*models.IdempotencyKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := key.UserID.String() + "/" + key.Key
+	existing, ok := r.keys[id]
+	if ok && existing.ExpiresAt.After(key.CreatedAt) &&
+		!(existing.IsInProgress() && existing.CreatedAt.Before(staleBefore)) {
+		return &existing, nil
+	}
+	key.ID = uuid.New()
+	r.keys[id] = *key
+	return nil, nil
+}
+
+func (r *memoryIdempotencyRepository) Complete(key *models.IdempotencyKey) error {
+	return r.update(key, func(stored *models.IdempotencyKey) {
+		stored.StatusCode = key.StatusCode
+		stored.Response = key.Response
+	})
+}
+
+func (r *memoryIdempotencyRepository) MarkUnknown(key *models.IdempotencyKey) error {
+	return r.update(key, func(stored *models.IdempotencyKey) {
+		if stored.IsInProgress() {
+			stored.StatusCode = models.IdempotencyStatusUnknown
+		}
+	})
+}
+
+func (r *memoryIdempotencyRepository) Release(key *models.IdempotencyKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := key.UserID.String() + "/" + key.Key
+	if stored, ok := r.keys[id]; ok && stored.ID == key.ID && stored.IsInProgress() {
+		delete(r.keys, id)
+	}
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) DeleteExpiredWithTx(tx *gorm.DB, now time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+// update applies change to the stored copy of key, if it still holds key
+func (r *memoryIdempotencyRepository) update(key *models.IdempotencyKey, change func(stored *models.IdempotencyKey)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := key.UserID.String() + "/" + key.Key
+	if stored, ok := r.keys[id]; ok && stored.ID == key.ID {
+		change(&stored)
+		r.keys[id] = stored
+	}
+	return nil
+}
+
+// idempotentApp serves POST /orders behind the idempotency middleware as
+// userID. The handler answers with status and counts its calls; while block
+// is non-nil it signals started and waits for block to close.
+type idempotentApp struct {
+	app     *fiber.App
+	mu      sync.Mutex
+	calls   int
+	status  int
+	started chan struct{}
+	block   chan struct{}
+}
+
+func newIdempotentApp(userID uuid.UUID) *idempotentApp {
+	a := &idempotentApp{status: fiber.StatusCreated}
+	service := services.NewIdempotencyService(newMemoryIdempotencyRepository(), &config.Config{
+		Orders: config.OrderConfig{IdempotencyKeyTTL: time.Hour},
+	})
+
+	a.app = fiber.New()
+	a.app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userID", userID)
+		return c.Next()
+	})
+	a.app.Post("/orders", Idempotency(service), func(c *fiber.Ctx) error {
+		a.mu.Lock()
+		a.calls++
+		calls, status, started, block := a.calls, a.status, a.started, a.block
+		a.mu.Unlock()
+
+		if block != nil {
+			started <- struct{}{}
+			<-block
+		}
+		return c.Status(status).JSON(fiber.Map{"order": calls})
+	})
+	return a
+}
+
+// post sends body to POST /orders with the Idempotency-Key header set to key,
+// if any, and returns the status, body and whether the response was replayed
+func (a *idempotentApp) post(t *testing.T, key, body string) (int, string, bool) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	resp, err := a.app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("send request: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+	return resp.StatusCode, string(data), resp.Header.Get("Idempotent-Replayed") == "true"
+}
+
+func (a *idempotentApp) callCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.calls
+}
+
+func TestIdempotencyReplaysStoredResponse(t *testing.T) {
+	a := newIdempotentApp(uuid.New())
+
+	status, first, replayed := a.post(t, "order-1", `{"listing_id":"a","qty":1}`)
+	if status != fiber.StatusCreated || replayed {
+		t.Fatalf("first request: status = %d, replayed = %v, want 201 from the handler", status, replayed)
+	}
+
+	status, again, replayed := a.post(t, "order-1", `{"listing_id":"a","qty":1}`)
+	if status != fiber.StatusCreated || !replayed {
+		t.Errorf("retry: status = %d, replayed = %v, want replayed 201", status, replayed)
+	}
+	if again != first {
+		t.Errorf("retry body = %s, want %s", again, first)
+	}
+	if calls := a.callCount(); calls != 1 {
+		t.Errorf("handler ran %d times, want 1", calls)
+	}
+
+	// Without a key, or with a new one, the request runs again
+	if _, _, replayed := a.post(t, "", `{"listing_id":"a","qty":1}`); replayed {
+		t.Error("request without a key was replayed")
+	}
+	if _, _, replayed := a.post(t, "order-2", `{"listing_id":"a","qty":1}`); replayed {
+		t.Error("request with a new key was replayed")
+	}
+	if calls := a.callCount(); calls != 3 {
+		t.Errorf("handler ran %d times, want 3", calls)
+	}
+}
+
+func TestIdempotencyRejectsKeyReusedForDifferentBody(t *testing.T) {
+	a := newIdempotentApp(uuid.New())
+
+	if status, _, _ := a.post(t, "order-1", `{"listing_id":"a","qty":1}`); status != fiber.StatusCreated {
+		t.Fatalf("first request: status = %d, want 201", status)
+	}
+	status, body, replayed := a.post(t, "order-1", `{"listing_id":"a","qty":2}`)
+	if status != fiber.StatusUnprocessableEntity || replayed {
+		t.Errorf("reused key: status = %d, replayed = %v, want 422", status, replayed)
+	}
+	if !strings.Contains(body, "different request") {
+		t.Errorf("reused key: body = %s, want the reuse error", body)
+	}
+	if calls := a.callCount(); calls != 1 {
+		t.Errorf("handler ran %d times, want 1", calls)
+	}
+}
+
+func TestIdempotencyRejectsRequestInProgress(t *testing.T) {
+	a := newIdempotentApp(uuid.New())
+	a.started = make(chan struct{})
+	a.block = make(chan struct{})
+
+	done := make(chan int)
+	go func() {
+		status, _, _ := a.post(t, "order-1", `{"listing_id":"a","qty":1}`)
+		done <- status
+	}()
+	<-a.started
+
+	status, body, _ := a.post(t, "order-1", `{"listing_id":"a","qty":1}`)
+	if status != fiber.StatusConflict {
+		t.Errorf("concurrent retry: status = %d, want 409", status)
+	}
+	if !strings.Contains(body, "still in progress") {
+		t.Errorf("concurrent retry: body = %s, want the in-progress error", body)
+	}
+
+	close(a.block)
+	if status := <-done; status != fiber.StatusCreated {
+		t.Errorf("first request: status = %d, want 201", status)
+	}
+	if calls := a.callCount(); calls != 1 {
+		t.Errorf("handler ran %d times, want 1", calls)
+	}
+
+	// Once finished, retries replay the first response
+	a.block = nil
+	if status, _, replayed := a.post(t, "order-1", `{"listing_id":"a","qty":1}`); status != fiber.StatusCreated || !replayed {
+		t.Errorf("retry after completion: status = %d, replayed = %v, want replayed 201", status, replayed)
+	}
+}
+
+func TestIdempotencyRetriesServerErrors(t *testing.T) {
+	a := newIdempotentApp(uuid.New())
+	a.status = fiber.StatusInternalServerError
+
+	if status, _, _ := a.post(t, "order-1", `{"listing_id":"a","qty":1}`); status != fiber.StatusInternalServerError {
+		t.Fatalf("first request: status = %d, want 500", status)
+	}
+
+	a.status = fiber.StatusCreated
+	status, _, replayed := a.post(t, "order-1", `{"listing_id":"a","qty":1}`)
+	if status != fiber.StatusCreated || replayed {
+		t.Errorf("retry: status = %d, replayed = %v, want 201 from the handler", status, replayed)
+	}
+	if calls := a.callCount(); calls != 2 {
+		t.Errorf("handler ran %d times, want 2", calls)
+	}
+}
@@ -7,18 +7,25 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-// RestaurantOnly ensures only users with restaurant role can access the endpoint
-func RestaurantOnly() fiber.Handler {
+// RequireRole ensures the authenticated user has one of the given roles
+func RequireRole(roles ...models.UserRole) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		role, err := GetUserRole(c)
 		if err != nil {
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", err)
 		}
 
-		if role != string(models.RoleRestaurant) {
-			return utils.ErrorResponse(c, fiber.StatusForbidden, "Restaurant role required", nil)
+		for _, allowed := range roles {
+			if role == string(allowed) {
+				return c.Next()
+			}
 		}
 
-		return c.Next()
+		return utils.ErrorResponse(c, fiber.StatusForbidden, "Insufficient role for this endpoint", nil)
 	}
 }
+
+// RestaurantOnly ensures only users with restaurant role can access the endpoint
+func RestaurantOnly() fiber.Handler {
+	return RequireRole(models.RoleRestaurant)
+}
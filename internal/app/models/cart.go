@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CartItem is a listing and quantity in a user's cart. A cart holds listings
+// from one restaurant only.
+type CartItem struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_cart_items_user_listing" json:"user_id"`
+	ListingID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_cart_items_user_listing" json:"listing_id"`
+	Qty       int       `gorm:"not null" json:"qty"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	Listing Listing `gorm:"foreignKey:ListingID" json:"listing,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID before creating
+func (i *CartItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for CartItem model
+func (CartItem) TableName() string {
+	return "cart_items"
+}
+
+// Cart is a user's cart priced at current listing prices
+type Cart struct {
+	RestaurantID *uuid.UUID `json:"restaurant_id"` // Nil while the cart is empty
+	Items        []CartItem `json:"items"`
+	Qty          int        `json:"qty"`
+	TotalPrice   int        `json:"total_price"`
+	Savings      int        `json:"savings"`
+}
+
+// NewCart prices cart items, whose listings must be loaded, at now
+func NewCart(items []CartItem, now time.Time) *Cart {
+	cart := &Cart{Items: items}
+	if cart.Items == nil {
+		cart.Items = []CartItem{}
+	}
+	for _, item := range cart.Items {
+		restaurantID := item.Listing.RestaurantID
+		cart.RestaurantID = &restaurantID
+
+		price := item.Listing.PriceAt(now)
+		cart.Qty += item.Qty
+		cart.TotalPrice += price * item.Qty
+		cart.Savings += item.Listing.SavingsPerItem(price) * item.Qty
+	}
+	return cart
+}
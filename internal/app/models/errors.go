@@ -12,4 +12,51 @@ var (
 	ErrDuplicateEntry          = errors.New("duplicate entry")
 	ErrNegativeStock           = errors.New("stock cannot be negative")
 	ErrInvalidQuantity         = errors.New("quantity must be greater than zero")
+	ErrAlreadyPartner          = errors.New("user is already a restaurant partner")
+	ErrAccountSuspended        = errors.New("account has been suspended")
+	ErrRestaurantSuspended     = errors.New("restaurant has been suspended")
+	ErrDeactivatedByAdmin      = errors.New("listing was deactivated by an admin")
+	ErrRestaurantClosed        = errors.New("restaurant is closed at the pickup time")
+	ErrOverlappingHours        = errors.New("opening hours intervals must not overlap")
+	ErrInvalidPickupWindow     = errors.New("pickup window must end after it starts and lie within opening hours")
+	ErrPickupWindowClosed      = errors.New("pickup window has ended")
+	ErrInvalidOriginalValue    = errors.New("original value must be greater than the price")
+	ErrInvalidPriceSchedule    = errors.New("price schedule steps must have positive minutes and 1-100 percent off, with a floor between 0 and the price")
+	ErrOccurrenceCreated       = errors.New("listing for this occurrence has already been created")
+	ErrCancellationClosed      = errors.New("order can no longer be cancelled")
+	ErrInvalidPickupCode       = errors.New("pickup code is invalid")
+	ErrPickupLocked            = errors.New("too many invalid pickup codes, try again later")
+	ErrPickupCodeRequired      = errors.New("orders are completed by verifying the pickup code")
+	ErrCartEmpty               = errors.New("cart is empty")
+	ErrCartRestaurantMismatch  = errors.New("cart can only hold listings from one restaurant")
+	ErrCartChanged             = errors.New("cart changed during checkout")
+	ErrPickupWindowsDisjoint   = errors.New("listings have no pickup time in common")
+	ErrInvalidPurchaseLimit    = errors.New("purchase limit must be greater than zero")
+	ErrListingLimitReached     = errors.New("purchase limit per customer for this listing reached")
+	ErrDailyLimitReached       = errors.New("daily purchase limit per customer for this restaurant reached")
+	ErrListingInStock          = errors.New("listing is in stock")
+	ErrReservationNotActive    = errors.New("reservation is no longer active")
+	ErrReservationLimitReached = errors.New("maximum number of active reservations reached")
+	ErrPaymentUnavailable      = errors.New("payment could not be started")
+	ErrInvalidWebhook          = errors.New("webhook signature is invalid")
+	ErrNotRefundable           = errors.New("order has no captured payment to refund")
+	ErrRefundExceedsPayment    = errors.New("refund amount exceeds what is left of the payment")
+	ErrRefundWindowClosed      = errors.New("refunds can no longer be requested for this order")
+	ErrRefundAlreadyRequested  = errors.New("order already has a refund request waiting for a decision")
+	ErrIdempotencyKeyReused    = errors.New("idempotency key was already used for a different request")
+	ErrIdempotencyKeyInUse     = errors.New("a request with this idempotency key is still in progress")
+	ErrIdempotencyKeyUnknown   = errors.New("the outcome of the request with this idempotency key is unknown")
+	ErrHasPendingOrders        = errors.New("restaurant has pending orders")
+	ErrInvitationExpired       = errors.New("invitation has expired")
+	ErrOwnerRequired           = errors.New("restaurant ownership cannot be assigned, removed or demoted")
+
+	// Token verification errors
+	ErrTokenExpired          = errors.New("token has expired")
+	ErrTokenInvalidSignature = errors.New("token signature is invalid")
+	ErrTokenMalformed        = errors.New("token is malformed")
+	ErrTokenInvalidClaims    = errors.New("token claims are invalid")
+	ErrTokenRevoked          = errors.New("token has been revoked")
+	ErrIdentityUnavailable   = errors.New("identity provider is unavailable")
+	ErrInvalidRefreshToken   = errors.New("refresh token is invalid")
+	ErrRefreshTokenReused    = errors.New("refresh token has already been used")
 )
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey records a request made with an Idempotency-Key header so that
+// retries replay the original response instead of repeating the request.
+// Keys are scoped to the user who sent them.
+type IdempotencyKey struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_idempotency_keys_user_key" json:"user_id"`
+	Key         string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_user_key" json:"key"`
+	Fingerprint string    `gorm:"type:varchar(64);not null" json:"-"`    // SHA-256 of the method, path and body
+	StatusCode  int       `gorm:"not null;default:0" json:"status_code"` // Zero while the request is in progress, IdempotencyStatusUnknown if its response was lost
+	Response    []byte    `gorm:"type:bytea" json:"-"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ExpiresAt   time.Time `gorm:"not null;index" json:"expires_at"`
+}
+
+// IdempotencyStatusUnknown marks a key whose request finished but whose response
+// could not be stored. Retries are refused, since the request may have taken effect.
+const IdempotencyStatusUnknown = -1
+
+// BeforeCreate hook to generate UUID before creating
+func (k *IdempotencyKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for IdempotencyKey model
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// IsCompleted checks if the original request finished and its response was stored
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode > 0
+}
+
+// IsInProgress checks if the original request has not finished yet
+func (k *IdempotencyKey) IsInProgress() bool {
+	return k.StatusCode == 0
+}
@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+// ImpactPeriod is the bucket size of an impact time series
+type ImpactPeriod string
+
+const (
+	ImpactPeriodDay   ImpactPeriod = "day"
+	ImpactPeriodWeek  ImpactPeriod = "week"
+	ImpactPeriodMonth ImpactPeriod = "month"
+)
+
+// IsValid checks if the period is a known bucket size
+func (p ImpactPeriod) IsValid() bool {
+	return p == ImpactPeriodDay || p == ImpactPeriodWeek || p == ImpactPeriodMonth
+}
+
+// Start returns the start of the bucket containing t, in t's location.
+// Weeks start on Monday.
+func (p ImpactPeriod) Start(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch p {
+	case ImpactPeriodWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case ImpactPeriodMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day
+}
+
+// Next returns the start of the bucket after the one starting at start
+func (p ImpactPeriod) Next(start time.Time) time.Time {
+	switch p {
+	case ImpactPeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case ImpactPeriodMonth:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// ImpactFactor estimates the food weight and emissions of one item of a listing type
+type ImpactFactor struct {
+	WeightKg  float64
+	CO2ePerKg float64
+}
+
+// Impact is the estimated food waste avoided by completed orders
+type Impact struct {
+	MealsRescued  int64   `json:"meals_rescued"`
+	FoodSavedKg   float64 `json:"food_saved_kg"`
+	CO2eAvoidedKg float64 `json:"co2e_avoided_kg"`
+}
+
+// Add adds qty rescued items with the given factor
+func (i *Impact) Add(qty int64, factor ImpactFactor) {
+	kg := float64(qty) * factor.WeightKg
+	i.MealsRescued += qty
+	i.FoodSavedKg += kg
+	i.CO2eAvoidedKg += kg * factor.CO2ePerKg
+}
+
+// ImpactPoint is the impact within one bucket of a time series
+type ImpactPoint struct {
+	PeriodStart DateOnly `json:"period_start"`
+	Impact
+}
+
+// PlatformImpact is the platform-wide impact with a time series
+type PlatformImpact struct {
+	Total  Impact        `json:"total"`
+	Period ImpactPeriod  `json:"period"`
+	From   DateOnly      `json:"from"`
+	To     DateOnly      `json:"to"`
+	Series []ImpactPoint `json:"series"`
+}
+
+// ItemsByType is the number of items in completed orders of one listing type,
+// optionally within a time bucket
+type ItemsByType struct {
+	PeriodStart time.Time
+	Type        ListingType
+	Items       int64
+}
@@ -15,6 +15,17 @@ const (
 	ListingTypeReveal     ListingType = "reveal"
 )
 
+// DeactivationReason records why a listing stopped being active
+type DeactivationReason string
+
+const (
+	DeactivationManual            DeactivationReason = "manual"
+	DeactivationAdmin             DeactivationReason = "admin"
+	DeactivationPickupEnded       DeactivationReason = "pickup_ended"
+	DeactivationRestaurantClosed  DeactivationReason = "restaurant_closed"
+	DeactivationRestaurantDeleted DeactivationReason = "restaurant_deleted"
+)
+
 // Listing represents a food listing (mystery box or reveal item)
 type Listing struct {
 	ID           uuid.UUID   `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
@@ -25,13 +36,39 @@ type Listing struct {
 	Price        int         `gorm:"not null" json:"price"` // Price in smallest currency unit (e.g., cents)
 	Stock        int         `gorm:"not null;default:0" json:"stock"`
 	PhotoURL     string      `gorm:"type:text" json:"photo_url"`
-	PickupTime   TimeOnly    `gorm:"type:time;not null" json:"pickup_time"`
+	PickupStart  time.Time   `gorm:"not null" json:"pickup_start"`
+	PickupEnd    time.Time   `gorm:"not null;index" json:"pickup_end"`
 	IsActive     bool        `gorm:"default:true" json:"is_active"`
-	CreatedAt    time.Time   `gorm:"autoCreateTime" json:"created_at"`
+
+	// Retail value of the food, above Price; nil if not provided
+	OriginalValue *int `json:"original_value,omitempty"`
+
+	// Most units one customer may hold across their non-cancelled orders; nil for no limit
+	MaxPerCustomer *int `json:"max_per_customer,omitempty"`
+
+	// Optional markdown as the pickup window ends; Price stays the undiscounted price
+	PriceSchedule *PriceSchedule `gorm:"type:jsonb" json:"price_schedule,omitempty"`
+
+	// Set when the listing was created from a template
+	TemplateID     *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_listings_template_occurrence" json:"template_id,omitempty"`
+	OccurrenceDate *DateOnly  `gorm:"type:date;uniqueIndex:idx_listings_template_occurrence" json:"occurrence_date,omitempty"`
+
+	// Set when the listing is deactivated, cleared when reactivated
+	DeactivatedAt      *time.Time          `json:"deactivated_at,omitempty"`
+	DeactivationReason *DeactivationReason `gorm:"type:varchar(30)" json:"deactivation_reason,omitempty"`
+
+	// Set when stock goes from zero back up, cleared once the waitlist is notified
+	RestockedAt *time.Time `gorm:"index" json:"-"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Computed pricing, not stored
+	CurrentPrice    *int       `gorm:"-" json:"current_price,omitempty"`
+	NextPriceDropAt *time.Time `gorm:"-" json:"next_price_drop_at,omitempty"`
 
 	// Relationships
-	Restaurant Restaurant `gorm:"foreignKey:RestaurantID" json:"restaurant,omitempty"`
-	Orders     []Order    `gorm:"foreignKey:ListingID" json:"orders,omitempty"`
+	Restaurant Restaurant  `gorm:"foreignKey:RestaurantID" json:"restaurant,omitempty"`
+	OrderItems []OrderItem `gorm:"foreignKey:ListingID" json:"order_items,omitempty"`
 }
 
 // BeforeCreate hook to generate UUID before creating
@@ -52,11 +89,22 @@ func (l *Listing) IsMysteryBox() bool {
 	return l.Type == ListingTypeMysteryBox
 }
 
+// IsDeactivatedByAdmin checks if an admin force-deactivated the listing
+func (l *Listing) IsDeactivatedByAdmin() bool {
+	return l.DeactivationReason != nil && *l.DeactivationReason == DeactivationAdmin
+}
+
 // HasStock checks if the listing has available stock
 func (l *Listing) HasStock(qty int) bool {
 	return l.Stock >= qty
 }
 
+// IsOrderable checks if the listing can be ordered at now, stock aside.
+// The restaurant must be loaded.
+func (l *Listing) IsOrderable(now time.Time) bool {
+	return l.IsActive && !l.Restaurant.IsSuspended() && !l.IsPickupOver(now)
+}
+
 // DecrementStock reduces the stock by the given quantity
 func (l *Listing) DecrementStock(qty int) error {
 	if !l.HasStock(qty) {
@@ -65,3 +113,75 @@ func (l *Listing) DecrementStock(qty int) error {
 	l.Stock -= qty
 	return nil
 }
+
+// IsPickupOver checks if the pickup window has ended
+func (l *Listing) IsPickupOver(now time.Time) bool {
+	return !now.Before(l.PickupEnd)
+}
+
+// LocalizePickup expresses the pickup window in the restaurant's timezone
+func (l *Listing) LocalizePickup() {
+	if l.Restaurant.ID == uuid.Nil {
+		return
+	}
+	loc := l.Restaurant.Location()
+	l.PickupStart = l.PickupStart.In(loc)
+	l.PickupEnd = l.PickupEnd.In(loc)
+}
+
+// ValidateOriginalValue checks that the original value, if set, is above the price
+func (l *Listing) ValidateOriginalValue() error {
+	if l.OriginalValue != nil && *l.OriginalValue <= l.Price {
+		return ErrInvalidOriginalValue
+	}
+	return nil
+}
+
+// ValidateMaxPerCustomer checks that the per-customer limit, if set, is positive
+func (l *Listing) ValidateMaxPerCustomer() error {
+	if l.MaxPerCustomer != nil && *l.MaxPerCustomer <= 0 {
+		return ErrInvalidPurchaseLimit
+	}
+	return nil
+}
+
+// ExceedsMaxPerCustomer checks if ordering qty more units would take a customer
+// who already ordered some past the per-customer limit
+func (l *Listing) ExceedsMaxPerCustomer(ordered, qty int) bool {
+	return l.MaxPerCustomer != nil && ordered+qty > *l.MaxPerCustomer
+}
+
+// SavingsPerItem returns what a customer saves per item when charged unitPrice,
+// against the original value or, if unknown, the undiscounted price
+func (l *Listing) SavingsPerItem(unitPrice int) int {
+	reference := l.Price
+	if l.OriginalValue != nil {
+		reference = *l.OriginalValue
+	}
+	if reference < unitPrice {
+		return 0
+	}
+	return reference - unitPrice
+}
+
+// PriceAt returns the price charged at time t after any markdown
+func (l *Listing) PriceAt(t time.Time) int {
+	if l.PriceSchedule == nil {
+		return l.Price
+	}
+	return l.PriceSchedule.PriceAt(l.Price, l.PickupEnd, t)
+}
+
+// SetPricing computes the current price and the next price drop at now
+func (l *Listing) SetPricing(now time.Time) {
+	price := l.PriceAt(now)
+	l.CurrentPrice = &price
+	l.NextPriceDropAt = nil
+	if l.PriceSchedule != nil {
+		l.NextPriceDropAt = l.PriceSchedule.NextDropAfter(l.Price, l.PickupEnd, now)
+		if l.NextPriceDropAt != nil && l.Restaurant.ID != uuid.Nil {
+			local := l.NextPriceDropAt.In(l.Restaurant.Location())
+			l.NextPriceDropAt = &local
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WeekdaySet is a set of weekdays stored as a bitmask (bit 0 = Sunday).
+// It is exposed in JSON as a list of weekday numbers, e.g. [1,2,3,4,5].
+type WeekdaySet uint8
+
+// NewWeekdaySet builds a set from weekday numbers, rejecting values outside 0-6
+func NewWeekdaySet(days []int) (WeekdaySet, error) {
+	var set WeekdaySet
+	for _, day := range days {
+		if day < 0 || day > 6 {
+			return 0, ErrInvalidInput
+		}
+		set |= 1 << uint(day)
+	}
+	return set, nil
+}
+
+// Has checks if the set contains the weekday
+func (s WeekdaySet) Has(day time.Weekday) bool {
+	return s&(1<<uint(day)) != 0
+}
+
+// Days returns the weekday numbers in the set
+func (s WeekdaySet) Days() []int {
+	days := []int{}
+	for day := 0; day < 7; day++ {
+		if s.Has(time.Weekday(day)) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// MarshalJSON implements json.Marshaler
+func (s WeekdaySet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Days())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *WeekdaySet) UnmarshalJSON(data []byte) error {
+	var days []int
+	if err := json.Unmarshal(data, &days); err != nil {
+		return err
+	}
+	set, err := NewWeekdaySet(days)
+	if err != nil {
+		return err
+	}
+	*s = set
+	return nil
+}
+
+// ListingTemplate describes a listing that is created automatically on recurring weekdays
+type ListingTemplate struct {
+	ID           uuid.UUID   `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID   `gorm:"type:uuid;not null;index" json:"restaurant_id"`
+	Type         ListingType `gorm:"type:varchar(20);not null" json:"type"`
+	Name         *string     `gorm:"type:varchar(255)" json:"name"`
+	Description  string      `gorm:"type:text;not null" json:"description"`
+	Price        int         `gorm:"not null" json:"price"`
+	Stock        int         `gorm:"not null" json:"stock"` // Default stock per occurrence
+	PhotoURL     string      `gorm:"type:text" json:"photo_url"`
+	Weekdays     WeekdaySet  `gorm:"type:smallint;not null" json:"weekdays"`
+	PickupStart  TimeOnly    `gorm:"type:time;not null" json:"pickup_start"` // Restaurant local time
+	PickupEnd    TimeOnly    `gorm:"type:time;not null" json:"pickup_end"`   // At or before start runs past midnight
+	IsActive     bool        `gorm:"default:true" json:"is_active"`
+
+	OriginalValue *int           `json:"original_value,omitempty"`
+	PriceSchedule *PriceSchedule `gorm:"type:jsonb" json:"price_schedule,omitempty"` // Copied to each occurrence
+
+	MaxPerCustomer *int `json:"max_per_customer,omitempty"` // Copied to each occurrence
+
+	CreatedBy uuid.UUID `gorm:"type:uuid;not null" json:"created_by"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	Restaurant Restaurant                  `gorm:"foreignKey:RestaurantID" json:"-"`
+	Overrides  []ListingOccurrenceOverride `gorm:"foreignKey:TemplateID" json:"overrides,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID before creating
+func (t *ListingTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ListingTemplate model
+func (ListingTemplate) TableName() string {
+	return "listing_templates"
+}
+
+// Validate checks the recurrence rule and defaults
+func (t *ListingTemplate) Validate() error {
+	if t.Type != ListingTypeMysteryBox && t.Type != ListingTypeReveal {
+		return ErrInvalidInput
+	}
+	if t.Weekdays == 0 || t.Price < 0 {
+		return ErrInvalidInput
+	}
+	if t.Stock < 0 {
+		return ErrNegativeStock
+	}
+	if t.OriginalValue != nil && *t.OriginalValue <= t.Price {
+		return ErrInvalidOriginalValue
+	}
+	if t.PickupStart.Format("15:04") == t.PickupEnd.Format("15:04") {
+		return ErrInvalidPickupWindow
+	}
+	if t.MaxPerCustomer != nil && *t.MaxPerCustomer <= 0 {
+		return ErrInvalidPurchaseLimit
+	}
+	if t.PriceSchedule != nil {
+		return t.PriceSchedule.Validate(t.Price)
+	}
+	return nil
+}
+
+// OverrideFor returns the override for a date, if any
+func (t *ListingTemplate) OverrideFor(date DateOnly) *ListingOccurrenceOverride {
+	for i := range t.Overrides {
+		if t.Overrides[i].Date.Equal(date.Time) {
+			return &t.Overrides[i]
+		}
+	}
+	return nil
+}
+
+// Occurrence builds the listing for the given date in the restaurant's timezone,
+// or returns nil if the template does not run that day or the occurrence is skipped
+func (t *ListingTemplate) Occurrence(restaurant *Restaurant, day time.Time) *Listing {
+	local := day.In(restaurant.Location())
+	if !t.Weekdays.Has(local.Weekday()) {
+		return nil
+	}
+
+	date := NewDateOnly(local)
+	stock := t.Stock
+	if override := t.OverrideFor(date); override != nil {
+		if override.Skip {
+			return nil
+		}
+		if override.Stock != nil {
+			stock = *override.Stock
+		}
+	}
+
+	start := restaurant.At(local, t.PickupStart)
+	end := restaurant.At(local, t.PickupEnd)
+	if !end.After(start) {
+		end = restaurant.At(local.AddDate(0, 0, 1), t.PickupEnd)
+	}
+
+	templateID := t.ID
+	return &Listing{
+		RestaurantID:   t.RestaurantID,
+		Type:           t.Type,
+		Name:           t.Name,
+		Description:    t.Description,
+		Price:          t.Price,
+		Stock:          stock,
+		PhotoURL:       t.PhotoURL,
+		PickupStart:    start,
+		PickupEnd:      end,
+		IsActive:       true,
+		OriginalValue:  t.OriginalValue,
+		PriceSchedule:  t.PriceSchedule,
+		MaxPerCustomer: t.MaxPerCustomer,
+		TemplateID:     &templateID,
+		OccurrenceDate: &date,
+	}
+}
+
+// ListingTemplateUpdate holds a partial template update; nil fields are left unchanged
+type ListingTemplateUpdate struct {
+	Name        *string
+	Description *string
+	Price       *int
+	Stock       *int
+	PhotoURL    *string
+	Weekdays    *WeekdaySet
+	PickupStart *TimeOnly
+	PickupEnd   *TimeOnly
+	IsActive    *bool
+
+	OriginalValue      *int
+	PriceSchedule      *PriceSchedule
+	ClearPriceSchedule bool
+
+	MaxPerCustomer      *int
+	ClearMaxPerCustomer bool
+}
+
+// Apply copies the set fields onto the template
+func (u ListingTemplateUpdate) Apply(t *ListingTemplate) {
+	if u.Name != nil {
+		t.Name = u.Name
+	}
+	if u.Description != nil {
+		t.Description = *u.Description
+	}
+	if u.Price != nil {
+		t.Price = *u.Price
+	}
+	if u.Stock != nil {
+		t.Stock = *u.Stock
+	}
+	if u.PhotoURL != nil {
+		t.PhotoURL = *u.PhotoURL
+	}
+	if u.Weekdays != nil {
+		t.Weekdays = *u.Weekdays
+	}
+	if u.PickupStart != nil {
+		t.PickupStart = *u.PickupStart
+	}
+	if u.PickupEnd != nil {
+		t.PickupEnd = *u.PickupEnd
+	}
+	if u.IsActive != nil {
+		t.IsActive = *u.IsActive
+	}
+	if u.OriginalValue != nil {
+		t.OriginalValue = u.OriginalValue
+	}
+	if u.PriceSchedule != nil {
+		t.PriceSchedule = u.PriceSchedule
+	} else if u.ClearPriceSchedule {
+		t.PriceSchedule = nil
+	}
+	if u.MaxPerCustomer != nil {
+		t.MaxPerCustomer = u.MaxPerCustomer
+	} else if u.ClearMaxPerCustomer {
+		t.MaxPerCustomer = nil
+	}
+}
+
+// ListingOccurrenceOverride skips or changes the stock of a single template occurrence
+type ListingOccurrenceOverride struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TemplateID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_listing_template_overrides_date" json:"template_id"`
+	Date       DateOnly  `gorm:"type:date;not null;uniqueIndex:idx_listing_template_overrides_date" json:"date"`
+	Skip       bool      `gorm:"not null;default:false" json:"skip"`
+	Stock      *int      `json:"stock,omitempty"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID before creating
+func (o *ListingOccurrenceOverride) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ListingOccurrenceOverride model
+func (ListingOccurrenceOverride) TableName() string {
+	return "listing_template_overrides"
+}
@@ -0,0 +1,31 @@
+package models
+
+import "testing"
+
+func TestExceedsMaxPerCustomer(t *testing.T) {
+	limit := 3
+	tests := []struct {
+		name    string
+		limit   *int
+		ordered int
+		qty     int
+		exceeds bool
+	}{
+		{name: "no limit", limit: nil, ordered: 50, qty: 50},
+		{name: "first order under the limit", limit: &limit, ordered: 0, qty: 2},
+		{name: "reaching the limit", limit: &limit, ordered: 2, qty: 1},
+		{name: "one past the limit", limit: &limit, ordered: 2, qty: 2, exceeds: true},
+		{name: "single order past the limit", limit: &limit, ordered: 0, qty: 4, exceeds: true},
+		// A lowered limit leaves the customer already past it
+		{name: "already past a lowered limit", limit: &limit, ordered: 4, qty: 1, exceeds: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listing := &Listing{MaxPerCustomer: tt.limit}
+			if got := listing.ExceedsMaxPerCustomer(tt.ordered, tt.qty); got != tt.exceeds {
+				t.Errorf("exceeds = %v, want %v", got, tt.exceeds)
+			}
+		})
+	}
+}
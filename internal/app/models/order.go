@@ -15,21 +15,86 @@ const (
 	OrderStatusReady     OrderStatus = "ready"
 	OrderStatusCompleted OrderStatus = "completed"
 	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusNoShow    OrderStatus = "no_show" // Not picked up before the pickup window ended
+
+	// Holds stock until the customer pays, then becomes pending
+	OrderStatusAwaitingPayment OrderStatus = "awaiting_payment"
 )
 
-// Order represents a food order
+// OrderActor identifies who triggers an order status transition
+type OrderActor string
+
+const (
+	OrderActorCustomer   OrderActor = "customer"
+	OrderActorRestaurant OrderActor = "restaurant"
+	OrderActorAdmin      OrderActor = "admin"
+	OrderActorSystem     OrderActor = "system" // Background jobs
+)
+
+// orderTransitions lists the allowed status transitions and who may trigger each.
+// Completed, cancelled and no-show orders are final.
+var orderTransitions = map[OrderStatus]map[OrderStatus][]OrderActor{
+	OrderStatusAwaitingPayment: {
+		OrderStatusPending:   {OrderActorSystem}, // Payment captured
+		OrderStatusCancelled: {OrderActorCustomer, OrderActorRestaurant, OrderActorAdmin, OrderActorSystem},
+	},
+	OrderStatusPending: {
+		OrderStatusReady:     {OrderActorRestaurant},
+		OrderStatusCancelled: {OrderActorCustomer, OrderActorRestaurant, OrderActorAdmin, OrderActorSystem},
+		OrderStatusNoShow:    {OrderActorSystem}, // Pickup window ended before it was marked ready
+	},
+	OrderStatusReady: {
+		OrderStatusCompleted: {OrderActorRestaurant},
+		OrderStatusCancelled: {OrderActorCustomer, OrderActorRestaurant, OrderActorAdmin},
+		OrderStatusNoShow:    {OrderActorRestaurant, OrderActorSystem},
+	},
+}
+
+// OrderTransition describes a requested status change; it is not stored
+type OrderTransition struct {
+	To      OrderStatus
+	Actor   OrderActor
+	ActorID *uuid.UUID // Nil for system transitions
+	Reason  *string
+}
+
+// Order represents a food order of one or more listings from one restaurant
 type Order struct {
-	ID         uuid.UUID   `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	UserID     uuid.UUID   `gorm:"type:uuid;not null;index" json:"user_id"`
-	ListingID  uuid.UUID   `gorm:"type:uuid;not null;index" json:"listing_id"`
-	Qty        int         `gorm:"not null" json:"qty"`
-	TotalPrice int         `gorm:"not null" json:"total_price"` // Total price in smallest currency unit
-	Status     OrderStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
-	CreatedAt  time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	ID           uuid.UUID   `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID       uuid.UUID   `gorm:"type:uuid;not null;index" json:"user_id"`
+	RestaurantID uuid.UUID   `gorm:"type:uuid;not null;index" json:"restaurant_id"`
+	Qty          int         `gorm:"not null" json:"qty"`               // Total items across all lines
+	TotalPrice   int         `gorm:"not null" json:"total_price"`       // Total price in smallest currency unit
+	Savings      int         `gorm:"not null;default:0" json:"savings"` // Saved against the listings' original values
+	Status       OrderStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	PickupStart  time.Time   `gorm:"not null" json:"pickup_start"` // When every item can be collected
+	PickupEnd    time.Time   `gorm:"not null;index" json:"pickup_end"`
+	CreatedAt    time.Time   `gorm:"autoCreateTime" json:"created_at"`
+
+	// Set when the order is picked up
+	CompletedAt *time.Time `gorm:"index" json:"completed_at,omitempty"`
+
+	// Set when the order is cancelled
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty"`
+	CancelledBy        *uuid.UUID `gorm:"type:uuid" json:"cancelled_by,omitempty"`
+	CancellationReason *string    `gorm:"type:text" json:"cancellation_reason,omitempty"`
+
+	// Returned to the customer by succeeded refunds, in smallest currency unit
+	RefundedAmount int `gorm:"not null;default:0" json:"refunded_amount"`
+
+	// Pickup verification; the code is never serialized so restaurants cannot read it
+	PickupCode        string      `gorm:"type:varchar(12);not null;default:''" json:"-"`
+	PickupAttempts    int         `gorm:"not null;default:0" json:"-"` // Failed attempts since the last lockout
+	PickupLockedUntil *time.Time  `json:"-"`
+	Pickup            *PickupPass `gorm:"-" json:"pickup,omitempty"` // Only set for the customer while the order is open
 
 	// Relationships
-	User    User    `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Listing Listing `gorm:"foreignKey:ListingID" json:"listing,omitempty"`
+	User       User               `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Restaurant Restaurant         `gorm:"foreignKey:RestaurantID" json:"restaurant,omitempty"`
+	Items      []OrderItem        `gorm:"foreignKey:OrderID" json:"items"`
+	Events     []OrderStatusEvent `gorm:"foreignKey:OrderID" json:"events,omitempty"` // Status timeline, oldest first
+	Payment    *Payment           `gorm:"foreignKey:OrderID" json:"payment,omitempty"`
+	Refunds    []Refund           `gorm:"foreignKey:OrderID" json:"refunds,omitempty"` // Oldest first
 }
 
 // BeforeCreate hook to generate UUID and set defaults
@@ -48,23 +113,69 @@ func (Order) TableName() string {
 	return "orders"
 }
 
+// PriceItems charges every item at its listing's price in effect at now, after
+// any markdown, and totals the order. listings must hold each item's listing.
+func (o *Order) PriceItems(listings map[uuid.UUID]*Listing, now time.Time) {
+	o.Qty, o.TotalPrice, o.Savings = 0, 0, 0
+	for i := range o.Items {
+		item := &o.Items[i]
+		listing := listings[item.ListingID]
+		item.UnitPrice = listing.PriceAt(now)
+		item.TotalPrice = item.UnitPrice * item.Qty
+		item.Savings = listing.SavingsPerItem(item.UnitPrice) * item.Qty
+
+		o.Qty += item.Qty
+		o.TotalPrice += item.TotalPrice
+		o.Savings += item.Savings
+	}
+}
+
+// IsPickupOver checks if the pickup window has ended
+func (o *Order) IsPickupOver(now time.Time) bool {
+	return !now.Before(o.PickupEnd)
+}
+
+// LocalizePickup expresses the order's and its listings' pickup windows in loc
+func (o *Order) LocalizePickup(loc *time.Location) {
+	o.PickupStart = o.PickupStart.In(loc)
+	o.PickupEnd = o.PickupEnd.In(loc)
+	for i := range o.Items {
+		o.Items[i].Listing.PickupStart = o.Items[i].Listing.PickupStart.In(loc)
+		o.Items[i].Listing.PickupEnd = o.Items[i].Listing.PickupEnd.In(loc)
+	}
+}
+
+// IsAwaitingPickup checks if the order can still be collected
+func (o *Order) IsAwaitingPickup() bool {
+	return o.Status == OrderStatusPending || o.Status == OrderStatusReady
+}
+
 // CanUpdateStatus checks if the order can transition to the new status
 func (o *Order) CanUpdateStatus(newStatus OrderStatus) bool {
-	// Cancelled orders cannot be updated
-	if o.Status == OrderStatusCancelled {
-		return false
+	_, ok := orderTransitions[o.Status][newStatus]
+	return ok
+}
+
+// CheckTransition checks that actor may move the order to newStatus. It returns
+// ErrInvalidStatusTransition for moves outside the transition table and
+// ErrUnauthorized when the move exists but actor may not trigger it.
+func (o *Order) CheckTransition(newStatus OrderStatus, actor OrderActor) error {
+	actors, ok := orderTransitions[o.Status][newStatus]
+	if !ok {
+		return ErrInvalidStatusTransition
 	}
-	// Completed orders cannot be updated
-	if o.Status == OrderStatusCompleted {
-		return false
+	for _, allowed := range actors {
+		if allowed == actor {
+			return nil
+		}
 	}
-	return true
+	return ErrUnauthorized
 }
 
-// UpdateStatus updates the order status if valid
-func (o *Order) UpdateStatus(newStatus OrderStatus) error {
-	if !o.CanUpdateStatus(newStatus) {
-		return ErrInvalidStatusTransition
+// UpdateStatus updates the order status if actor may trigger the transition
+func (o *Order) UpdateStatus(newStatus OrderStatus, actor OrderActor) error {
+	if err := o.CheckTransition(newStatus, actor); err != nil {
+		return err
 	}
 	o.Status = newStatus
 	return nil
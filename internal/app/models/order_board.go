@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderBoardEntry is a compact view of an order for the restaurant's daily board
+type OrderBoardEntry struct {
+	ID           uuid.UUID        `json:"id"`
+	Status       OrderStatus      `json:"status"`
+	Qty          int              `json:"qty"`
+	CustomerName string           `json:"customer_name"`
+	Items        []OrderBoardItem `json:"items"`
+	PickupStart  time.Time        `json:"pickup_start"`
+	PickupEnd    time.Time        `json:"pickup_end"`
+}
+
+// OrderBoardItem is one line of a board entry
+type OrderBoardItem struct {
+	ListingID   uuid.UUID   `json:"listing_id"`
+	ListingType ListingType `json:"listing_type"`
+	ListingName *string     `json:"listing_name"`
+	Qty         int         `json:"qty"`
+}
+
+// OrderBoardColumn holds the board entries in one status
+type OrderBoardColumn struct {
+	Count  int               `json:"count"`
+	Orders []OrderBoardEntry `json:"orders"`
+}
+
+// OrderBoard groups a restaurant's orders for one pickup date by status
+type OrderBoard struct {
+	Date      DateOnly         `json:"date"`
+	Pending   OrderBoardColumn `json:"pending"`
+	Ready     OrderBoardColumn `json:"ready"`
+	Completed OrderBoardColumn `json:"completed"`
+}
+
+// NewOrderBoard groups orders, already sorted by pickup time, into board columns.
+// Orders in other statuses are left out.
+func NewOrderBoard(date DateOnly, orders []Order) *OrderBoard {
+	board := &OrderBoard{
+		Date:      date,
+		Pending:   OrderBoardColumn{Orders: []OrderBoardEntry{}},
+		Ready:     OrderBoardColumn{Orders: []OrderBoardEntry{}},
+		Completed: OrderBoardColumn{Orders: []OrderBoardEntry{}},
+	}
+
+	for _, order := range orders {
+		var column *OrderBoardColumn
+		switch order.Status {
+		case OrderStatusPending:
+			column = &board.Pending
+		case OrderStatusReady:
+			column = &board.Ready
+		case OrderStatusCompleted:
+			column = &board.Completed
+		default:
+			continue
+		}
+
+		items := make([]OrderBoardItem, 0, len(order.Items))
+		for _, item := range order.Items {
+			items = append(items, OrderBoardItem{
+				ListingID:   item.ListingID,
+				ListingType: item.Listing.Type,
+				ListingName: item.Listing.Name,
+				Qty:         item.Qty,
+			})
+		}
+
+		column.Count++
+		column.Orders = append(column.Orders, OrderBoardEntry{
+			ID:           order.ID,
+			Status:       order.Status,
+			Qty:          order.Qty,
+			CustomerName: order.User.Name,
+			Items:        items,
+			PickupStart:  order.PickupStart,
+			PickupEnd:    order.PickupEnd,
+		})
+	}
+	return board
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrderItem is one listing and quantity within an order
+type OrderItem struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID    uuid.UUID `gorm:"type:uuid;not null;index" json:"order_id"`
+	ListingID  uuid.UUID `gorm:"type:uuid;not null;index" json:"listing_id"`
+	Qty        int       `gorm:"not null" json:"qty"`
+	UnitPrice  int       `gorm:"not null" json:"unit_price"`        // Price per item actually charged, after markdown
+	TotalPrice int       `gorm:"not null" json:"total_price"`       // unit_price * qty
+	Savings    int       `gorm:"not null;default:0" json:"savings"` // Saved against the listing's original value
+
+	// Relationships
+	Listing Listing `gorm:"foreignKey:ListingID" json:"listing,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID before creating
+func (i *OrderItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for OrderItem model
+func (OrderItem) TableName() string {
+	return "order_items"
+}
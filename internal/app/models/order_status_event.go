@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrderStatusEvent records one order status transition
+type OrderStatusEvent struct {
+	ID         uuid.UUID    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID    uuid.UUID    `gorm:"type:uuid;not null;index" json:"order_id"`
+	FromStatus *OrderStatus `gorm:"type:varchar(20)" json:"from_status"` // Nil when the order was placed
+	ToStatus   OrderStatus  `gorm:"type:varchar(20);not null" json:"to_status"`
+	Actor      OrderActor   `gorm:"type:varchar(20);not null" json:"actor"`
+	ActorID    *uuid.UUID   `gorm:"type:uuid" json:"actor_id,omitempty"` // Nil for system transitions
+	Reason     *string      `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt  time.Time    `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID before creating
+func (e *OrderStatusEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for OrderStatusEvent model
+func (OrderStatusEvent) TableName() string {
+	return "order_status_events"
+}
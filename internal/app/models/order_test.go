@@ -0,0 +1,121 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+var (
+	allOrderStatuses = []OrderStatus{
+		OrderStatusAwaitingPayment, OrderStatusPending, OrderStatusReady,
+		OrderStatusCompleted, OrderStatusCancelled, OrderStatusNoShow,
+	}
+	allOrderActors = []OrderActor{OrderActorCustomer, OrderActorRestaurant, OrderActorAdmin, OrderActorSystem}
+)
+
+// orderMove is one status change by one actor
+type orderMove struct {
+	from  OrderStatus
+	to    OrderStatus
+	actor OrderActor
+}
+
+// allowedOrderMoves spells out the transition table move by move
+var allowedOrderMoves = []orderMove{
+	{OrderStatusAwaitingPayment, OrderStatusPending, OrderActorSystem},
+	{OrderStatusAwaitingPayment, OrderStatusCancelled, OrderActorCustomer},
+	{OrderStatusAwaitingPayment, OrderStatusCancelled, OrderActorRestaurant},
+	{OrderStatusAwaitingPayment, OrderStatusCancelled, OrderActorAdmin},
+	{OrderStatusAwaitingPayment, OrderStatusCancelled, OrderActorSystem},
+	{OrderStatusPending, OrderStatusReady, OrderActorRestaurant},
+	{OrderStatusPending, OrderStatusCancelled, OrderActorCustomer},
+	{OrderStatusPending, OrderStatusCancelled, OrderActorRestaurant},
+	{OrderStatusPending, OrderStatusCancelled, OrderActorAdmin},
+	{OrderStatusPending, OrderStatusCancelled, OrderActorSystem},
+	{OrderStatusPending, OrderStatusNoShow, OrderActorSystem},
+	{OrderStatusReady, OrderStatusCompleted, OrderActorRestaurant},
+	{OrderStatusReady, OrderStatusCancelled, OrderActorCustomer},
+	{OrderStatusReady, OrderStatusCancelled, OrderActorRestaurant},
+	{OrderStatusReady, OrderStatusCancelled, OrderActorAdmin},
+	{OrderStatusReady, OrderStatusNoShow, OrderActorRestaurant},
+	{OrderStatusReady, OrderStatusNoShow, OrderActorSystem},
+}
+
+func TestCheckTransitionAllowed(t *testing.T) {
+	for _, move := range allowedOrderMoves {
+		order := &Order{Status: move.from}
+		if err := order.CheckTransition(move.to, move.actor); err != nil {
+			t.Errorf("%s -> %s by %s: err = %v, want nil", move.from, move.to, move.actor, err)
+		}
+	}
+}
+
+func TestCheckTransitionForbidden(t *testing.T) {
+	tests := []struct {
+		name string
+		move orderMove
+		want error
+	}{
+		{"payment captured by customer", orderMove{OrderStatusAwaitingPayment, OrderStatusPending, OrderActorCustomer}, ErrUnauthorized},
+		{"payment captured by restaurant", orderMove{OrderStatusAwaitingPayment, OrderStatusPending, OrderActorRestaurant}, ErrUnauthorized},
+		{"payment captured by admin", orderMove{OrderStatusAwaitingPayment, OrderStatusPending, OrderActorAdmin}, ErrUnauthorized},
+		{"unpaid order prepared", orderMove{OrderStatusAwaitingPayment, OrderStatusReady, OrderActorRestaurant}, ErrInvalidStatusTransition},
+		{"customer marks ready", orderMove{OrderStatusPending, OrderStatusReady, OrderActorCustomer}, ErrUnauthorized},
+		{"pending completed", orderMove{OrderStatusPending, OrderStatusCompleted, OrderActorRestaurant}, ErrInvalidStatusTransition},
+		{"restaurant marks pending no-show", orderMove{OrderStatusPending, OrderStatusNoShow, OrderActorRestaurant}, ErrUnauthorized},
+		{"ready back to pending", orderMove{OrderStatusReady, OrderStatusPending, OrderActorRestaurant}, ErrInvalidStatusTransition},
+		{"customer completes", orderMove{OrderStatusReady, OrderStatusCompleted, OrderActorCustomer}, ErrUnauthorized},
+		{"system cancels ready", orderMove{OrderStatusReady, OrderStatusCancelled, OrderActorSystem}, ErrUnauthorized},
+		{"customer no-show", orderMove{OrderStatusReady, OrderStatusNoShow, OrderActorCustomer}, ErrUnauthorized},
+		{"completed cancelled", orderMove{OrderStatusCompleted, OrderStatusCancelled, OrderActorAdmin}, ErrInvalidStatusTransition},
+		{"no-show cancelled", orderMove{OrderStatusNoShow, OrderStatusCancelled, OrderActorRestaurant}, ErrInvalidStatusTransition},
+		{"cancelled reopened", orderMove{OrderStatusCancelled, OrderStatusPending, OrderActorAdmin}, ErrInvalidStatusTransition},
+		{"no-show completed", orderMove{OrderStatusNoShow, OrderStatusCompleted, OrderActorRestaurant}, ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &Order{Status: tt.move.from}
+			if err := order.CheckTransition(tt.move.to, tt.move.actor); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTransitionOnlyAllowsTable(t *testing.T) {
+	allowed := make(map[orderMove]bool, len(allowedOrderMoves))
+	for _, move := range allowedOrderMoves {
+		allowed[move] = true
+	}
+
+	for _, from := range allOrderStatuses {
+		for _, to := range allOrderStatuses {
+			for _, actor := range allOrderActors {
+				move := orderMove{from, to, actor}
+				order := &Order{Status: from}
+				if err := order.CheckTransition(to, actor); (err == nil) != allowed[move] {
+					t.Errorf("%s -> %s by %s: err = %v, allowed = %v", from, to, actor, err, allowed[move])
+				}
+			}
+		}
+	}
+}
+
+func TestUpdateStatusLeavesStatusOnError(t *testing.T) {
+	order := &Order{Status: OrderStatusCompleted}
+	if err := order.UpdateStatus(OrderStatusCancelled, OrderActorAdmin); err == nil {
+		t.Fatal("completed order was cancelled")
+	}
+	if order.Status != OrderStatusCompleted {
+		t.Errorf("status = %s, want completed", order.Status)
+	}
+
+	order = &Order{Status: OrderStatusPending}
+	if err := order.UpdateStatus(OrderStatusReady, OrderActorRestaurant); err != nil {
+		t.Fatalf("mark ready: %v", err)
+	}
+	if order.Status != OrderStatusReady {
+		t.Errorf("status = %s, want ready", order.Status)
+	}
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PartnerApplicationStatus represents the review status of a partner application
+type PartnerApplicationStatus string
+
+const (
+	ApplicationStatusPending   PartnerApplicationStatus = "pending"
+	ApplicationStatusApproved  PartnerApplicationStatus = "approved"
+	ApplicationStatusRejected  PartnerApplicationStatus = "rejected"
+	ApplicationStatusWithdrawn PartnerApplicationStatus = "withdrawn"
+)
+
+// applicationTransitions lists the statuses each status may move to.
+// Approved, rejected and withdrawn are final.
+var applicationTransitions = map[PartnerApplicationStatus][]PartnerApplicationStatus{
+	ApplicationStatusPending: {
+		ApplicationStatusApproved,
+		ApplicationStatusRejected,
+		ApplicationStatusWithdrawn,
+	},
+}
+
+// ApplicationDocument describes a supporting document uploaded elsewhere (e.g. Supabase Storage)
+type ApplicationDocument struct {
+	Type string `json:"type"` // e.g. "business_license", "id_card", "storefront_photo"
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// ApplicationDocuments is a list of documents stored as JSONB
+type ApplicationDocuments []ApplicationDocument
+
+// Scan implements the Scanner interface for database reading
+func (d *ApplicationDocuments) Scan(value interface{}) error {
+	return scanJSON(value, d)
+}
+
+// Value implements the Valuer interface for database writing
+func (d ApplicationDocuments) Value() (driver.Value, error) {
+	if d == nil {
+		return jsonValue([]ApplicationDocument{})
+	}
+	return jsonValue([]ApplicationDocument(d))
+}
+
+// PartnerApplication represents a user's request to become a restaurant partner
+type PartnerApplication struct {
+	ID           uuid.UUID                `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID       uuid.UUID                `gorm:"type:uuid;not null;index" json:"user_id"`
+	BusinessName string                   `gorm:"type:varchar(255);not null" json:"business_name"`
+	Address      string                   `gorm:"type:text;not null" json:"address"`
+	Phone        string                   `gorm:"type:varchar(50)" json:"phone"`
+	Documents    ApplicationDocuments     `gorm:"type:jsonb;not null;default:'[]'" json:"documents"`
+	Status       PartnerApplicationStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	ReviewNote   *string                  `gorm:"type:text" json:"review_note,omitempty"`
+	ReviewedBy   *uuid.UUID               `gorm:"type:uuid" json:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time               `json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time                `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time                `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID and set defaults
+func (a *PartnerApplication) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	if a.Status == "" {
+		a.Status = ApplicationStatusPending
+	}
+	return nil
+}
+
+// TableName specifies the table name for PartnerApplication model
+func (PartnerApplication) TableName() string {
+	return "partner_applications"
+}
+
+// CanTransitionTo checks if the application can move to the new status
+func (a *PartnerApplication) CanTransitionTo(newStatus PartnerApplicationStatus) bool {
+	for _, allowed := range applicationTransitions[a.Status] {
+		if allowed == newStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the application to the new status if allowed
+func (a *PartnerApplication) TransitionTo(newStatus PartnerApplicationStatus) error {
+	if !a.CanTransitionTo(newStatus) {
+		return ErrInvalidStatusTransition
+	}
+	a.Status = newStatus
+	return nil
+}
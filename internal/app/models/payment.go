@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PaymentStatus represents the status of an order's payment
+type PaymentStatus string
+
+const (
+	PaymentStatusRequiresPayment   PaymentStatus = "requires_payment" // Waiting for the customer
+	PaymentStatusFailed            PaymentStatus = "failed"           // Last attempt failed; the customer may retry until the order expires
+	PaymentStatusCaptured          PaymentStatus = "captured"
+	PaymentStatusCancelled         PaymentStatus = "cancelled" // Order cancelled or expired before it was paid
+	PaymentStatusPartiallyRefunded PaymentStatus = "partially_refunded"
+	PaymentStatusRefunded          PaymentStatus = "refunded" // Returned in full
+)
+
+// Payment is the payment of an order through the payment provider
+type Payment struct {
+	ID               uuid.UUID     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID          uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex" json:"order_id"`
+	Provider         string        `gorm:"type:varchar(30);not null" json:"provider"`
+	ProviderIntentID string        `gorm:"type:varchar(255);not null;uniqueIndex" json:"provider_intent_id"`
+	Amount           int           `gorm:"not null" json:"amount"` // Smallest currency unit
+	Currency         string        `gorm:"type:varchar(3);not null" json:"currency"`
+	Status           PaymentStatus `gorm:"type:varchar(20);not null;default:'requires_payment'" json:"status"`
+	FailureReason    *string       `gorm:"type:text" json:"failure_reason,omitempty"`
+	CapturedAt       *time.Time    `json:"captured_at,omitempty"`
+	RefundedAmount   int           `gorm:"not null;default:0" json:"refunded_amount"` // Returned by succeeded refunds
+	CreatedAt        time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Lets the customer's client authorize the payment; only shown to the customer
+	ClientSecret string `gorm:"type:varchar(255);not null;default:''" json:"client_secret,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID and set defaults
+func (p *Payment) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	if p.Status == "" {
+		p.Status = PaymentStatusRequiresPayment
+	}
+	return nil
+}
+
+// TableName specifies the table name for Payment model
+func (Payment) TableName() string {
+	return "payments"
+}
+
+// IsOpen checks if the payment can still be completed
+func (p *Payment) IsOpen() bool {
+	return p.Status == PaymentStatusRequiresPayment || p.Status == PaymentStatusFailed
+}
+
+// IsCaptured checks if the payment was captured and not yet returned in full
+func (p *Payment) IsCaptured() bool {
+	return p.Status == PaymentStatusCaptured || p.Status == PaymentStatusPartiallyRefunded
+}
@@ -0,0 +1,7 @@
+package models
+
+// PickupPass is what the customer shows at the counter to collect an order
+type PickupPass struct {
+	Code      string `json:"code"`       // Typed by restaurant staff
+	QRPayload string `json:"qr_payload"` // Signed payload to render as a QR code
+}
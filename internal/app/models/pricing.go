@@ -0,0 +1,105 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MarkdownStep discounts a listing once the pickup window is about to end
+type MarkdownStep struct {
+	MinutesBeforeEnd int `json:"minutes_before_end"` // Applies from this many minutes before pickup_end
+	PercentOff       int `json:"percent_off"`        // 1-100
+}
+
+// PriceSchedule lowers a listing's price as the end of its pickup window approaches.
+// The deepest step in effect applies; the price never drops below FloorPrice.
+type PriceSchedule struct {
+	Steps      []MarkdownStep `json:"steps"`
+	FloorPrice int            `json:"floor_price"`
+}
+
+// Validate checks the steps and floor against the listing's original price,
+// and orders the steps from earliest to latest
+func (s *PriceSchedule) Validate(price int) error {
+	if len(s.Steps) == 0 || s.FloorPrice < 0 || s.FloorPrice > price {
+		return ErrInvalidPriceSchedule
+	}
+	for _, step := range s.Steps {
+		if step.MinutesBeforeEnd <= 0 || step.PercentOff <= 0 || step.PercentOff > 100 {
+			return ErrInvalidPriceSchedule
+		}
+	}
+	sort.Slice(s.Steps, func(i, j int) bool {
+		return s.Steps[i].MinutesBeforeEnd > s.Steps[j].MinutesBeforeEnd
+	})
+	return nil
+}
+
+// PriceAt returns the price of an item originally costing price at time t,
+// for a pickup window ending at end
+func (s *PriceSchedule) PriceAt(price int, end, t time.Time) int {
+	percentOff := 0
+	for _, step := range s.Steps {
+		if !t.Before(stepStart(end, step)) && step.PercentOff > percentOff {
+			percentOff = step.PercentOff
+		}
+	}
+	if percentOff == 0 {
+		return price
+	}
+
+	discounted := price * (100 - percentOff) / 100
+	if discounted < s.FloorPrice {
+		return s.FloorPrice
+	}
+	return discounted
+}
+
+// NextDropAfter returns when the price next decreases after t, or nil if it will not
+func (s *PriceSchedule) NextDropAfter(price int, end, t time.Time) *time.Time {
+	current := s.PriceAt(price, end, t)
+	var next *time.Time
+	for _, step := range s.Steps {
+		start := stepStart(end, step)
+		if !start.After(t) || !start.Before(end) {
+			continue
+		}
+		if s.PriceAt(price, end, start) < current && (next == nil || start.Before(*next)) {
+			next = &start
+		}
+	}
+	return next
+}
+
+// stepStart returns when a step takes effect
+func stepStart(end time.Time, step MarkdownStep) time.Time {
+	return end.Add(-time.Duration(step.MinutesBeforeEnd) * time.Minute)
+}
+
+// Scan implements the Scanner interface for database reading
+func (s *PriceSchedule) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("cannot scan type %T into PriceSchedule", value)
+	}
+}
+
+// Value implements the Valuer interface for database writing
+func (s PriceSchedule) Value() (driver.Value, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
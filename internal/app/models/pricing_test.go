@@ -0,0 +1,134 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testPickupEnd = time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+
+// minutesBeforeEnd returns the time m minutes before testPickupEnd
+func minutesBeforeEnd(m int) time.Time {
+	return testPickupEnd.Add(-time.Duration(m) * time.Minute)
+}
+
+func TestPriceScheduleValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule PriceSchedule
+		valid    bool
+	}{
+		{name: "valid", schedule: PriceSchedule{Steps: []MarkdownStep{{60, 25}, {30, 50}}, FloorPrice: 8000}, valid: true},
+		{name: "floor equal to price", schedule: PriceSchedule{Steps: []MarkdownStep{{60, 25}}, FloorPrice: 20000}, valid: true},
+		{name: "free at the end", schedule: PriceSchedule{Steps: []MarkdownStep{{10, 100}}}, valid: true},
+		{name: "no steps", schedule: PriceSchedule{FloorPrice: 8000}},
+		{name: "floor above price", schedule: PriceSchedule{Steps: []MarkdownStep{{60, 25}}, FloorPrice: 20001}},
+		{name: "negative floor", schedule: PriceSchedule{Steps: []MarkdownStep{{60, 25}}, FloorPrice: -1}},
+		{name: "step at pickup end", schedule: PriceSchedule{Steps: []MarkdownStep{{0, 25}}}},
+		{name: "step after pickup end", schedule: PriceSchedule{Steps: []MarkdownStep{{-30, 25}}}},
+		{name: "no discount", schedule: PriceSchedule{Steps: []MarkdownStep{{60, 0}}}},
+		{name: "more than free", schedule: PriceSchedule{Steps: []MarkdownStep{{60, 101}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.Validate(20000)
+			if tt.valid && err != nil {
+				t.Errorf("err = %v, want nil", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidPriceSchedule) {
+				t.Errorf("err = %v, want ErrInvalidPriceSchedule", err)
+			}
+		})
+	}
+}
+
+func TestPriceScheduleValidateSortsSteps(t *testing.T) {
+	schedule := PriceSchedule{Steps: []MarkdownStep{{15, 75}, {60, 25}, {30, 50}}}
+	if err := schedule.Validate(20000); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	for i, want := range []int{60, 30, 15} {
+		if got := schedule.Steps[i].MinutesBeforeEnd; got != want {
+			t.Errorf("step %d starts %d minutes before the end, want %d", i, got, want)
+		}
+	}
+}
+
+func TestPriceSchedulePriceAt(t *testing.T) {
+	schedule := PriceSchedule{Steps: []MarkdownStep{{60, 25}, {30, 50}, {10, 90}}, FloorPrice: 8000}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want int
+	}{
+		{name: "before the first step", at: minutesBeforeEnd(61), want: 20000},
+		{name: "a nanosecond before the first step", at: minutesBeforeEnd(60).Add(-time.Nanosecond), want: 20000},
+		{name: "exactly at the first step", at: minutesBeforeEnd(60), want: 15000},
+		{name: "between steps", at: minutesBeforeEnd(45), want: 15000},
+		{name: "exactly at the second step", at: minutesBeforeEnd(30), want: 10000},
+		{name: "floored at the last step", at: minutesBeforeEnd(10), want: 8000},
+		{name: "at pickup end", at: testPickupEnd, want: 8000},
+		{name: "after pickup end", at: testPickupEnd.Add(time.Hour), want: 8000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := schedule.PriceAt(20000, testPickupEnd, tt.at); got != tt.want {
+				t.Errorf("price = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriceScheduleNeverAboveBasePrice(t *testing.T) {
+	// A floor equal to the price leaves the price unchanged rather than raising it
+	schedule := PriceSchedule{Steps: []MarkdownStep{{60, 25}}, FloorPrice: 20000}
+	if err := schedule.Validate(20000); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	for _, at := range []time.Time{minutesBeforeEnd(90), minutesBeforeEnd(60), testPickupEnd} {
+		if got := schedule.PriceAt(20000, testPickupEnd, at); got > 20000 {
+			t.Errorf("price at %s = %d, want at most 20000", at.Format(time.Kitchen), got)
+		}
+	}
+	if next := schedule.NextDropAfter(20000, testPickupEnd, minutesBeforeEnd(90)); next != nil {
+		t.Errorf("next drop = %s, want none", next)
+	}
+}
+
+func TestPriceScheduleNextDropAfter(t *testing.T) {
+	schedule := PriceSchedule{Steps: []MarkdownStep{{60, 25}, {30, 50}, {10, 90}}, FloorPrice: 10000}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want *time.Time
+	}{
+		{name: "before the first step", at: minutesBeforeEnd(90), want: ptrTime(minutesBeforeEnd(60))},
+		{name: "exactly at the first step", at: minutesBeforeEnd(60), want: ptrTime(minutesBeforeEnd(30))},
+		// The last step is floored to the second step's price, so it is no drop
+		{name: "exactly at the second step", at: minutesBeforeEnd(30), want: nil},
+		{name: "at pickup end", at: testPickupEnd, want: nil},
+		{name: "after pickup end", at: testPickupEnd.Add(time.Hour), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := schedule.NextDropAfter(20000, testPickupEnd, tt.at)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("next drop = %s, want none", got)
+			case tt.want != nil && (got == nil || !got.Equal(*tt.want)):
+				t.Errorf("next drop = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// ptrTime returns a pointer to t
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RefundStatus represents the status of a refund
+type RefundStatus string
+
+const (
+	RefundStatusRequested RefundStatus = "requested" // Asked for by the customer; waiting for the restaurant or an admin
+	RefundStatusApproved  RefundStatus = "approved"  // Queued for the payment provider, or pending there once provider_refund_id is set
+	RefundStatusRejected  RefundStatus = "rejected"
+	RefundStatusSucceeded RefundStatus = "succeeded"
+	RefundStatusFailed    RefundStatus = "failed" // Last attempt through the provider failed; retried
+)
+
+// Refund returns all or part of an order's captured payment
+type Refund struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID     uuid.UUID    `gorm:"type:uuid;not null;index" json:"order_id"`
+	PaymentID   uuid.UUID    `gorm:"type:uuid;not null;index" json:"payment_id"`
+	Amount      int          `gorm:"not null" json:"amount"` // Smallest currency unit
+	Reason      string       `gorm:"type:text;not null" json:"reason"`
+	Status      RefundStatus `gorm:"type:varchar(20);not null;default:'requested'" json:"status"`
+	RequestedBy OrderActor   `gorm:"type:varchar(20);not null" json:"requested_by"`
+	RequesterID *uuid.UUID   `gorm:"type:uuid" json:"requester_id,omitempty"` // Nil for system refunds
+	CreatedAt   time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Set when the refund is approved or rejected
+	DecidedBy    *uuid.UUID `gorm:"type:uuid" json:"decided_by,omitempty"`
+	DecidedAt    *time.Time `json:"decided_at,omitempty"`
+	DecisionNote *string    `gorm:"type:text" json:"decision_note,omitempty"`
+
+	// Execution through the payment provider
+	ProviderRefundID *string    `gorm:"type:varchar(255)" json:"provider_refund_id,omitempty"`
+	Attempts         int        `gorm:"not null;default:0" json:"attempts"`
+	FailureReason    *string    `gorm:"type:text" json:"failure_reason,omitempty"` // Generic; provider errors are only logged
+	RefundedAt       *time.Time `json:"refunded_at,omitempty"`
+
+	// Relationships
+	Payment *Payment `gorm:"foreignKey:PaymentID" json:"-"`
+}
+
+// BeforeCreate hook to generate UUID and set defaults
+func (r *Refund) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	if r.Status == "" {
+		r.Status = RefundStatusRequested
+	}
+	return nil
+}
+
+// TableName specifies the table name for Refund model
+func (Refund) TableName() string {
+	return "refunds"
+}
+
+// IsPendingAtProvider checks if the provider accepted the refund but has not settled it yet
+func (r *Refund) IsPendingAtProvider() bool {
+	return r.Status == RefundStatusApproved && r.ProviderRefundID != nil
+}
+
+// IsExecutable checks if the refund is waiting to be sent to the payment provider
+func (r *Refund) IsExecutable() bool {
+	return r.Status == RefundStatusApproved || r.Status == RefundStatusFailed
+}
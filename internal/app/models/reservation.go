@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReservationStatus represents the status of a stock reservation
+type ReservationStatus string
+
+const (
+	ReservationStatusActive    ReservationStatus = "active"
+	ReservationStatusConverted ReservationStatus = "converted" // Used by an order
+	ReservationStatusReleased  ReservationStatus = "released"  // Given up by the customer
+	ReservationStatusExpired   ReservationStatus = "expired"
+)
+
+// StockReservation holds units of a listing for a customer while they check
+// out. Held units are taken out of the listing's stock when reserved and return
+// to it when the reservation is released or expires. A customer has at most one
+// active reservation per listing.
+type StockReservation struct {
+	ID        uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID         `gorm:"type:uuid;not null;index" json:"user_id"`
+	ListingID uuid.UUID         `gorm:"type:uuid;not null;index" json:"listing_id"`
+	Qty       int               `gorm:"not null" json:"qty"`
+	Status    ReservationStatus `gorm:"type:varchar(20);not null;default:'active'" json:"status"`
+	ExpiresAt time.Time         `gorm:"not null;index" json:"expires_at"`
+	CreatedAt time.Time         `gorm:"autoCreateTime" json:"created_at"`
+
+	// Set when the reservation is used by an order
+	OrderID *uuid.UUID `gorm:"type:uuid" json:"order_id,omitempty"`
+
+	// Relationships
+	Listing Listing `gorm:"foreignKey:ListingID" json:"listing,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID and set defaults
+func (r *StockReservation) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	if r.Status == "" {
+		r.Status = ReservationStatusActive
+	}
+	return nil
+}
+
+// TableName specifies the table name for StockReservation model
+func (StockReservation) TableName() string {
+	return "stock_reservations"
+}
+
+// IsExpired checks if the reservation's hold has run out
+func (r *StockReservation) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
@@ -18,11 +18,71 @@ type Restaurant struct {
 	Lat         float64   `gorm:"type:decimal(10,8);not null" json:"lat"`
 	Lng         float64   `gorm:"type:decimal(11,8);not null" json:"lng"`
 	ClosingTime TimeOnly  `gorm:"type:time;not null" json:"closing_time"`
+	Timezone    string    `gorm:"type:varchar(64);not null;default:'Asia/Jakarta'" json:"timezone"` // IANA name
 	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
 
+	// Most items one customer may order for pickup on one local day; nil for no limit
+	DailyLimitPerCustomer *int `json:"daily_limit_per_customer,omitempty"`
+
+	// Soft delete; deleted restaurants are excluded from all queries
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Moderation
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty"`
+	SuspensionReason *string    `gorm:"type:text" json:"suspension_reason,omitempty"`
+
+	// Computed from the schedule, not stored
+	IsOpenNow  *bool      `gorm:"-" json:"is_open_now,omitempty"`
+	NextOpenAt *time.Time `gorm:"-" json:"next_open_at,omitempty"`
+
+	// Computed from completed orders for the detail endpoint, not stored
+	Impact *Impact `gorm:"-" json:"impact,omitempty"`
+
 	// Relationships
-	Owner    User      `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
-	Listings []Listing `gorm:"foreignKey:RestaurantID" json:"listings,omitempty"`
+	Owner        User                `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
+	Listings     []Listing           `gorm:"foreignKey:RestaurantID" json:"listings,omitempty"`
+	OpeningHours []OpeningHours      `gorm:"foreignKey:RestaurantID" json:"opening_hours,omitempty"`
+	Closures     []RestaurantClosure `gorm:"foreignKey:RestaurantID" json:"closures,omitempty"`
+}
+
+// RestaurantUpdate holds a partial restaurant update; nil fields are left unchanged
+type RestaurantUpdate struct {
+	Name        *string
+	Address     *string
+	Lat         *float64
+	Lng         *float64
+	ClosingTime *TimeOnly
+	Timezone    *string
+
+	DailyLimitPerCustomer *int // Zero removes the limit
+}
+
+// Apply copies the set fields onto the restaurant
+func (u RestaurantUpdate) Apply(r *Restaurant) {
+	if u.Name != nil {
+		r.Name = *u.Name
+	}
+	if u.Address != nil {
+		r.Address = *u.Address
+	}
+	if u.Lat != nil {
+		r.Lat = *u.Lat
+	}
+	if u.Lng != nil {
+		r.Lng = *u.Lng
+	}
+	if u.ClosingTime != nil {
+		r.ClosingTime = *u.ClosingTime
+	}
+	if u.Timezone != nil {
+		r.Timezone = *u.Timezone
+	}
+	if u.DailyLimitPerCustomer != nil {
+		r.DailyLimitPerCustomer = u.DailyLimitPerCustomer
+		if *u.DailyLimitPerCustomer == 0 {
+			r.DailyLimitPerCustomer = nil
+		}
+	}
 }
 
 // TimeOnly is a custom type for time without date
@@ -96,3 +156,8 @@ func (r *Restaurant) BeforeCreate(tx *gorm.DB) error {
 func (Restaurant) TableName() string {
 	return "restaurants"
 }
+
+// IsSuspended checks if the restaurant has been suspended by an admin
+func (r *Restaurant) IsSuspended() bool {
+	return r.SuspendedAt != nil
+}
@@ -0,0 +1,160 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MemberRole represents a user's role within a single restaurant
+type MemberRole string
+
+const (
+	MemberRoleOwner   MemberRole = "owner"
+	MemberRoleManager MemberRole = "manager"
+	MemberRoleStaff   MemberRole = "staff"
+)
+
+// Permission represents an action on a restaurant's resources
+type Permission string
+
+const (
+	PermManageRestaurant Permission = "restaurant:manage"
+	PermDeleteRestaurant Permission = "restaurant:delete"
+	PermViewMembers      Permission = "members:view"
+	PermManageMembers    Permission = "members:manage"
+	PermManageListings   Permission = "listings:manage"
+	PermUpdateStock      Permission = "listings:stock"
+	PermViewOrders       Permission = "orders:view"
+	PermManageOrders     Permission = "orders:manage"
+)
+
+// rolePermissions lists the permissions granted to each member role
+var rolePermissions = map[MemberRole][]Permission{
+	MemberRoleOwner: {
+		PermManageRestaurant, PermDeleteRestaurant,
+		PermViewMembers, PermManageMembers,
+		PermManageListings, PermUpdateStock,
+		PermViewOrders, PermManageOrders,
+	},
+	MemberRoleManager: {
+		PermManageRestaurant,
+		PermViewMembers, PermManageMembers,
+		PermManageListings, PermUpdateStock,
+		PermViewOrders, PermManageOrders,
+	},
+	MemberRoleStaff: {
+		PermViewMembers,
+		PermUpdateStock,
+		PermViewOrders, PermManageOrders,
+	},
+}
+
+// roleRank orders member roles by seniority
+var roleRank = map[MemberRole]int{
+	MemberRoleOwner:   3,
+	MemberRoleManager: 2,
+	MemberRoleStaff:   1,
+}
+
+// IsValid checks if the role is a known member role
+func (r MemberRole) IsValid() bool {
+	_, ok := roleRank[r]
+	return ok
+}
+
+// Can checks if the role grants the given permission
+func (r MemberRole) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Outranks checks if the role is strictly more senior than the other role
+func (r MemberRole) Outranks(other MemberRole) bool {
+	return roleRank[r] > roleRank[other]
+}
+
+// RestaurantMember links a user to a restaurant with a role
+type RestaurantMember struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_restaurant_members_unique" json:"restaurant_id"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_restaurant_members_unique;index" json:"user_id"`
+	Role         MemberRole `gorm:"type:varchar(20);not null" json:"role"`
+	InvitedBy    *uuid.UUID `gorm:"type:uuid" json:"invited_by,omitempty"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID before creating
+func (m *RestaurantMember) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for RestaurantMember model
+func (RestaurantMember) TableName() string {
+	return "restaurant_members"
+}
+
+// InvitationStatus represents the status of a restaurant invitation
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusDeclined InvitationStatus = "declined"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+)
+
+// RestaurantInvitation invites a user, identified by email, to join a restaurant
+type RestaurantInvitation struct {
+	ID           uuid.UUID        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID        `gorm:"type:uuid;not null;index" json:"restaurant_id"`
+	Email        string           `gorm:"type:varchar(255);not null;index" json:"email"`
+	Role         MemberRole       `gorm:"type:varchar(20);not null" json:"role"`
+	Status       InvitationStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	InvitedBy    uuid.UUID        `gorm:"type:uuid;not null" json:"invited_by"`
+	ExpiresAt    time.Time        `gorm:"not null" json:"expires_at"`
+	RespondedAt  *time.Time       `json:"responded_at,omitempty"`
+	CreatedAt    time.Time        `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	Restaurant Restaurant `gorm:"foreignKey:RestaurantID" json:"restaurant,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID, normalize email and set defaults
+func (i *RestaurantInvitation) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	if i.Status == "" {
+		i.Status = InvitationStatusPending
+	}
+	i.Email = strings.ToLower(strings.TrimSpace(i.Email))
+	return nil
+}
+
+// TableName specifies the table name for RestaurantInvitation model
+func (RestaurantInvitation) TableName() string {
+	return "restaurant_invitations"
+}
+
+// IsExpired checks if the invitation can no longer be accepted
+func (i *RestaurantInvitation) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
+
+// IsFor checks if the invitation was addressed to the given email
+func (i *RestaurantInvitation) IsFor(email string) bool {
+	return strings.EqualFold(i.Email, strings.TrimSpace(email))
+}
@@ -0,0 +1,9 @@
+package models
+
+// SavingsSummary aggregates completed orders and what customers saved on them
+type SavingsSummary struct {
+	OrdersCompleted int64 `json:"orders_completed"`
+	ItemsRescued    int64 `json:"items_rescued"`
+	TotalPaid       int64 `json:"total_paid"`    // Smallest currency unit
+	TotalSavings    int64 `json:"total_savings"` // Smallest currency unit
+}
@@ -0,0 +1,288 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultTimezone is used for restaurants that have not set their own timezone
+const DefaultTimezone = "Asia/Jakarta"
+
+// scheduleHorizonDays limits how far ahead NextOpeningAfter searches
+const scheduleHorizonDays = 90
+
+// OpeningHours is one open interval on a weekday in the restaurant's timezone.
+// An interval whose ClosesAt is not after OpensAt runs past midnight into the next day.
+type OpeningHours struct {
+	ID           uuid.UUID    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID    `gorm:"type:uuid;not null;index" json:"restaurant_id"`
+	Weekday      time.Weekday `gorm:"not null" json:"weekday" swaggertype:"integer"` // 0 = Sunday ... 6 = Saturday
+	OpensAt      TimeOnly     `gorm:"type:time;not null" json:"opens_at"`
+	ClosesAt     TimeOnly     `gorm:"type:time;not null" json:"closes_at"`
+}
+
+// BeforeCreate hook to generate UUID before creating
+func (h *OpeningHours) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for OpeningHours model
+func (OpeningHours) TableName() string {
+	return "restaurant_opening_hours"
+}
+
+// weekSeconds is the length of the weekly schedule
+const weekSeconds = 7 * 24 * 60 * 60
+
+// span returns the interval's start, in seconds since Sunday midnight, and its length
+func (h *OpeningHours) span() (start, length int) {
+	opensH, opensM, opensS := h.OpensAt.Clock()
+	closesH, closesM, closesS := h.ClosesAt.Clock()
+	opens := opensH*3600 + opensM*60 + opensS
+	closes := closesH*3600 + closesM*60 + closesS
+
+	length = closes - opens
+	if length <= 0 {
+		length += 24 * 60 * 60
+	}
+	return int(h.Weekday)*24*60*60 + opens, length
+}
+
+// ValidateOpeningHours checks that no two intervals of a weekly schedule
+// overlap, counting the part of an interval that runs past midnight against
+// the next day's intervals
+func ValidateOpeningHours(hours []OpeningHours) error {
+	for i := range hours {
+		startI, lengthI := hours[i].span()
+		for j := i + 1; j < len(hours); j++ {
+			startJ, lengthJ := hours[j].span()
+			// Distances wrap around from Saturday into Sunday
+			if (startJ-startI+weekSeconds)%weekSeconds < lengthI ||
+				(startI-startJ+weekSeconds)%weekSeconds < lengthJ {
+				return ErrOverlappingHours
+			}
+		}
+	}
+	return nil
+}
+
+// RestaurantClosure closes a restaurant for a range of dates, inclusive
+type RestaurantClosure struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	RestaurantID uuid.UUID `gorm:"type:uuid;not null;index" json:"restaurant_id"`
+	StartDate    DateOnly  `gorm:"type:date;not null" json:"start_date"`
+	EndDate      DateOnly  `gorm:"type:date;not null" json:"end_date"`
+	Reason       string    `gorm:"type:text" json:"reason"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID before creating
+func (c *RestaurantClosure) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for RestaurantClosure model
+func (RestaurantClosure) TableName() string {
+	return "restaurant_closures"
+}
+
+// Covers checks if the closure includes the given calendar date
+func (c *RestaurantClosure) Covers(date DateOnly) bool {
+	return !date.Before(c.StartDate.Time) && !date.After(c.EndDate.Time)
+}
+
+// DateOnly is a custom type for a calendar date without time
+type DateOnly struct {
+	time.Time
+}
+
+// NewDateOnly returns the calendar date of t in t's location
+func NewDateOnly(t time.Time) DateOnly {
+	return DateOnly{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// ParseDateOnly parses a date in YYYY-MM-DD format
+func ParseDateOnly(s string) (DateOnly, error) {
+	parsed, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return DateOnly{}, err
+	}
+	return DateOnly{parsed}, nil
+}
+
+// Scan implements the Scanner interface for database reading
+func (d *DateOnly) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		*d = NewDateOnly(v)
+		return nil
+	case string:
+		parsed, err := ParseDateOnly(v)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	default:
+		return fmt.Errorf("cannot scan type %T into DateOnly", value)
+	}
+}
+
+// Value implements the Valuer interface for database writing
+func (d DateOnly) Value() (driver.Value, error) {
+	if d.Time.IsZero() {
+		return nil, nil
+	}
+	return d.Time.Format("2006-01-02"), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (d DateOnly) MarshalJSON() ([]byte, error) {
+	if d.Time.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(fmt.Sprintf(`"%s"`, d.Time.Format("2006-01-02"))), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *DateOnly) UnmarshalJSON(data []byte) error {
+	str := string(data)
+	if str == "null" || str == `""` {
+		return nil
+	}
+	parsed, err := ParseDateOnly(str[1 : len(str)-1])
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Location returns the restaurant's timezone, falling back to DefaultTimezone
+func (r *Restaurant) Location() *time.Location {
+	name := r.Timezone
+	if name == "" {
+		name = DefaultTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// At returns the instant on t's calendar date, in the restaurant's timezone, at the given time of day
+func (r *Restaurant) At(t time.Time, timeOfDay TimeOnly) time.Time {
+	local := t.In(r.Location())
+	h, m, s := timeOfDay.Clock()
+	return time.Date(local.Year(), local.Month(), local.Day(), h, m, s, 0, local.Location())
+}
+
+// IsOpenAt checks if the restaurant is open at the given instant
+func (r *Restaurant) IsOpenAt(t time.Time) bool {
+	_, ok := r.closingAfter(t)
+	return ok
+}
+
+// IsOpenThroughout checks if the restaurant is open continuously from start until end
+func (r *Restaurant) IsOpenThroughout(start, end time.Time) bool {
+	closes, ok := r.closingAfter(start)
+	return ok && !end.After(closes)
+}
+
+// NextOpeningAfter returns when the restaurant next opens after t, or nil if it
+// is open at t or has no opening within the search horizon
+func (r *Restaurant) NextOpeningAfter(t time.Time) *time.Time {
+	if r.IsOpenAt(t) {
+		return nil
+	}
+
+	local := t.In(r.Location())
+	for offset := 0; offset <= scheduleHorizonDays; offset++ {
+		var next *time.Time
+		for _, interval := range r.intervalsOn(local.AddDate(0, 0, offset)) {
+			start := interval[0]
+			if start.After(local) && (next == nil || start.Before(*next)) {
+				next = &start
+			}
+		}
+		if next != nil {
+			return next
+		}
+	}
+	return nil
+}
+
+// SetOpenStatus fills the computed is_open_now and next_open_at fields
+func (r *Restaurant) SetOpenStatus(now time.Time) {
+	open := r.IsOpenAt(now)
+	r.IsOpenNow = &open
+	r.NextOpenAt = r.NextOpeningAfter(now)
+}
+
+// closingAfter returns the end of the open interval containing t, if any
+func (r *Restaurant) closingAfter(t time.Time) (time.Time, bool) {
+	local := t.In(r.Location())
+
+	// Intervals from the previous day may run past midnight
+	var closes time.Time
+	found := false
+	for _, day := range []time.Time{local.AddDate(0, 0, -1), local} {
+		for _, interval := range r.intervalsOn(day) {
+			if !local.Before(interval[0]) && local.Before(interval[1]) && interval[1].After(closes) {
+				closes = interval[1]
+				found = true
+			}
+		}
+	}
+	return closes, found
+}
+
+// intervalsOn returns the open intervals starting on day's calendar date.
+// Without a weekly schedule the restaurant is treated as open from midnight
+// until its closing time, matching the behaviour before opening hours existed.
+func (r *Restaurant) intervalsOn(day time.Time) [][2]time.Time {
+	date := NewDateOnly(day)
+	for _, closure := range r.Closures {
+		if closure.Covers(date) {
+			return nil
+		}
+	}
+
+	if len(r.OpeningHours) == 0 {
+		start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+		end := r.At(day, r.ClosingTime)
+		if !end.After(start) {
+			end = start.AddDate(0, 0, 1)
+		}
+		return [][2]time.Time{{start, end}}
+	}
+
+	var intervals [][2]time.Time
+	for _, hours := range r.OpeningHours {
+		if hours.Weekday != day.Weekday() {
+			continue
+		}
+		opens := r.At(day, hours.OpensAt)
+		closes := r.At(day, hours.ClosesAt)
+		if !closes.After(opens) {
+			closes = r.At(day.AddDate(0, 0, 1), hours.ClosesAt)
+		}
+		intervals = append(intervals, [2]time.Time{opens, closes})
+	}
+	return intervals
+}
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// openingHours returns an interval on weekday between two "15:04" times
+func openingHours(weekday time.Weekday, opens, closes string) OpeningHours {
+	parse := func(s string) TimeOnly {
+		t, err := time.Parse("15:04", s)
+		if err != nil {
+			panic(err)
+		}
+		return TimeOnly{t}
+	}
+	return OpeningHours{Weekday: weekday, OpensAt: parse(opens), ClosesAt: parse(closes)}
+}
+
+// marchAt returns the given day of March 2026, in UTC; March 7 is a Saturday
+func marchAt(day, hour, minute int) time.Time {
+	return time.Date(2026, 3, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestValidateOpeningHours(t *testing.T) {
+	tests := []struct {
+		name    string
+		hours   []OpeningHours
+		overlap bool
+	}{
+		{
+			name:  "separate intervals on one day",
+			hours: []OpeningHours{openingHours(time.Monday, "07:00", "11:00"), openingHours(time.Monday, "17:00", "21:00")},
+		},
+		{
+			name:    "overlapping intervals on one day",
+			hours:   []OpeningHours{openingHours(time.Monday, "07:00", "12:00"), openingHours(time.Monday, "11:00", "14:00")},
+			overlap: true,
+		},
+		{
+			name:    "overnight into the next day",
+			hours:   []OpeningHours{openingHours(time.Monday, "22:00", "02:00"), openingHours(time.Tuesday, "01:00", "05:00")},
+			overlap: true,
+		},
+		{
+			name:    "Saturday night into Sunday",
+			hours:   []OpeningHours{openingHours(time.Saturday, "22:00", "02:00"), openingHours(time.Sunday, "01:00", "05:00")},
+			overlap: true,
+		},
+		{
+			name:    "Sunday listed first",
+			hours:   []OpeningHours{openingHours(time.Sunday, "00:00", "08:00"), openingHours(time.Saturday, "23:00", "00:30")},
+			overlap: true,
+		},
+		{
+			name:  "Saturday night ending as Sunday opens",
+			hours: []OpeningHours{openingHours(time.Saturday, "22:00", "02:00"), openingHours(time.Sunday, "02:00", "05:00")},
+		},
+		{
+			name:  "Saturday ending at midnight",
+			hours: []OpeningHours{openingHours(time.Saturday, "18:00", "00:00"), openingHours(time.Sunday, "00:00", "05:00")},
+		},
+		{
+			name:  "opens equal to closes alone",
+			hours: []OpeningHours{openingHours(time.Monday, "09:00", "09:00")},
+		},
+		{
+			name:    "opens equal to closes runs a full day",
+			hours:   []OpeningHours{openingHours(time.Monday, "09:00", "09:00"), openingHours(time.Tuesday, "08:00", "10:00")},
+			overlap: true,
+		},
+		{
+			name:    "opens equal to closes on the same day",
+			hours:   []OpeningHours{openingHours(time.Monday, "09:00", "09:00"), openingHours(time.Monday, "12:00", "13:00")},
+			overlap: true,
+		},
+		{
+			name:  "full day followed by the next",
+			hours: []OpeningHours{openingHours(time.Monday, "09:00", "09:00"), openingHours(time.Tuesday, "09:00", "10:00")},
+		},
+		{
+			name:    "full Saturday into Sunday",
+			hours:   []OpeningHours{openingHours(time.Saturday, "12:00", "12:00"), openingHours(time.Sunday, "11:00", "13:00")},
+			overlap: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOpeningHours(tt.hours)
+			if tt.overlap && !errors.Is(err, ErrOverlappingHours) {
+				t.Errorf("err = %v, want ErrOverlappingHours", err)
+			}
+			if !tt.overlap && err != nil {
+				t.Errorf("err = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestIsOpenAtOvernight(t *testing.T) {
+	restaurant := &Restaurant{
+		Timezone:     "UTC",
+		OpeningHours: []OpeningHours{openingHours(time.Saturday, "22:00", "02:00")},
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		open bool
+	}{
+		{name: "before opening", at: marchAt(7, 21, 59), open: false},
+		{name: "at opening", at: marchAt(7, 22, 0), open: true},
+		{name: "Saturday night", at: marchAt(7, 23, 30), open: true},
+		{name: "after midnight on Sunday", at: marchAt(8, 1, 0), open: true},
+		{name: "at closing", at: marchAt(8, 2, 0), open: false},
+		{name: "early Saturday", at: marchAt(7, 1, 0), open: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := restaurant.IsOpenAt(tt.at); got != tt.open {
+				t.Errorf("open = %v, want %v", got, tt.open)
+			}
+		})
+	}
+}
+
+func TestIsOpenThroughoutAcrossMidnight(t *testing.T) {
+	restaurant := &Restaurant{
+		Timezone: "UTC",
+		OpeningHours: []OpeningHours{
+			openingHours(time.Saturday, "22:00", "02:00"),
+			openingHours(time.Monday, "09:00", "09:00"),
+		},
+	}
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		open       bool
+	}{
+		{name: "across midnight", start: marchAt(7, 23, 0), end: marchAt(8, 1, 30), open: true},
+		{name: "until closing", start: marchAt(8, 0, 30), end: marchAt(8, 2, 0), open: true},
+		{name: "past closing", start: marchAt(7, 23, 0), end: marchAt(8, 2, 30), open: false},
+		{name: "starting before opening", start: marchAt(7, 21, 30), end: marchAt(7, 23, 0), open: false},
+		{name: "through a full day", start: marchAt(9, 10, 0), end: marchAt(10, 9, 0), open: true},
+		{name: "past a full day", start: marchAt(9, 10, 0), end: marchAt(10, 9, 1), open: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := restaurant.IsOpenThroughout(tt.start, tt.end); got != tt.open {
+				t.Errorf("open throughout = %v, want %v", got, tt.open)
+			}
+		})
+	}
+}
+
+func TestClosureOnPreviousDay(t *testing.T) {
+	saturday := NewDateOnly(marchAt(7, 0, 0))
+	sunday := NewDateOnly(marchAt(8, 0, 0))
+	hours := []OpeningHours{openingHours(time.Saturday, "22:00", "02:00")}
+
+	// Closing Saturday closes the whole interval, including its Sunday part
+	closedSaturday := &Restaurant{
+		Timezone:     "UTC",
+		OpeningHours: hours,
+		Closures:     []RestaurantClosure{{StartDate: saturday, EndDate: saturday}},
+	}
+	if closedSaturday.IsOpenAt(marchAt(8, 1, 0)) {
+		t.Error("open after midnight although Saturday is closed")
+	}
+
+	// Closing Sunday leaves the interval that started on Saturday alone
+	closedSunday := &Restaurant{
+		Timezone:     "UTC",
+		OpeningHours: hours,
+		Closures:     []RestaurantClosure{{StartDate: sunday, EndDate: sunday}},
+	}
+	if !closedSunday.IsOpenAt(marchAt(8, 1, 0)) {
+		t.Error("closed after midnight although only Sunday is closed")
+	}
+	if next := closedSaturday.NextOpeningAfter(marchAt(8, 3, 0)); next == nil || !next.Equal(marchAt(14, 22, 0)) {
+		t.Errorf("next opening = %v, want %s", next, marchAt(14, 22, 0))
+	}
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RefreshToken represents a server-side refresh token.
+// Tokens are rotated on every use; all tokens descending from the same
+// login share a FamilyID so that reuse of a rotated token can revoke them all.
+type RefreshToken struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	FamilyID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"family_id"`
+	TokenHash  string     `gorm:"type:varchar(64);unique;not null" json:"-"`
+	AccessJTI  uuid.UUID  `gorm:"column:access_jti;type:uuid;not null" json:"-"` // ID of the access token issued alongside
+	UserAgent  string     `gorm:"type:text" json:"user_agent"`
+	DeviceName string     `gorm:"type:varchar(255)" json:"device_name"`
+	IPAddress  string     `gorm:"type:varchar(64)" json:"ip_address"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	ReplacedBy *uuid.UUID `gorm:"type:uuid" json:"-"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID before creating
+func (t *RefreshToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	if t.FamilyID == uuid.Nil {
+		t.FamilyID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for RefreshToken model
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// IsExpired checks if the refresh token has passed its expiry
+func (t *RefreshToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+// IsRevoked checks if the refresh token has been rotated or revoked
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// RevokedToken represents a revoked access token, identified by its jti claim
+type RevokedToken struct {
+	JTI       uuid.UUID `gorm:"column:jti;type:uuid;primary_key" json:"jti"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"` // Row can be purged after this time
+	RevokedAt time.Time `gorm:"autoCreateTime" json:"revoked_at"`
+}
+
+// TableName specifies the table name for RevokedToken model
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
+
+// SessionMetadata describes the client a session was issued to
+type SessionMetadata struct {
+	UserAgent  string
+	DeviceName string
+	IPAddress  string
+}
@@ -13,6 +13,7 @@ type UserRole string
 const (
 	RoleUser       UserRole = "user"
 	RoleRestaurant UserRole = "restaurant"
+	RoleAdmin      UserRole = "admin"
 )
 
 // User represents a user in the system
@@ -23,6 +24,13 @@ type User struct {
 	Role      UserRole  `gorm:"type:varchar(20);not null;default:'user'" json:"role"`
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 
+	// Moderation
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty"`
+	SuspensionReason *string    `gorm:"type:text" json:"suspension_reason,omitempty"`
+
+	// Computed for the profile endpoint, not stored
+	Savings *SavingsSummary `gorm:"-" json:"savings,omitempty"`
+
 	// Relationships
 	Restaurants []Restaurant `gorm:"foreignKey:OwnerID" json:"restaurants,omitempty"`
 	Orders      []Order      `gorm:"foreignKey:UserID" json:"orders,omitempty"`
@@ -48,3 +56,13 @@ func (User) TableName() string {
 func (u *User) IsRestaurant() bool {
 	return u.Role == RoleRestaurant
 }
+
+// IsAdmin checks if the user has admin role
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// IsSuspended checks if the user has been suspended by an admin
+func (u *User) IsSuspended() bool {
+	return u.SuspendedAt != nil
+}
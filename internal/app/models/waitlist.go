@@ -0,0 +1,114 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WaitlistStatus represents the status of a waitlist entry
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting  WaitlistStatus = "waiting"
+	WaitlistStatusNotified WaitlistStatus = "notified" // Told the listing is back; no longer in line
+)
+
+// WaitlistEntry is a customer in line for a sold-out listing. Customers are
+// notified in the order they joined when the listing comes back in stock.
+type WaitlistEntry struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	ListingID uuid.UUID      `gorm:"type:uuid;not null;index" json:"listing_id"`
+	Qty       int            `gorm:"not null;default:1" json:"qty"` // Units wanted
+	Status    WaitlistStatus `gorm:"type:varchar(20);not null;default:'waiting'" json:"status"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+
+	// Set when the customer is notified; the reservation holds units for them
+	NotifiedAt    *time.Time `json:"notified_at,omitempty"`
+	ReservationID *uuid.UUID `gorm:"type:uuid" json:"reservation_id,omitempty"`
+
+	// Place in line, counted from 1 while waiting; not stored
+	Position int `gorm:"-" json:"position,omitempty"`
+
+	// End of the hold while the reservation still holds the units; not stored
+	HoldExpiresAt *time.Time `gorm:"-" json:"hold_expires_at,omitempty"`
+
+	// Relationships
+	Listing     Listing           `gorm:"foreignKey:ListingID" json:"listing,omitempty"`
+	Reservation *StockReservation `gorm:"foreignKey:ReservationID" json:"-"`
+}
+
+// BeforeCreate hook to generate UUID and set defaults
+func (e *WaitlistEntry) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.Status == "" {
+		e.Status = WaitlistStatusWaiting
+	}
+	return nil
+}
+
+// SetHold sets HoldExpiresAt from the preloaded reservation if it is still active
+func (e *WaitlistEntry) SetHold() {
+	if e.Reservation != nil && e.Reservation.Status == ReservationStatusActive {
+		e.HoldExpiresAt = &e.Reservation.ExpiresAt
+	}
+}
+
+// TableName specifies the table name for WaitlistEntry model
+func (WaitlistEntry) TableName() string {
+	return "waitlist_entries"
+}
+
+// RestaurantSubscription asks for a notification whenever one of a
+// restaurant's sold-out listings comes back in stock
+type RestaurantSubscription struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_restaurant_subscriptions_user_restaurant" json:"user_id"`
+	RestaurantID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_restaurant_subscriptions_user_restaurant" json:"restaurant_id"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	Restaurant Restaurant `gorm:"foreignKey:RestaurantID" json:"restaurant,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID before creating
+func (s *RestaurantSubscription) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for RestaurantSubscription model
+func (RestaurantSubscription) TableName() string {
+	return "restaurant_subscriptions"
+}
+
+// Waitlist is what a customer is waiting for
+type Waitlist struct {
+	Listings    []WaitlistEntry          `json:"listings"`
+	Restaurants []RestaurantSubscription `json:"restaurants"`
+}
+
+// HasWaiting checks if the customer is still in line for any listing
+func (w *Waitlist) HasWaiting() bool {
+	for _, entry := range w.Listings {
+		if entry.Status == WaitlistStatusWaiting {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitlistNotification tells a customer a listing is back in stock; it is not
+// stored
+type WaitlistNotification struct {
+	UserID      uuid.UUID
+	Listing     *Listing
+	Qty         int               // Units offered
+	Reservation *StockReservation // Exclusive hold for the customer, if any
+}
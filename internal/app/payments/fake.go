@@ -0,0 +1,207 @@
+package payments
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// webhookTolerance is how old a signed webhook may be before it is refused as a replay
+const webhookTolerance = 5 * time.Minute
+
+// fakeIntentStatus tracks a fake intent
+type fakeIntentStatus string
+
+const (
+	fakeRequiresPayment fakeIntentStatus = "requires_payment"
+	fakeAuthorized      fakeIntentStatus = "authorized"
+	fakeCaptured        fakeIntentStatus = "captured"
+)
+
+// fakeIntent is an intent held by FakeProvider
+type fakeIntent struct {
+	amount   int
+	status   fakeIntentStatus
+	refunded int
+	refunds  map[string]*Refund // By idempotency key
+}
+
+// FakeProvider is an in-process PaymentProvider for development and tests. No
+// money moves: Simulate stands in for the customer paying on the client and
+// returns the webhook the provider would send. Webhooks are signed like a real
+// provider's, with "t=<unix time>,v1=<hex HMAC-SHA256 of "<t>.<payload>">" in
+// the Payment-Signature header.
+type FakeProvider struct {
+	secret string
+
+	mu      sync.Mutex
+	intents map[string]*fakeIntent
+}
+
+// NewFakeProvider creates a fake provider signing webhooks with secret
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{
+		secret:  secret,
+		intents: make(map[string]*fakeIntent),
+	}
+}
+
+// Name returns the provider name
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+// SignatureHeader returns the request header carrying the webhook signature
+func (p *FakeProvider) SignatureHeader() string {
+	return "Payment-Signature"
+}
+
+// CreateIntent creates an intent awaiting the customer
+func (p *FakeProvider) CreateIntent(req IntentRequest) (*Intent, error) {
+	secret, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	id := "fake_pi_" + uuid.New().String()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.intents[id] = &fakeIntent{
+		amount:  req.Amount,
+		status:  fakeRequiresPayment,
+		refunds: make(map[string]*Refund),
+	}
+	return &Intent{ID: id, ClientSecret: id + "_secret_" + secret}, nil
+}
+
+// Capture collects amount from an authorized intent
+func (p *FakeProvider) Capture(intentID string, amount int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return ErrIntentNotFound
+	}
+	if intent.status == fakeCaptured {
+		return nil // Captures are retried after failed commits
+	}
+	if intent.status != fakeAuthorized || amount > intent.amount {
+		return ErrNotCapturable
+	}
+	intent.status = fakeCaptured
+	intent.amount = amount
+	return nil
+}
+
+// Refund returns part or all of a captured intent; refunds succeed at once
+func (p *FakeProvider) Refund(req RefundRequest) (*Refund, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[req.IntentID]
+	if !ok {
+		return nil, ErrIntentNotFound
+	}
+	if refund, ok := intent.refunds[req.IdempotencyKey]; ok {
+		return refund, nil
+	}
+	if intent.status != fakeCaptured {
+		return nil, ErrNotCapturable
+	}
+	if req.Amount <= 0 || intent.refunded+req.Amount > intent.amount {
+		return nil, ErrRefundExceeded
+	}
+
+	refund := &Refund{ID: "fake_re_" + uuid.New().String(), Status: RefundSucceeded}
+	intent.refunded += req.Amount
+	intent.refunds[req.IdempotencyKey] = refund
+	return refund, nil
+}
+
+// ParseWebhook verifies a webhook's signature and age and decodes it
+func (p *FakeProvider) ParseWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	var timestamp, mac string
+	for _, part := range strings.Split(signature, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			mac = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || mac == "" {
+		return nil, ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(mac), []byte(p.sign(timestamp, payload))) {
+		return nil, ErrInvalidSignature
+	}
+	if age := time.Since(time.Unix(unix, 0)); age > webhookTolerance || age < -webhookTolerance {
+		return nil, ErrInvalidSignature
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook: %w", err)
+	}
+	return &event, nil
+}
+
+// Simulate settles an intent as if the customer had paid, or failed to pay, on
+// the client and returns the signed webhook reporting it
+func (p *FakeProvider) Simulate(intentID string, succeed bool) ([]byte, string, error) {
+	event := WebhookEvent{ID: "fake_evt_" + uuid.New().String(), IntentID: intentID}
+
+	p.mu.Lock()
+	intent, ok := p.intents[intentID]
+	switch {
+	case !ok:
+		p.mu.Unlock()
+		return nil, "", ErrIntentNotFound
+	case succeed:
+		if intent.status == fakeRequiresPayment {
+			intent.status = fakeAuthorized
+		}
+		event.Type = EventPaymentAuthorized
+	default:
+		event.Type = EventPaymentFailed
+		event.FailureReason = "card_declined"
+	}
+	p.mu.Unlock()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, "", err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	return payload, "t=" + timestamp + ",v1=" + p.sign(timestamp, payload), nil
+}
+
+// sign returns the hex HMAC-SHA256 of "<timestamp>.<payload>"
+func (p *FakeProvider) sign(timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// randomHex returns n random bytes, hex encoded
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,135 @@
+package payments
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "test-webhook-secret"
+
+// authorizedIntent creates an intent and settles it as paid, returning its ID
+// and the signed webhook reporting it
+func authorizedIntent(t *testing.T, p *FakeProvider, amount int) (string, []byte, string) {
+	t.Helper()
+	intent, err := p.CreateIntent(IntentRequest{Amount: amount, Currency: "IDR"})
+	if err != nil {
+		t.Fatalf("create intent: %v", err)
+	}
+	payload, signature, err := p.Simulate(intent.ID, true)
+	if err != nil {
+		t.Fatalf("simulate payment: %v", err)
+	}
+	return intent.ID, payload, signature
+}
+
+// signedAt signs payload as if sent at sentAt
+func signedAt(p *FakeProvider, payload []byte, sentAt time.Time) string {
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	return "t=" + timestamp + ",v1=" + p.sign(timestamp, payload)
+}
+
+func TestParseWebhookSignature(t *testing.T) {
+	p := NewFakeProvider(testWebhookSecret)
+	intentID, payload, signature := authorizedIntent(t, p, 25000)
+
+	event, err := p.ParseWebhook(payload, signature)
+	if err != nil {
+		t.Fatalf("valid webhook rejected: %v", err)
+	}
+	if event.Type != EventPaymentAuthorized || event.IntentID != intentID {
+		t.Errorf("event = %+v, want %s for %s", event, EventPaymentAuthorized, intentID)
+	}
+
+	tests := []struct {
+		name      string
+		payload   []byte
+		signature string
+	}{
+		{name: "tampered payload", payload: append(append([]byte(nil), payload...), ' '), signature: signature},
+		{name: "other secret", payload: payload, signature: signedAt(NewFakeProvider("another-secret"), payload, time.Now())},
+		{name: "missing signature", payload: payload, signature: ""},
+		{name: "missing timestamp", payload: payload, signature: "v1=" + p.sign("", payload)},
+		{name: "malformed timestamp", payload: payload, signature: "t=soon,v1=" + p.sign("soon", payload)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := p.ParseWebhook(tt.payload, tt.signature); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("err = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+}
+
+func TestParseWebhookTimestampTolerance(t *testing.T) {
+	p := NewFakeProvider(testWebhookSecret)
+	_, payload, _ := authorizedIntent(t, p, 25000)
+
+	tests := []struct {
+		name   string
+		sentAt time.Time
+		valid  bool
+	}{
+		{name: "recent", sentAt: time.Now().Add(-webhookTolerance + time.Minute), valid: true},
+		{name: "slightly ahead", sentAt: time.Now().Add(webhookTolerance - time.Minute), valid: true},
+		{name: "replayed", sentAt: time.Now().Add(-webhookTolerance - time.Minute)},
+		{name: "far ahead", sentAt: time.Now().Add(webhookTolerance + time.Minute)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ParseWebhook(payload, signedAt(p, payload, tt.sentAt))
+			if tt.valid && err != nil {
+				t.Errorf("err = %v, want nil", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("err = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+}
+
+func TestCaptureRequiresAuthorization(t *testing.T) {
+	p := NewFakeProvider(testWebhookSecret)
+	intent, err := p.CreateIntent(IntentRequest{Amount: 25000, Currency: "IDR"})
+	if err != nil {
+		t.Fatalf("create intent: %v", err)
+	}
+
+	if err := p.Capture(intent.ID, 25000); !errors.Is(err, ErrNotCapturable) {
+		t.Fatalf("capture before authorization: err = %v, want ErrNotCapturable", err)
+	}
+	if _, _, err := p.Simulate(intent.ID, true); err != nil {
+		t.Fatalf("simulate payment: %v", err)
+	}
+	if err := p.Capture(intent.ID, 25000); err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	if err := p.Capture(intent.ID, 25000); err != nil {
+		t.Errorf("repeated capture: err = %v, want nil", err)
+	}
+}
+
+func TestRefundIdempotencyAndCap(t *testing.T) {
+	p := NewFakeProvider(testWebhookSecret)
+	intentID, _, _ := authorizedIntent(t, p, 25000)
+	if err := p.Capture(intentID, 25000); err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+
+	first, err := p.Refund(RefundRequest{IntentID: intentID, Amount: 20000, IdempotencyKey: "refund-1"})
+	if err != nil {
+		t.Fatalf("refund: %v", err)
+	}
+	again, err := p.Refund(RefundRequest{IntentID: intentID, Amount: 20000, IdempotencyKey: "refund-1"})
+	if err != nil {
+		t.Fatalf("retried refund: %v", err)
+	}
+	if again.ID != first.ID {
+		t.Errorf("retried refund ID = %s, want %s", again.ID, first.ID)
+	}
+
+	if _, err := p.Refund(RefundRequest{IntentID: intentID, Amount: 10000, IdempotencyKey: "refund-2"}); !errors.Is(err, ErrRefundExceeded) {
+		t.Errorf("refund above the captured amount: err = %v, want ErrRefundExceeded", err)
+	}
+}
@@ -0,0 +1,79 @@
+package payments
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Provider errors
+var (
+	ErrInvalidSignature = errors.New("webhook signature is invalid")
+	ErrIntentNotFound   = errors.New("payment intent not found")
+	ErrNotCapturable    = errors.New("payment intent is not authorized for capture")
+	ErrRefundExceeded   = errors.New("refund exceeds the captured amount")
+)
+
+// PaymentProvider takes payments from customers. Customers authorize an intent
+// on the client with its client secret; the provider reports the outcome
+// through a signed webhook and authorized intents are then captured.
+type PaymentProvider interface {
+	Name() string
+	CreateIntent(req IntentRequest) (*Intent, error)
+	Capture(intentID string, amount int) error
+	Refund(req RefundRequest) (*Refund, error)
+	ParseWebhook(payload []byte, signature string) (*WebhookEvent, error)
+	SignatureHeader() string // Request header carrying the webhook signature
+}
+
+// IntentRequest describes a payment to collect
+type IntentRequest struct {
+	OrderID  uuid.UUID
+	Amount   int // Smallest currency unit
+	Currency string
+}
+
+// Intent is a payment the customer has yet to authorize
+type Intent struct {
+	ID           string
+	ClientSecret string // Lets the client authorize the intent
+}
+
+// RefundRequest describes money to return from a captured intent. Requests
+// with the same idempotency key are refunded once.
+type RefundRequest struct {
+	IntentID       string
+	Amount         int // Smallest currency unit
+	IdempotencyKey string
+}
+
+// RefundStatus represents the outcome of a refund at the provider
+type RefundStatus string
+
+const (
+	RefundSucceeded RefundStatus = "succeeded"
+	RefundPending   RefundStatus = "pending" // Confirmed later through a webhook
+	RefundFailed    RefundStatus = "failed"
+)
+
+// Refund is a refund issued by the provider
+type Refund struct {
+	ID     string
+	Status RefundStatus
+}
+
+// WebhookEventType identifies what a webhook reports
+type WebhookEventType string
+
+const (
+	EventPaymentAuthorized WebhookEventType = "payment.authorized" // Ready to capture
+	EventPaymentFailed     WebhookEventType = "payment.failed"
+)
+
+// WebhookEvent is a verified webhook notification
+type WebhookEvent struct {
+	ID            string           `json:"id"`
+	Type          WebhookEventType `json:"type"`
+	IntentID      string           `json:"intent_id"`
+	FailureReason string           `json:"failure_reason,omitempty"`
+}
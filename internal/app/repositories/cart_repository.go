@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CartRepository interface defines cart data access methods
+type CartRepository interface {
+	FindByUserID(userID uuid.UUID) ([]models.CartItem, error)
+	SetItem(item *models.CartItem, restaurantID uuid.UUID) error
+	RemoveItem(userID, listingID uuid.UUID) error
+	Clear(userID uuid.UUID) error
+}
+
+// cartRepository implements CartRepository
+type cartRepository struct {
+	db *gorm.DB
+}
+
+// NewCartRepository creates a new cart repository
+func NewCartRepository(db *gorm.DB) CartRepository {
+	return &cartRepository{db: db}
+}
+
+// FindByUserID finds a user's cart items with listings and restaurants preloaded,
+// oldest first
+func (r *cartRepository) FindByUserID(userID uuid.UUID) ([]models.CartItem, error) {
+	var items []models.CartItem
+	err := r.db.Preload("Listing").Preload("Listing.Restaurant").
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&items).Error
+	return items, err
+}
+
+// SetItem adds a listing to a user's cart or replaces its quantity. restaurantID
+// is the listing's restaurant; it fails with ErrCartRestaurantMismatch if the
+// cart holds listings from another restaurant.
+func (r *cartRepository) SetItem(item *models.CartItem, restaurantID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the user so concurrent additions check the cart one at a time
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").Where("id = ?", item.UserID).First(&models.User{}).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return models.ErrNotFound
+			}
+			return err
+		}
+
+		var others int64
+		err = tx.Model(&models.CartItem{}).
+			Joins("JOIN listings ON listings.id = cart_items.listing_id").
+			Where("cart_items.user_id = ? AND listings.restaurant_id <> ?", item.UserID, restaurantID).
+			Count(&others).Error
+		if err != nil {
+			return err
+		}
+		if others > 0 {
+			return models.ErrCartRestaurantMismatch
+		}
+
+		return tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "listing_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"qty", "updated_at"}),
+		}).Create(item).Error
+	})
+}
+
+// RemoveItem removes a listing from a user's cart
+func (r *cartRepository) RemoveItem(userID, listingID uuid.UUID) error {
+	result := r.db.Where("user_id = ? AND listing_id = ?", userID, listingID).Delete(&models.CartItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// Clear removes every item from a user's cart
+func (r *cartRepository) Clear(userID uuid.UUID) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.CartItem{}).Error
+}
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"time"
+
+	"eatright-backend/internal/app/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRepository interface defines idempotency key data access methods
+type IdempotencyRepository interface {
+	Reserve(key *models.IdempotencyKey, staleBefore time.Time) (*models.IdempotencyKey, error)
+	Complete(key *models.IdempotencyKey) error
+	MarkUnknown(key *models.IdempotencyKey) error
+	Release(key *models.IdempotencyKey) error
+	DeleteExpiredWithTx(tx *gorm.DB, now time.Time, limit int) (int64, error)
+}
+
+// idempotencyRepository implements IdempotencyRepository
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository creates a new idempotency repository
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// Reserve stores a new in-progress key for the user. If the user already used
+// the key, it returns the existing record instead, unless that record has
+// expired or is an in-progress reservation created before staleBefore (its
+// request never finished); those are taken over. Keys whose outcome is unknown
+// are never taken over before they expire. It returns nil when key was
+// reserved.
+func (r *idempotencyRepository) Reserve(key *models.IdempotencyKey, staleBefore time.Time) (*models.IdempotencyKey, error) {
+	var existing *models.IdempotencyKey
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 1 {
+			return nil
+		}
+
+		// Lock the existing key so concurrent retries take it over one at a time
+		var found models.IdempotencyKey
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND key = ?", key.UserID, key.Key).
+			First(&found).Error
+		if err != nil {
+			return err
+		}
+
+		if found.ExpiresAt.After(key.CreatedAt) && (!found.IsInProgress() || !found.CreatedAt.Before(staleBefore)) {
+			existing = &found
+			return nil
+		}
+
+		key.ID = found.ID
+		return tx.Model(&found).Updates(map[string]interface{}{
+			"fingerprint": key.Fingerprint,
+			"status_code": 0,
+			"response":    nil,
+			"created_at":  key.CreatedAt,
+			"expires_at":  key.ExpiresAt,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// Complete stores the response of a reserved key
+func (r *idempotencyRepository) Complete(key *models.IdempotencyKey) error {
+	return r.db.Model(&models.IdempotencyKey{}).
+		Where("id = ?", key.ID).
+		Updates(map[string]interface{}{
+			"status_code": key.StatusCode,
+			"response":    key.Response,
+		}).Error
+}
+
+// MarkUnknown marks a reserved key whose response could not be stored
+func (r *idempotencyRepository) MarkUnknown(key *models.IdempotencyKey) error {
+	return r.db.Model(&models.IdempotencyKey{}).
+		Where("id = ? AND status_code = 0", key.ID).
+		Update("status_code", models.IdempotencyStatusUnknown).Error
+}
+
+// Release deletes a reserved key whose request failed, so it can be retried
+func (r *idempotencyRepository) Release(key *models.IdempotencyKey) error {
+	return r.db.Where("id = ? AND status_code = 0", key.ID).Delete(&models.IdempotencyKey{}).Error
+}
+
+// DeleteExpiredWithTx deletes up to limit keys that expired before now.
+// Keys locked by a retry taking them over are skipped.
+func (r *idempotencyRepository) DeleteExpiredWithTx(tx *gorm.DB, now time.Time, limit int) (int64, error) {
+	claimed := tx.Model(&models.IdempotencyKey{}).Select("id").
+		Where("expires_at <= ?", now).
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+
+	result := tx.Where("id IN (?)", claimed).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
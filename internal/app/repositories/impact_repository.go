@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"time"
+
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImpactRepository interface defines impact aggregation over completed orders
+type ImpactRepository interface {
+	ItemsByUser(userID uuid.UUID) ([]models.ItemsByType, error)
+	ItemsByRestaurant(restaurantID uuid.UUID) ([]models.ItemsByType, error)
+	ItemsTotal() ([]models.ItemsByType, error)
+	ItemsSeries(period models.ImpactPeriod, timezone string, from, to time.Time) ([]models.ItemsByType, error)
+}
+
+// impactRepository implements ImpactRepository
+type impactRepository struct {
+	db *gorm.DB
+}
+
+// NewImpactRepository creates a new impact repository
+func NewImpactRepository(db *gorm.DB) ImpactRepository {
+	return &impactRepository{db: db}
+}
+
+// ItemsByUser counts a user's completed items per listing type
+func (r *impactRepository) ItemsByUser(userID uuid.UUID) ([]models.ItemsByType, error) {
+	return r.itemsByType(r.completed().Where("orders.user_id = ?", userID))
+}
+
+// ItemsByRestaurant counts a restaurant's completed items per listing type
+func (r *impactRepository) ItemsByRestaurant(restaurantID uuid.UUID) ([]models.ItemsByType, error) {
+	return r.itemsByType(r.completed().Where("orders.restaurant_id = ?", restaurantID))
+}
+
+// ItemsTotal counts all completed items per listing type
+func (r *impactRepository) ItemsTotal() ([]models.ItemsByType, error) {
+	return r.itemsByType(r.completed())
+}
+
+// ItemsSeries counts completed items per listing type and period bucket, for orders
+// completed in [from, to). Buckets start at local midnight in timezone.
+func (r *impactRepository) ItemsSeries(period models.ImpactPeriod, timezone string, from, to time.Time) ([]models.ItemsByType, error) {
+	var rows []models.ItemsByType
+	err := r.completed().
+		Select("date_trunc(?, orders.completed_at AT TIME ZONE ?) AS period_start, "+
+			"listings.type AS type, SUM(order_items.qty) AS items", string(period), timezone).
+		Where("orders.completed_at >= ? AND orders.completed_at < ?", from, to).
+		Group("1, 2").
+		Order("1").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// completed selects the items of completed orders joined with their order and listing
+func (r *impactRepository) completed() *gorm.DB {
+	return r.db.Model(&models.OrderItem{}).
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Joins("JOIN listings ON listings.id = order_items.listing_id").
+		Where("orders.status = ?", models.OrderStatusCompleted)
+}
+
+// itemsByType sums items per listing type for the given query
+func (r *impactRepository) itemsByType(query *gorm.DB) ([]models.ItemsByType, error) {
+	var rows []models.ItemsByType
+	err := query.Select("listings.type AS type, SUM(order_items.qty) AS items").
+		Group("listings.type").
+		Scan(&rows).Error
+	return rows, err
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"fmt"
+	"time"
 
 	"eatright-backend/internal/app/models"
 
@@ -17,9 +18,17 @@ type ListingRepository interface {
 	FindAll(activeOnly bool) ([]models.Listing, error)
 	FindByRestaurantID(restaurantID uuid.UUID) ([]models.Listing, error)
 	Update(listing *models.Listing) error
-	UpdateStock(id uuid.UUID, qty int) error
-	UpdateStockWithTx(tx *gorm.DB, id uuid.UUID, qty int) error
+	UpdateStock(id uuid.UUID, qty int) (int, error)
+	UpdateStockWithTx(tx *gorm.DB, id uuid.UUID, qty int) (int, error)
 	ToggleActive(id uuid.UUID, active bool) error
+	Deactivate(id uuid.UUID, reason models.DeactivationReason) error
+	ExpireEndedWithTx(tx *gorm.DB, now time.Time, limit int) (int64, error)
+	FindInProgressWithTx(tx *gorm.DB, now time.Time, limit int) ([]models.Listing, error)
+	DeactivateManyWithTx(tx *gorm.DB, ids []uuid.UUID, reason models.DeactivationReason, now time.Time) (int64, error)
+	SetPriceSchedule(id uuid.UUID, schedule *models.PriceSchedule) error
+	SetMaxPerCustomer(id uuid.UUID, limit *int) error
+	FindOccurrence(templateID uuid.UUID, date models.DateOnly) (*models.Listing, error)
+	CreateOccurrenceWithTx(tx *gorm.DB, listing *models.Listing) (bool, error)
 }
 
 // listingRepository implements ListingRepository
@@ -40,7 +49,10 @@ func (r *listingRepository) Create(listing *models.Listing) error {
 // FindByID finds a listing by ID with restaurant preloaded
 func (r *listingRepository) FindByID(id uuid.UUID) (*models.Listing, error) {
 	var listing models.Listing
-	err := r.db.Preload("Restaurant").Preload("Restaurant.Owner").Where("id = ?", id).First(&listing).Error
+	err := r.db.Preload("Restaurant").Preload("Restaurant.Owner").
+		Preload("Restaurant.OpeningHours").
+		Preload("Restaurant.Closures", "end_date >= CURRENT_DATE - 1").
+		Where("id = ?", id).First(&listing).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, models.ErrNotFound
@@ -50,13 +62,15 @@ func (r *listingRepository) FindByID(id uuid.UUID) (*models.Listing, error) {
 	return &listing, nil
 }
 
-// FindAll finds all listings, optionally filtering by active status
+// FindAll finds all listings, optionally filtering by active status.
+// Active listings exclude those of suspended or deleted restaurants and ended pickup windows.
 func (r *listingRepository) FindAll(activeOnly bool) ([]models.Listing, error) {
 	var listings []models.Listing
 	query := r.db.Preload("Restaurant").Preload("Restaurant.Owner")
 
 	if activeOnly {
-		query = query.Where("is_active = ? AND stock > 0", true)
+		query = query.Where("is_active = ? AND stock > 0 AND pickup_end > ?", true, time.Now()).
+			Where("restaurant_id IN (SELECT id FROM restaurants WHERE suspended_at IS NULL AND deleted_at IS NULL)")
 	}
 
 	err := query.Order("created_at DESC").Find(&listings).Error
@@ -75,24 +89,28 @@ func (r *listingRepository) Update(listing *models.Listing) error {
 	return r.db.Save(listing).Error
 }
 
-// UpdateStock updates listing stock with validation
-func (r *listingRepository) UpdateStock(id uuid.UUID, qty int) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		return r.UpdateStockWithTx(tx, id, qty)
+// UpdateStock updates listing stock with validation and returns the new stock
+func (r *listingRepository) UpdateStock(id uuid.UUID, qty int) (int, error) {
+	var stock int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		stock, err = r.UpdateStockWithTx(tx, id, qty)
+		return err
 	})
+	return stock, err
 }
 
-// UpdateStockWithTx updates listing stock within a transaction
-func (r *listingRepository) UpdateStockWithTx(tx *gorm.DB, id uuid.UUID, qty int) error {
+// UpdateStockWithTx updates listing stock within a transaction and returns the new stock
+func (r *listingRepository) UpdateStockWithTx(tx *gorm.DB, id uuid.UUID, qty int) (int, error) {
 	var listing models.Listing
 
 	// Lock the row for update to prevent race conditions
 	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&listing).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return models.ErrNotFound
+			return 0, models.ErrNotFound
 		}
-		return err
+		return 0, err
 	}
 
 	// Calculate new stock
@@ -100,28 +118,156 @@ func (r *listingRepository) UpdateStockWithTx(tx *gorm.DB, id uuid.UUID, qty int
 
 	// Prevent negative stock
 	if newStock < 0 {
-		return models.ErrNegativeStock
+		return 0, models.ErrNegativeStock
 	}
 
-	// Update stock
-	err = tx.Model(&listing).Update("stock", newStock).Error
+	// Update stock, marking a sold-out listing as restocked for its waitlist
+	updates := map[string]interface{}{"stock": newStock}
+	if listing.Stock == 0 && newStock > 0 {
+		updates["restocked_at"] = time.Now()
+	}
+	err = tx.Model(&listing).Updates(updates).Error
 	if err != nil {
-		return fmt.Errorf("failed to update stock: %w", err)
+		return 0, fmt.Errorf("failed to update stock: %w", err)
 	}
 
-	return nil
+	return newStock, nil
 }
 
 // ToggleActive toggles the active status of a listing
 func (r *listingRepository) ToggleActive(id uuid.UUID, active bool) error {
+	if !active {
+		return r.Deactivate(id, models.DeactivationManual)
+	}
+
+	result := r.db.Model(&models.Listing{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"is_active":           true,
+		"deactivated_at":      nil,
+		"deactivation_reason": nil,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// Deactivate deactivates a listing and records why
+func (r *listingRepository) Deactivate(id uuid.UUID, reason models.DeactivationReason) error {
+	result := r.db.Model(&models.Listing{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"is_active":           false,
+		"deactivated_at":      time.Now(),
+		"deactivation_reason": reason,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// SetPriceSchedule replaces a listing's markdown schedule; nil removes it
+func (r *listingRepository) SetPriceSchedule(id uuid.UUID, schedule *models.PriceSchedule) error {
+	var value interface{} = gorm.Expr("NULL")
+	if schedule != nil {
+		value = schedule
+	}
+
+	result := r.db.Model(&models.Listing{}).Where("id = ?", id).Update("price_schedule", value)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// SetMaxPerCustomer replaces a listing's per-customer limit; nil removes it
+func (r *listingRepository) SetMaxPerCustomer(id uuid.UUID, limit *int) error {
+	var value interface{} = gorm.Expr("NULL")
+	if limit != nil {
+		value = *limit
+	}
+
+	result := r.db.Model(&models.Listing{}).Where("id = ?", id).Update("max_per_customer", value)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// ExpireEndedWithTx deactivates up to limit active listings whose pickup window has ended.
+// Rows locked by another transaction are skipped and picked up on a later run.
+func (r *listingRepository) ExpireEndedWithTx(tx *gorm.DB, now time.Time, limit int) (int64, error) {
+	claimed := tx.Model(&models.Listing{}).Select("id").
+		Where("is_active = ? AND pickup_end <= ?", true, now).
+		Order("pickup_end ASC").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+
+	result := tx.Model(&models.Listing{}).Where("id IN (?)", claimed).Updates(map[string]interface{}{
+		"is_active":           false,
+		"deactivated_at":      now,
+		"deactivation_reason": models.DeactivationPickupEnded,
+	})
+	return result.RowsAffected, result.Error
+}
+
+// FindInProgressWithTx claims up to limit active listings whose pickup window is under way,
+// with their restaurant's schedule preloaded
+func (r *listingRepository) FindInProgressWithTx(tx *gorm.DB, now time.Time, limit int) ([]models.Listing, error) {
+	var listings []models.Listing
+	err := tx.Preload("Restaurant").
+		Preload("Restaurant.OpeningHours").
+		Preload("Restaurant.Closures", "end_date >= CURRENT_DATE - 1").
+		Where("is_active = ? AND pickup_start <= ? AND pickup_end > ?", true, now, now).
+		Order("pickup_end ASC").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Find(&listings).Error
+	return listings, err
+}
+
+// DeactivateManyWithTx deactivates the given listings with a reason
+func (r *listingRepository) DeactivateManyWithTx(tx *gorm.DB, ids []uuid.UUID, reason models.DeactivationReason, now time.Time) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := tx.Model(&models.Listing{}).Where("id IN ? AND is_active = ?", ids, true).Updates(map[string]interface{}{
+		"is_active":           false,
+		"deactivated_at":      now,
+		"deactivation_reason": reason,
+	})
+	return result.RowsAffected, result.Error
+}
+
+// FindOccurrence finds the listing created from a template for a date
+func (r *listingRepository) FindOccurrence(templateID uuid.UUID, date models.DateOnly) (*models.Listing, error) {
 	var listing models.Listing
-	err := r.db.Where("id = ?", id).First(&listing).Error
+	err := r.db.Where("template_id = ? AND occurrence_date = ?", templateID, date).First(&listing).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return models.ErrNotFound
+			return nil, models.ErrNotFound
 		}
-		return err
+		return nil, err
 	}
+	return &listing, nil
+}
 
-	return r.db.Model(&listing).Update("is_active", active).Error
+// CreateOccurrenceWithTx creates a listing for a template occurrence unless one already
+// exists for that date, reporting whether it was created
+func (r *listingRepository) CreateOccurrenceWithTx(tx *gorm.DB, listing *models.Listing) (bool, error) {
+	result := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "template_id"}, {Name: "occurrence_date"}},
+		DoNothing: true,
+	}).Create(listing)
+	return result.RowsAffected > 0, result.Error
 }
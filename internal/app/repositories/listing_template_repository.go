@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListingTemplateRepository interface defines listing template data access methods
+type ListingTemplateRepository interface {
+	Create(template *models.ListingTemplate) error
+	FindByID(id uuid.UUID) (*models.ListingTemplate, error)
+	FindByRestaurantID(restaurantID uuid.UUID) ([]models.ListingTemplate, error)
+	Update(template *models.ListingTemplate) error
+	Delete(id uuid.UUID) error
+	UpsertOverride(override *models.ListingOccurrenceOverride) error
+	DeleteOverride(templateID uuid.UUID, date models.DateOnly) error
+	FindActiveWithTx(tx *gorm.DB) ([]models.ListingTemplate, error)
+}
+
+// listingTemplateRepository implements ListingTemplateRepository
+type listingTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewListingTemplateRepository creates a new listing template repository
+func NewListingTemplateRepository(db *gorm.DB) ListingTemplateRepository {
+	return &listingTemplateRepository{db: db}
+}
+
+// Create creates a new listing template
+func (r *listingTemplateRepository) Create(template *models.ListingTemplate) error {
+	return r.db.Omit(clause.Associations).Create(template).Error
+}
+
+// FindByID finds a template by ID with upcoming overrides preloaded
+func (r *listingTemplateRepository) FindByID(id uuid.UUID) (*models.ListingTemplate, error) {
+	var template models.ListingTemplate
+	err := r.withOverrides(r.db).Where("id = ?", id).First(&template).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+// FindByRestaurantID finds a restaurant's templates with upcoming overrides preloaded
+func (r *listingTemplateRepository) FindByRestaurantID(restaurantID uuid.UUID) ([]models.ListingTemplate, error) {
+	var templates []models.ListingTemplate
+	err := r.withOverrides(r.db).Where("restaurant_id = ?", restaurantID).Order("created_at ASC").Find(&templates).Error
+	return templates, err
+}
+
+// Update updates a template's own columns
+func (r *listingTemplateRepository) Update(template *models.ListingTemplate) error {
+	return r.db.Omit(clause.Associations).Save(template).Error
+}
+
+// Delete deletes a template and its overrides; listings already created from it are kept
+func (r *listingTemplateRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("template_id = ?", id).Delete(&models.ListingOccurrenceOverride{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&models.ListingTemplate{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrNotFound
+		}
+		return nil
+	})
+}
+
+// UpsertOverride creates or replaces the override for a template and date
+func (r *listingTemplateRepository) UpsertOverride(override *models.ListingOccurrenceOverride) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "template_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"skip", "stock"}),
+	}).Create(override).Error
+}
+
+// DeleteOverride deletes the override for a template and date
+func (r *listingTemplateRepository) DeleteOverride(templateID uuid.UUID, date models.DateOnly) error {
+	result := r.db.Where("template_id = ? AND date = ?", templateID, date).Delete(&models.ListingOccurrenceOverride{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// FindActiveWithTx finds active templates of restaurants that are neither suspended nor deleted,
+// with the restaurant's schedule and upcoming overrides preloaded
+func (r *listingTemplateRepository) FindActiveWithTx(tx *gorm.DB) ([]models.ListingTemplate, error) {
+	var templates []models.ListingTemplate
+	err := r.withOverrides(tx).
+		Preload("Restaurant").
+		Preload("Restaurant.OpeningHours").
+		Preload("Restaurant.Closures", "end_date >= CURRENT_DATE - 1").
+		Where("is_active = ?", true).
+		Where("restaurant_id IN (SELECT id FROM restaurants WHERE suspended_at IS NULL AND deleted_at IS NULL)").
+		Find(&templates).Error
+	return templates, err
+}
+
+// withOverrides preloads overrides that have not passed yet
+func (r *listingTemplateRepository) withOverrides(db *gorm.DB) *gorm.DB {
+	return db.Preload("Overrides", func(db *gorm.DB) *gorm.DB {
+		// One day of slack covers timezones ahead of the database clock
+		return db.Where("date >= CURRENT_DATE - 1").Order("date ASC")
+	})
+}
@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MembershipRepository interface defines restaurant member and invitation data access methods
+type MembershipRepository interface {
+	FindMember(restaurantID, userID uuid.UUID) (*models.RestaurantMember, error)
+	FindMembers(restaurantID uuid.UUID) ([]models.RestaurantMember, error)
+	UpdateMemberRole(restaurantID, userID uuid.UUID, role models.MemberRole) error
+	RemoveMember(restaurantID, userID uuid.UUID) error
+	CreateInvitation(invitation *models.RestaurantInvitation) error
+	FindInvitationByID(id uuid.UUID) (*models.RestaurantInvitation, error)
+	FindInvitationsByRestaurant(restaurantID uuid.UUID) ([]models.RestaurantInvitation, error)
+	FindPendingInvitationsByEmail(email string) ([]models.RestaurantInvitation, error)
+	HasPendingInvitation(restaurantID uuid.UUID, email string) (bool, error)
+	UpdateInvitationStatus(invitation *models.RestaurantInvitation, from models.InvitationStatus) error
+	AcceptInvitation(invitation *models.RestaurantInvitation, member *models.RestaurantMember) error
+}
+
+// membershipRepository implements MembershipRepository
+type membershipRepository struct {
+	db *gorm.DB
+}
+
+// NewMembershipRepository creates a new membership repository
+func NewMembershipRepository(db *gorm.DB) MembershipRepository {
+	return &membershipRepository{db: db}
+}
+
+// FindMember finds a user's membership in a restaurant
+func (r *membershipRepository) FindMember(restaurantID, userID uuid.UUID) (*models.RestaurantMember, error) {
+	var member models.RestaurantMember
+	err := r.db.Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).First(&member).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// FindMembers finds all members of a restaurant with users preloaded
+func (r *membershipRepository) FindMembers(restaurantID uuid.UUID) ([]models.RestaurantMember, error) {
+	var members []models.RestaurantMember
+	err := r.db.Preload("User").
+		Where("restaurant_id = ?", restaurantID).
+		Order("created_at ASC").
+		Find(&members).Error
+	return members, err
+}
+
+// UpdateMemberRole changes a member's role
+func (r *membershipRepository) UpdateMemberRole(restaurantID, userID uuid.UUID, role models.MemberRole) error {
+	result := r.db.Model(&models.RestaurantMember{}).
+		Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// RemoveMember removes a user from a restaurant
+func (r *membershipRepository) RemoveMember(restaurantID, userID uuid.UUID) error {
+	result := r.db.Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+		Delete(&models.RestaurantMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// CreateInvitation creates a new invitation
+func (r *membershipRepository) CreateInvitation(invitation *models.RestaurantInvitation) error {
+	return r.db.Create(invitation).Error
+}
+
+// FindInvitationByID finds an invitation by ID with restaurant preloaded
+func (r *membershipRepository) FindInvitationByID(id uuid.UUID) (*models.RestaurantInvitation, error) {
+	var invitation models.RestaurantInvitation
+	err := r.db.Preload("Restaurant").Where("id = ?", id).First(&invitation).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// FindInvitationsByRestaurant finds all invitations sent by a restaurant, newest first
+func (r *membershipRepository) FindInvitationsByRestaurant(restaurantID uuid.UUID) ([]models.RestaurantInvitation, error) {
+	var invitations []models.RestaurantInvitation
+	err := r.db.Where("restaurant_id = ?", restaurantID).Order("created_at DESC").Find(&invitations).Error
+	return invitations, err
+}
+
+// FindPendingInvitationsByEmail finds unexpired pending invitations addressed to an email
+func (r *membershipRepository) FindPendingInvitationsByEmail(email string) ([]models.RestaurantInvitation, error) {
+	var invitations []models.RestaurantInvitation
+	err := r.db.Preload("Restaurant").
+		Where("email = ? AND status = ? AND expires_at > ?", normalizeEmail(email), models.InvitationStatusPending, time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// HasPendingInvitation checks if an unexpired invitation is already outstanding for an email
+func (r *membershipRepository) HasPendingInvitation(restaurantID uuid.UUID, email string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.RestaurantInvitation{}).
+		Where("restaurant_id = ? AND email = ? AND status = ? AND expires_at > ?",
+			restaurantID, normalizeEmail(email), models.InvitationStatusPending, time.Now()).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateInvitationStatus persists a new invitation status if it is still in the expected status
+func (r *membershipRepository) UpdateInvitationStatus(invitation *models.RestaurantInvitation, from models.InvitationStatus) error {
+	return r.updateInvitationStatusWithTx(r.db, invitation, from)
+}
+
+// AcceptInvitation marks an invitation as accepted and adds the member atomically
+func (r *membershipRepository) AcceptInvitation(invitation *models.RestaurantInvitation, member *models.RestaurantMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.updateInvitationStatusWithTx(tx, invitation, models.InvitationStatusPending); err != nil {
+			return err
+		}
+
+		var count int64
+		err := tx.Model(&models.RestaurantMember{}).
+			Where("restaurant_id = ? AND user_id = ?", member.RestaurantID, member.UserID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return models.ErrDuplicateEntry
+		}
+
+		return tx.Create(member).Error
+	})
+}
+
+// updateInvitationStatusWithTx performs a conditional status update within a transaction
+func (r *membershipRepository) updateInvitationStatusWithTx(tx *gorm.DB, invitation *models.RestaurantInvitation, from models.InvitationStatus) error {
+	result := tx.Model(&models.RestaurantInvitation{}).
+		Where("id = ? AND status = ?", invitation.ID, from).
+		Updates(map[string]interface{}{
+			"status":       invitation.Status,
+			"responded_at": invitation.RespondedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrInvalidStatusTransition
+	}
+	return nil
+}
+
+// normalizeEmail lowercases and trims an email for comparison
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -1,69 +1,251 @@
 package repositories
 
 import (
+	"bytes"
+	"crypto/subtle"
+	"sort"
+	"time"
+
 	"eatright-backend/internal/app/models"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // OrderRepository interface defines order data access methods
 type OrderRepository interface {
-	Create(order *models.Order, listing *models.Listing) error
+	Create(order *models.Order, listings map[uuid.UUID]*models.Listing) error
+	Checkout(order *models.Order, listings map[uuid.UUID]*models.Listing) error
 	FindByID(id uuid.UUID) (*models.Order, error)
 	FindByUserID(userID uuid.UUID) ([]models.Order, error)
-	FindByRestaurantID(restaurantID uuid.UUID) ([]models.Order, error)
-	UpdateStatus(id uuid.UUID, status models.OrderStatus) error
+	FindByRestaurantID(restaurantID uuid.UUID, filter OrderFilter) ([]models.Order, int64, error)
+	Transition(id uuid.UUID, t models.OrderTransition) (*models.Order, error)
+	CompletePickup(id uuid.UUID, code string, actorID uuid.UUID, maxAttempts int, lockout time.Duration) error
+	MarkPaid(id uuid.UUID) (*models.Order, error)
+	ExpireUncollectedWithTx(tx *gorm.DB, cutoff time.Time, limit int) ([]models.Order, error)
+	ExpireUnpaidWithTx(tx *gorm.DB, cutoff time.Time, limit int) ([]models.Order, error)
+	SumSavingsByUser(userID uuid.UUID) (*models.SavingsSummary, error)
+	SumSavingsByRestaurant(restaurantID uuid.UUID) (*models.SavingsSummary, error)
+}
+
+// OrderFilter describes criteria for listing a restaurant's orders
+type OrderFilter struct {
+	Statuses   []models.OrderStatus
+	ListingID  *uuid.UUID
+	PickupDate *models.DateOnly // Pickup start date in the restaurant's timezone
+	Limit      int              // Zero for no limit
+	Offset     int
 }
 
 // orderRepository implements OrderRepository
 type orderRepository struct {
-	db          *gorm.DB
-	listingRepo ListingRepository
+	db              *gorm.DB
+	listingRepo     ListingRepository
+	reservationRepo ReservationRepository
+	refundRepo      RefundRepository
 }
 
 // NewOrderRepository creates a new order repository
-func NewOrderRepository(db *gorm.DB, listingRepo ListingRepository) OrderRepository {
+func NewOrderRepository(db *gorm.DB, listingRepo ListingRepository, reservationRepo ReservationRepository, refundRepo RefundRepository) OrderRepository {
 	return &orderRepository{
-		db:          db,
-		listingRepo: listingRepo,
+		db:              db,
+		listingRepo:     listingRepo,
+		reservationRepo: reservationRepo,
+		refundRepo:      refundRepo,
 	}
 }
 
-// Create creates a new order and decrements stock in a transaction
-func (r *orderRepository) Create(order *models.Order, listing *models.Listing) error {
+// Create creates a new order and decrements stock for every item in a
+// transaction. listings must hold each item's listing; their stock is updated.
+func (r *orderRepository) Create(order *models.Order, listings map[uuid.UUID]*models.Listing) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		return r.createWithTx(tx, order, listings)
+	})
+}
+
+// Checkout creates an order like Create and removes its items from the
+// customer's cart in the same transaction. It fails with ErrCartChanged if any
+// of those items was removed or its quantity changed in the meantime.
+func (r *orderRepository) Checkout(order *models.Order, listings map[uuid.UUID]*models.Listing) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the customer before their cart, as cart updates do, so that
+		// checkout and cart updates cannot deadlock
+		if err := lockUserWithTx(tx, order.UserID); err != nil {
+			return err
+		}
+
+		for _, item := range order.Items {
+			result := tx.Where("user_id = ? AND listing_id = ? AND qty = ?", order.UserID, item.ListingID, item.Qty).
+				Delete(&models.CartItem{})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return models.ErrCartChanged
+			}
+		}
+
+		return r.createWithTx(tx, order, listings)
+	})
+}
+
+// createWithTx decrements stock for every item, prices the items and creates
+// the order. Units the customer holds in active reservations are used first and
+// any surplus is returned to stock; the reservations are then converted.
+// Reservations are locked before listings, and listings in ID order, so that
+// concurrent orders sharing listings cannot deadlock; if any item is short the
+// whole order fails.
+func (r *orderRepository) createWithTx(tx *gorm.DB, order *models.Order, listings map[uuid.UUID]*models.Listing) error {
+	if len(order.Items) == 0 {
+		return models.ErrInvalidQuantity
+	}
+
+	if err := r.checkLimitsWithTx(tx, order, listings); err != nil {
+		return err
+	}
+
+	listingIDs := make([]uuid.UUID, len(order.Items))
+	for i, item := range order.Items {
+		listingIDs[i] = item.ListingID
+	}
+	held, err := r.reservationRepo.FindActiveForUpdateWithTx(tx, order.UserID, listingIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range byListingID(order.Items) {
 		// Validate quantity
-		if order.Qty <= 0 {
+		if item.Qty <= 0 {
 			return models.ErrInvalidQuantity
 		}
 
-		// Check stock availability and decrement (with row lock)
-		err := r.listingRepo.UpdateStockWithTx(tx, order.ListingID, -order.Qty)
+		// Take what is not already held from stock (with row lock)
+		delta := -item.Qty
+		if reservation, ok := held[item.ListingID]; ok {
+			delta += reservation.Qty
+		}
+		stock, err := r.listingRepo.UpdateStockWithTx(tx, item.ListingID, delta)
 		if err != nil {
 			if err == models.ErrNegativeStock {
 				return models.ErrInsufficientStock
 			}
 			return err
 		}
+		listings[item.ListingID].Stock = stock
+	}
+
+	// Charge the prices in effect now, after any markdown
+	order.PriceItems(listings, time.Now())
+
+	// Free orders have nothing to pay
+	if order.Status == models.OrderStatusAwaitingPayment && order.TotalPrice == 0 {
+		order.Status = models.OrderStatusPending
+	}
+
+	// Create the order, then its items
+	if err := tx.Omit(clause.Associations).Create(order).Error; err != nil {
+		return err
+	}
+	for i := range order.Items {
+		order.Items[i].OrderID = order.ID
+	}
+	if err := tx.Omit(clause.Associations).Create(&order.Items).Error; err != nil {
+		return err
+	}
+	for i := range order.Items {
+		order.Items[i].Listing = *listings[order.Items[i].ListingID]
+	}
 
-		// Calculate total price
-		order.TotalPrice = listing.Price * order.Qty
+	reservationIDs := make([]uuid.UUID, 0, len(held))
+	for _, reservation := range held {
+		reservationIDs = append(reservationIDs, reservation.ID)
+	}
+	if err := r.reservationRepo.ConvertWithTx(tx, reservationIDs, order.ID); err != nil {
+		return err
+	}
+
+	// Start the status timeline
+	return tx.Create(&models.OrderStatusEvent{
+		OrderID:  order.ID,
+		ToStatus: order.Status,
+		Actor:    models.OrderActorCustomer,
+		ActorID:  &order.UserID,
+	}).Error
+}
 
-		// Create the order
-		err = tx.Create(order).Error
+// checkLimitsWithTx enforces the listings' per-customer limits and the
+// restaurant's daily limit against the customer's non-cancelled orders. The
+// customer is locked first so that concurrent orders are counted one at a time.
+// order.Restaurant must be loaded.
+func (r *orderRepository) checkLimitsWithTx(tx *gorm.DB, order *models.Order, listings map[uuid.UUID]*models.Listing) error {
+	limited := order.Restaurant.DailyLimitPerCustomer != nil
+	for _, item := range order.Items {
+		limited = limited || listings[item.ListingID].MaxPerCustomer != nil
+	}
+	if !limited {
+		return nil
+	}
+
+	if err := lockUserWithTx(tx, order.UserID); err != nil {
+		return err
+	}
+
+	qty := 0
+	for _, item := range order.Items {
+		qty += item.Qty
+
+		listing := listings[item.ListingID]
+		if listing.MaxPerCustomer == nil {
+			continue
+		}
+		var ordered int
+		err := tx.Model(&models.OrderItem{}).
+			Select("COALESCE(SUM(order_items.qty), 0)").
+			Joins("JOIN orders ON orders.id = order_items.order_id").
+			Where("orders.user_id = ? AND order_items.listing_id = ? AND orders.status <> ?",
+				order.UserID, item.ListingID, models.OrderStatusCancelled).
+			Scan(&ordered).Error
 		if err != nil {
 			return err
 		}
+		if listing.ExceedsMaxPerCustomer(ordered, item.Qty) {
+			return models.ErrListingLimitReached
+		}
+	}
 
-		return nil
-	})
+	if limit := order.Restaurant.DailyLimitPerCustomer; limit != nil {
+		// Days are pickup dates in the restaurant's timezone
+		var ordered int
+		err := tx.Model(&models.Order{}).
+			Select("COALESCE(SUM(orders.qty), 0)").
+			Joins("JOIN restaurants ON restaurants.id = orders.restaurant_id").
+			Where("orders.user_id = ? AND orders.restaurant_id = ? AND orders.status <> ?",
+				order.UserID, order.RestaurantID, models.OrderStatusCancelled).
+			Where("(orders.pickup_start AT TIME ZONE restaurants.timezone)::date = (?::timestamptz AT TIME ZONE restaurants.timezone)::date",
+				order.PickupStart).
+			Scan(&ordered).Error
+		if err != nil {
+			return err
+		}
+		if ordered+qty > *limit {
+			return models.ErrDailyLimitReached
+		}
+	}
+	return nil
 }
 
 // FindByID finds an order by ID with related data preloaded
 func (r *orderRepository) FindByID(id uuid.UUID) (*models.Order, error) {
 	var order models.Order
-	err := r.db.Preload("User").Preload("Listing").Preload("Listing.Restaurant").Where("id = ?", id).First(&order).Error
+	err := r.db.Preload("User").Preload("Restaurant").Preload("Items.Listing").Preload("Payment").
+		Preload("Events", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Refunds", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("id = ?", id).First(&order).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, models.ErrNotFound
@@ -76,39 +258,343 @@ func (r *orderRepository) FindByID(id uuid.UUID) (*models.Order, error) {
 // FindByUserID finds all orders by user ID
 func (r *orderRepository) FindByUserID(userID uuid.UUID) ([]models.Order, error) {
 	var orders []models.Order
-	err := r.db.Preload("Listing").Preload("Listing.Restaurant").
+	err := r.db.Preload("Restaurant").Preload("Items.Listing").Preload("Payment").
 		Where("user_id = ?", userID).
 		Order("created_at DESC").
 		Find(&orders).Error
 	return orders, err
 }
 
-// FindByRestaurantID finds all orders for a restaurant
-func (r *orderRepository) FindByRestaurantID(restaurantID uuid.UUID) ([]models.Order, error) {
+// FindByRestaurantID finds a restaurant's orders matching the filter, sorted by
+// pickup time, and returns the total match count
+func (r *orderRepository) FindByRestaurantID(restaurantID uuid.UUID, filter OrderFilter) ([]models.Order, int64, error) {
+	query := r.db.Model(&models.Order{}).Where("orders.restaurant_id = ?", restaurantID)
+
+	if len(filter.Statuses) > 0 {
+		query = query.Where("orders.status IN ?", filter.Statuses)
+	}
+	if filter.ListingID != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM order_items WHERE order_items.order_id = orders.id AND order_items.listing_id = ?)",
+			*filter.ListingID)
+	}
+	if filter.PickupDate != nil {
+		query = query.Joins("JOIN restaurants ON restaurants.id = orders.restaurant_id").
+			Where("(orders.pickup_start AT TIME ZONE restaurants.timezone)::date = ?", *filter.PickupDate)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+
 	var orders []models.Order
-	err := r.db.Preload("User").Preload("Listing").
-		Joins("JOIN listings ON listings.id = orders.listing_id").
-		Where("listings.restaurant_id = ?", restaurantID).
-		Order("orders.created_at DESC").
+	err := query.Preload("User").Preload("Items.Listing").
+		Order("orders.pickup_start ASC, orders.created_at ASC").
 		Find(&orders).Error
-	return orders, err
+	return orders, total, err
 }
 
-// UpdateStatus updates the status of an order
-func (r *orderRepository) UpdateStatus(id uuid.UUID, status models.OrderStatus) error {
+// Transition moves an order to a new status, records it in the order's timeline
+// and returns the updated order with its items' listings
+func (r *orderRepository) Transition(id uuid.UUID, t models.OrderTransition) (*models.Order, error) {
 	var order models.Order
-	err := r.db.Where("id = ?", id).First(&order).Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the order so concurrent transitions are applied one at a time
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items.Listing").Where("id = ?", id).First(&order).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return models.ErrNotFound
+			}
+			return err
+		}
+
+		return r.transitionWithTx(tx, &order, t)
+	})
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// CompletePickup completes an order when code matches its pickup code. Pending
+// orders are marked ready first. Invalid codes are counted, and after
+// maxAttempts verification is locked for lockout.
+func (r *orderRepository) CompletePickup(id uuid.UUID, code string, actorID uuid.UUID, maxAttempts int, lockout time.Duration) error {
+	var result error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the order so concurrent attempts are counted one at a time
+		var order models.Order
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&order).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return models.ErrNotFound
+			}
+			return err
+		}
+
+		if !order.IsAwaitingPickup() {
+			return models.ErrInvalidStatusTransition
+		}
+
+		now := time.Now()
+		if order.PickupLockedUntil != nil && now.Before(*order.PickupLockedUntil) {
+			return models.ErrPickupLocked
+		}
+
+		if order.PickupCode == "" || subtle.ConstantTimeCompare([]byte(code), []byte(order.PickupCode)) != 1 {
+			// Commit the failed attempt but report the invalid code
+			result = models.ErrInvalidPickupCode
+			updates := map[string]interface{}{"pickup_attempts": order.PickupAttempts + 1}
+			if order.PickupAttempts+1 >= maxAttempts {
+				updates["pickup_attempts"] = 0
+				updates["pickup_locked_until"] = now.Add(lockout)
+			}
+			return tx.Model(&order).Updates(updates).Error
+		}
+
+		handover := models.OrderTransition{Actor: models.OrderActorRestaurant, ActorID: &actorID}
+		if order.Status == models.OrderStatusPending {
+			handover.To = models.OrderStatusReady
+			if err := r.transitionWithTx(tx, &order, handover); err != nil {
+				return err
+			}
+		}
+		handover.To = models.OrderStatusCompleted
+		if err := r.transitionWithTx(tx, &order, handover); err != nil {
+			return err
+		}
+
+		return tx.Model(&order).Updates(map[string]interface{}{
+			"pickup_attempts":     0,
+			"pickup_locked_until": nil,
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	return result
+}
+
+// MarkPaid records that an order's payment was captured and moves the order from
+// awaiting_payment to pending. Payments already recorded as captured are left
+// alone. If the order was cancelled meanwhile, the capture is still recorded, a
+// full refund is queued in order.Refunds and ErrInvalidStatusTransition is
+// returned. It returns the order with its items' listings and payment.
+func (r *orderRepository) MarkPaid(id uuid.UUID) (*models.Order, error) {
+	var order models.Order
+	var result error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the order so payment and cancellation are applied one at a time
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items.Listing").Preload("Payment").
+			Where("id = ?", id).First(&order).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return models.ErrNotFound
+			}
+			return err
+		}
+		if order.Payment == nil {
 			return models.ErrNotFound
 		}
+		if order.Payment.IsCaptured() || order.Payment.Status == models.PaymentStatusRefunded {
+			return nil
+		}
+
+		now := time.Now()
+		err = tx.Model(order.Payment).Updates(map[string]interface{}{
+			"status":         models.PaymentStatusCaptured,
+			"failure_reason": nil,
+			"captured_at":    now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if order.Status != models.OrderStatusAwaitingPayment {
+			// Commit the capture but report the order cannot take it
+			result = models.ErrInvalidStatusTransition
+			refund, err := r.refundRepo.QueueFullRefundWithTx(tx, order.ID, "Paid after the order was cancelled", models.OrderActorSystem, nil)
+			if err != nil || refund == nil {
+				return err
+			}
+			order.Refunds = append(order.Refunds, *refund)
+			return nil
+		}
+		return r.transitionWithTx(tx, &order, models.OrderTransition{
+			To:    models.OrderStatusPending,
+			Actor: models.OrderActorSystem,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &order, result
+}
+
+// ExpireUncollectedWithTx marks up to limit pending or ready orders whose pickup
+// window ended before cutoff as no-shows. Their stock is not restored, since
+// it can no longer be picked up. Locked orders are skipped. It returns the
+// closed orders with their items' listings.
+func (r *orderRepository) ExpireUncollectedWithTx(tx *gorm.DB, cutoff time.Time, limit int) ([]models.Order, error) {
+	var orders []models.Order
+	err := tx.Preload("Items.Listing").
+		Where("status IN ? AND pickup_end <= ?",
+			[]models.OrderStatus{models.OrderStatusPending, models.OrderStatusReady}, cutoff).
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Find(&orders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range orders {
+		t := models.OrderTransition{To: models.OrderStatusNoShow, Actor: models.OrderActorSystem}
+		if err := r.transitionWithTx(tx, &orders[i], t); err != nil {
+			return nil, err
+		}
+	}
+	return orders, nil
+}
+
+// ExpireUnpaidWithTx cancels up to limit orders still awaiting payment that
+// were placed before cutoff and restores their stock. Locked orders are skipped.
+// It returns the cancelled orders with their items' listings.
+func (r *orderRepository) ExpireUnpaidWithTx(tx *gorm.DB, cutoff time.Time, limit int) ([]models.Order, error) {
+	var orders []models.Order
+	err := tx.Preload("Items.Listing").
+		Where("status = ? AND created_at <= ?", models.OrderStatusAwaitingPayment, cutoff).
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Find(&orders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	reason := "Payment was not completed in time"
+	for i := range orders {
+		t := models.OrderTransition{To: models.OrderStatusCancelled, Actor: models.OrderActorSystem, Reason: &reason}
+		if err := r.transitionWithTx(tx, &orders[i], t); err != nil {
+			return nil, err
+		}
+	}
+	return orders, nil
+}
+
+// transitionWithTx applies a transition to a locked order: on cancellation it
+// restores stock (updating the stock of the items' listings), closes an unpaid
+// payment or queues a full refund of a paid one in order.Refunds; it sets the
+// completion or cancellation fields and records the event
+func (r *orderRepository) transitionWithTx(tx *gorm.DB, order *models.Order, t models.OrderTransition) error {
+	from := order.Status
+	if err := order.UpdateStatus(t.To, t.Actor); err != nil {
 		return err
 	}
 
-	// Validate status transition
-	if err := order.UpdateStatus(status); err != nil {
+	now := time.Now()
+	updates := map[string]interface{}{"status": t.To}
+	switch t.To {
+	case models.OrderStatusCompleted:
+		updates["completed_at"] = now
+	case models.OrderStatusCancelled:
+		if order.Items == nil {
+			if err := tx.Preload("Listing").Where("order_id = ?", order.ID).Find(&order.Items).Error; err != nil {
+				return err
+			}
+		}
+		for _, item := range byListingID(order.Items) {
+			stock, err := r.listingRepo.UpdateStockWithTx(tx, item.ListingID, item.Qty)
+			if err != nil {
+				return err
+			}
+			item.Listing.Stock = stock
+		}
+		updates["cancelled_at"] = now
+		updates["cancelled_by"] = t.ActorID
+		updates["cancellation_reason"] = t.Reason
+
+		if from == models.OrderStatusAwaitingPayment {
+			err := tx.Model(&models.Payment{}).
+				Where("order_id = ? AND status IN ?", order.ID,
+					[]models.PaymentStatus{models.PaymentStatusRequiresPayment, models.PaymentStatusFailed}).
+				Update("status", models.PaymentStatusCancelled).Error
+			if err != nil {
+				return err
+			}
+		} else {
+			refund, err := r.refundRepo.QueueFullRefundWithTx(tx, order.ID, "Order cancelled", t.Actor, t.ActorID)
+			if err != nil {
+				return err
+			}
+			if refund != nil {
+				order.Refunds = append(order.Refunds, *refund)
+			}
+		}
+	}
+
+	if err := tx.Model(order).Updates(updates).Error; err != nil {
 		return err
 	}
 
-	return r.db.Model(&order).Update("status", status).Error
+	return tx.Create(&models.OrderStatusEvent{
+		OrderID:    order.ID,
+		FromStatus: &from,
+		ToStatus:   t.To,
+		Actor:      t.Actor,
+		ActorID:    t.ActorID,
+		Reason:     t.Reason,
+		CreatedAt:  now,
+	}).Error
+}
+
+// SumSavingsByUser totals a user's completed orders and savings
+func (r *orderRepository) SumSavingsByUser(userID uuid.UUID) (*models.SavingsSummary, error) {
+	return r.sumSavings(r.db.Where("orders.user_id = ?", userID))
+}
+
+// SumSavingsByRestaurant totals a restaurant's completed orders and customer savings
+func (r *orderRepository) SumSavingsByRestaurant(restaurantID uuid.UUID) (*models.SavingsSummary, error) {
+	return r.sumSavings(r.db.Where("orders.restaurant_id = ?", restaurantID))
+}
+
+// sumSavings totals the completed orders matched by query
+func (r *orderRepository) sumSavings(query *gorm.DB) (*models.SavingsSummary, error) {
+	var summary models.SavingsSummary
+	err := query.Model(&models.Order{}).
+		Select("COUNT(*) AS orders_completed, "+
+			"COALESCE(SUM(orders.qty), 0) AS items_rescued, "+
+			"COALESCE(SUM(orders.total_price), 0) AS total_paid, "+
+			"COALESCE(SUM(orders.savings), 0) AS total_savings").
+		Where("orders.status = ?", models.OrderStatusCompleted).
+		Scan(&summary).Error
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// lockUserWithTx locks a customer's row for the rest of the transaction
+func lockUserWithTx(tx *gorm.DB, userID uuid.UUID) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").Where("id = ?", userID).First(&models.User{}).Error
+	if err == gorm.ErrRecordNotFound {
+		return models.ErrNotFound
+	}
+	return err
+}
+
+// byListingID returns the items sorted by listing ID, the order in which
+// listings are locked
+func byListingID(items []models.OrderItem) []*models.OrderItem {
+	sorted := make([]*models.OrderItem, len(items))
+	for i := range items {
+		sorted[i] = &items[i]
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].ListingID[:], sorted[j].ListingID[:]) < 0
+	})
+	return sorted
 }
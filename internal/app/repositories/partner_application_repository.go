@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// uniqueViolation is the Postgres error code for a unique constraint violation
+const uniqueViolation = "23505"
+
+// PartnerApplicationRepository interface defines partner application data access methods
+type PartnerApplicationRepository interface {
+	Create(application *models.PartnerApplication) error
+	FindByID(id uuid.UUID) (*models.PartnerApplication, error)
+	FindByUserID(userID uuid.UUID) ([]models.PartnerApplication, error)
+	FindAll(status models.PartnerApplicationStatus) ([]models.PartnerApplication, error)
+	HasPending(userID uuid.UUID) (bool, error)
+	UpdateStatus(application *models.PartnerApplication, from models.PartnerApplicationStatus) error
+	Approve(application *models.PartnerApplication, accessTTL time.Duration) error
+}
+
+// partnerApplicationRepository implements PartnerApplicationRepository
+type partnerApplicationRepository struct {
+	db        *gorm.DB
+	tokenRepo TokenRepository
+}
+
+// NewPartnerApplicationRepository creates a new partner application repository
+func NewPartnerApplicationRepository(db *gorm.DB, tokenRepo TokenRepository) PartnerApplicationRepository {
+	return &partnerApplicationRepository{db: db, tokenRepo: tokenRepo}
+}
+
+// Create creates a new partner application. Returns ErrDuplicateEntry if the
+// applicant already has a pending application.
+func (r *partnerApplicationRepository) Create(application *models.PartnerApplication) error {
+	err := r.db.Create(application).Error
+	if isUniqueViolation(err) {
+		// Lost a race with a concurrent submission
+		return models.ErrDuplicateEntry
+	}
+	return err
+}
+
+// FindByID finds a partner application by ID with applicant preloaded
+func (r *partnerApplicationRepository) FindByID(id uuid.UUID) (*models.PartnerApplication, error) {
+	var application models.PartnerApplication
+	err := r.db.Preload("User").Where("id = ?", id).First(&application).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+	return &application, nil
+}
+
+// FindByUserID finds all applications submitted by a user, newest first
+func (r *partnerApplicationRepository) FindByUserID(userID uuid.UUID) ([]models.PartnerApplication, error) {
+	var applications []models.PartnerApplication
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&applications).Error
+	return applications, err
+}
+
+// FindAll finds applications, optionally filtered by status, oldest first
+func (r *partnerApplicationRepository) FindAll(status models.PartnerApplicationStatus) ([]models.PartnerApplication, error) {
+	var applications []models.PartnerApplication
+	query := r.db.Preload("User")
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	err := query.Order("created_at ASC").Find(&applications).Error
+	return applications, err
+}
+
+// HasPending checks if a user already has an application awaiting review
+func (r *partnerApplicationRepository) HasPending(userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.PartnerApplication{}).
+		Where("user_id = ? AND status = ?", userID, models.ApplicationStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateStatus persists the application's new status and review fields
+// only if it is still in the expected previous status
+func (r *partnerApplicationRepository) UpdateStatus(application *models.PartnerApplication, from models.PartnerApplicationStatus) error {
+	return r.updateStatusWithTx(r.db, application, from)
+}
+
+// Approve marks a pending application as approved, upgrades the applicant to
+// restaurant role and revokes their access tokens issued within accessTTL, so
+// that no token carrying the old role outlives the change
+func (r *partnerApplicationRepository) Approve(application *models.PartnerApplication, accessTTL time.Duration) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.updateStatusWithTx(tx, application, models.ApplicationStatusPending); err != nil {
+			return err
+		}
+
+		// Never downgrade admins
+		err := tx.Model(&models.User{}).
+			Where("id = ? AND role = ?", application.UserID, models.RoleUser).
+			Update("role", models.RoleRestaurant).Error
+		if err != nil {
+			return err
+		}
+
+		return r.tokenRepo.RevokeAccessTokensForUserWithTx(tx, application.UserID, accessTTL)
+	})
+}
+
+// updateStatusWithTx performs a conditional status update within a transaction
+func (r *partnerApplicationRepository) updateStatusWithTx(tx *gorm.DB, application *models.PartnerApplication, from models.PartnerApplicationStatus) error {
+	result := tx.Model(&models.PartnerApplication{}).
+		Where("id = ? AND status = ?", application.ID, from).
+		Updates(map[string]interface{}{
+			"status":      application.Status,
+			"review_note": application.ReviewNote,
+			"reviewed_by": application.ReviewedBy,
+			"reviewed_at": application.ReviewedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// Someone else reviewed or withdrew the application concurrently
+	if result.RowsAffected == 0 {
+		return models.ErrInvalidStatusTransition
+	}
+
+	return nil
+}
+
+// isUniqueViolation reports whether err is a Postgres unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PaymentRepository interface defines payment data access methods
+type PaymentRepository interface {
+	Create(payment *models.Payment) error
+	FindByIntentID(provider, intentID string) (*models.Payment, error)
+	MarkFailed(id uuid.UUID, reason string) error
+}
+
+// paymentRepository implements PaymentRepository
+type paymentRepository struct {
+	db *gorm.DB
+}
+
+// NewPaymentRepository creates a new payment repository
+func NewPaymentRepository(db *gorm.DB) PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+// Create creates a new payment
+func (r *paymentRepository) Create(payment *models.Payment) error {
+	return r.db.Omit(clause.Associations).Create(payment).Error
+}
+
+// FindByIntentID finds a payment by its provider's intent ID
+func (r *paymentRepository) FindByIntentID(provider, intentID string) (*models.Payment, error) {
+	var payment models.Payment
+	err := r.db.Where("provider = ? AND provider_intent_id = ?", provider, intentID).First(&payment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// MarkFailed records a failed payment attempt; payments that are no longer
+// open are left alone
+func (r *paymentRepository) MarkFailed(id uuid.UUID, reason string) error {
+	return r.db.Model(&models.Payment{}).
+		Where("id = ? AND status IN ?", id,
+			[]models.PaymentStatus{models.PaymentStatusRequiresPayment, models.PaymentStatusFailed}).
+		Updates(map[string]interface{}{
+			"status":         models.PaymentStatusFailed,
+			"failure_reason": reason,
+		}).Error
+}
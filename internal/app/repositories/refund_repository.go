@@ -0,0 +1,383 @@
+package repositories
+
+import (
+	"time"
+
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RefundRepository interface defines refund data access methods
+type RefundRepository interface {
+	Create(refund *models.Refund) error
+	FindByID(id uuid.UUID) (*models.Refund, error)
+	FindAll(filter RefundFilter) ([]models.Refund, int64, error)
+	Decide(id uuid.UUID, d RefundDecision) (*models.Refund, error)
+	MarkSucceeded(id uuid.UUID, providerRefundID string) (*models.Refund, error)
+	MarkPending(id uuid.UUID, providerRefundID string) error
+	MarkFailed(id uuid.UUID, reason string) error
+	Retry(id uuid.UUID) (*models.Refund, error)
+	QueueFullRefundWithTx(tx *gorm.DB, orderID uuid.UUID, reason string, actor models.OrderActor, actorID *uuid.UUID) (*models.Refund, error)
+	FindExecutableWithTx(tx *gorm.DB, cutoff time.Time, maxAttempts, limit int) ([]models.Refund, error)
+}
+
+// RefundFilter describes criteria for listing refunds
+type RefundFilter struct {
+	RestaurantID *uuid.UUID
+	Statuses     []models.RefundStatus
+	Limit        int // Zero for no limit
+	Offset       int
+}
+
+// RefundDecision approves or rejects a refund
+type RefundDecision struct {
+	From      []models.RefundStatus // Statuses the refund may be decided from
+	To        models.RefundStatus   // Approved or rejected
+	DeciderID uuid.UUID
+	Note      *string
+}
+
+// committedRefundStatuses lists the statuses whose amounts are spoken for
+// against the payment
+var committedRefundStatuses = []models.RefundStatus{
+	models.RefundStatusApproved,
+	models.RefundStatusFailed,
+	models.RefundStatusSucceeded,
+}
+
+// refundRepository implements RefundRepository
+type refundRepository struct {
+	db *gorm.DB
+}
+
+// NewRefundRepository creates a new refund repository
+func NewRefundRepository(db *gorm.DB) RefundRepository {
+	return &refundRepository{db: db}
+}
+
+// Create creates a refund against its payment, which must be captured. The
+// amount must fit in what is left of the payment after the refunds already
+// approved, and an order has at most one refund waiting for a decision.
+func (r *refundRepository) Create(refund *models.Refund) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the payment so concurrent refunds are checked one at a time
+		payment, err := r.lockPaymentWithTx(tx, "id = ?", refund.PaymentID)
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return models.ErrNotRefundable
+		}
+
+		if refund.Status == models.RefundStatusRequested {
+			var open int64
+			err := tx.Model(&models.Refund{}).
+				Where("order_id = ? AND status = ?", refund.OrderID, models.RefundStatusRequested).
+				Count(&open).Error
+			if err != nil {
+				return err
+			}
+			if open > 0 {
+				return models.ErrRefundAlreadyRequested
+			}
+		}
+
+		remaining, err := r.remainingWithTx(tx, payment)
+		if err != nil {
+			return err
+		}
+		if refund.Amount > remaining {
+			return models.ErrRefundExceedsPayment
+		}
+
+		return tx.Omit(clause.Associations).Create(refund).Error
+	})
+}
+
+// FindByID finds a refund by ID with its payment preloaded
+func (r *refundRepository) FindByID(id uuid.UUID) (*models.Refund, error) {
+	var refund models.Refund
+	err := r.db.Preload("Payment").Where("id = ?", id).First(&refund).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+	return &refund, nil
+}
+
+// FindAll finds refunds matching the filter, newest first, and returns the
+// total match count
+func (r *refundRepository) FindAll(filter RefundFilter) ([]models.Refund, int64, error) {
+	query := r.db.Model(&models.Refund{})
+
+	if filter.RestaurantID != nil {
+		query = query.Joins("JOIN orders ON orders.id = refunds.order_id").
+			Where("orders.restaurant_id = ?", *filter.RestaurantID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("refunds.status IN ?", filter.Statuses)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+
+	var refunds []models.Refund
+	err := query.Order("refunds.created_at DESC").Find(&refunds).Error
+	return refunds, total, err
+}
+
+// Decide approves or rejects a refund in one of d.From. Approved amounts must
+// still fit in what is left of the payment.
+func (r *refundRepository) Decide(id uuid.UUID, d RefundDecision) (*models.Refund, error) {
+	var refund models.Refund
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&refund).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return models.ErrNotFound
+			}
+			return err
+		}
+		if !containsRefundStatus(d.From, refund.Status) {
+			return models.ErrInvalidStatusTransition
+		}
+
+		if d.To == models.RefundStatusApproved {
+			payment, err := r.lockPaymentWithTx(tx, "id = ?", refund.PaymentID)
+			if err != nil {
+				return err
+			}
+			if payment == nil {
+				return models.ErrNotRefundable
+			}
+			remaining, err := r.remainingWithTx(tx, payment)
+			if err != nil {
+				return err
+			}
+			if refund.Amount > remaining {
+				return models.ErrRefundExceedsPayment
+			}
+		}
+
+		now := time.Now()
+		refund.Status = d.To
+		refund.DecidedBy = &d.DeciderID
+		refund.DecidedAt = &now
+		refund.DecisionNote = d.Note
+		return tx.Model(&refund).Updates(map[string]interface{}{
+			"status":        refund.Status,
+			"decided_by":    refund.DecidedBy,
+			"decided_at":    refund.DecidedAt,
+			"decision_note": refund.DecisionNote,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+// MarkSucceeded records that the provider returned a refund and adds its amount
+// to the payment's and the order's refunded amounts. Refunds already recorded
+// are left alone, so the amounts are only added once.
+func (r *refundRepository) MarkSucceeded(id uuid.UUID, providerRefundID string) (*models.Refund, error) {
+	var refund models.Refund
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&refund).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return models.ErrNotFound
+			}
+			return err
+		}
+		if !refund.IsExecutable() {
+			return nil
+		}
+
+		now := time.Now()
+		refund.Status = models.RefundStatusSucceeded
+		refund.ProviderRefundID = &providerRefundID
+		refund.Attempts++
+		refund.FailureReason = nil
+		refund.RefundedAt = &now
+		err = tx.Model(&refund).Updates(map[string]interface{}{
+			"status":             refund.Status,
+			"provider_refund_id": refund.ProviderRefundID,
+			"attempts":           refund.Attempts,
+			"failure_reason":     nil,
+			"refunded_at":        refund.RefundedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.Payment{}).Where("id = ?", refund.PaymentID).Updates(map[string]interface{}{
+			"refunded_amount": gorm.Expr("refunded_amount + ?", refund.Amount),
+			"status": gorm.Expr("CASE WHEN refunded_amount + ? >= amount THEN ? ELSE ? END",
+				refund.Amount, models.PaymentStatusRefunded, models.PaymentStatusPartiallyRefunded),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Order{}).Where("id = ?", refund.OrderID).
+			Update("refunded_amount", gorm.Expr("refunded_amount + ?", refund.Amount)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+// MarkPending records an attempt the provider accepted but has not settled yet.
+// The refund stays approved and is checked again until the provider settles it;
+// waiting does not count as a failed attempt.
+func (r *refundRepository) MarkPending(id uuid.UUID, providerRefundID string) error {
+	return r.db.Model(&models.Refund{}).
+		Where("id = ? AND status IN ?", id,
+			[]models.RefundStatus{models.RefundStatusApproved, models.RefundStatusFailed}).
+		Updates(map[string]interface{}{
+			"status":             models.RefundStatusApproved,
+			"provider_refund_id": providerRefundID,
+			"failure_reason":     nil,
+		}).Error
+}
+
+// MarkFailed records a failed attempt; refunds that already succeeded are left alone
+func (r *refundRepository) MarkFailed(id uuid.UUID, reason string) error {
+	return r.db.Model(&models.Refund{}).
+		Where("id = ? AND status IN ?", id,
+			[]models.RefundStatus{models.RefundStatusApproved, models.RefundStatusFailed}).
+		Updates(map[string]interface{}{
+			"status":         models.RefundStatusFailed,
+			"failure_reason": reason,
+			"attempts":       gorm.Expr("attempts + 1"),
+		}).Error
+}
+
+// Retry queues an approved or failed refund again with its attempts reset, e.g.
+// once an admin has fixed what made it fail. It returns the refund with its
+// payment preloaded.
+func (r *refundRepository) Retry(id uuid.UUID) (*models.Refund, error) {
+	result := r.db.Model(&models.Refund{}).
+		Where("id = ? AND status IN ?", id,
+			[]models.RefundStatus{models.RefundStatusApproved, models.RefundStatusFailed}).
+		Updates(map[string]interface{}{
+			"status":         models.RefundStatusApproved,
+			"attempts":       0,
+			"failure_reason": nil,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	refund, err := r.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected == 0 {
+		return nil, models.ErrInvalidStatusTransition
+	}
+	return refund, nil
+}
+
+// QueueFullRefundWithTx approves a refund of whatever is left of an order's
+// captured payment. It returns nil when the order has nothing left to refund.
+func (r *refundRepository) QueueFullRefundWithTx(tx *gorm.DB, orderID uuid.UUID, reason string, actor models.OrderActor, actorID *uuid.UUID) (*models.Refund, error) {
+	payment, err := r.lockPaymentWithTx(tx, "order_id = ?", orderID)
+	if err != nil || payment == nil {
+		return nil, err
+	}
+
+	remaining, err := r.remainingWithTx(tx, payment)
+	if err != nil || remaining <= 0 {
+		return nil, err
+	}
+
+	now := time.Now()
+	refund := &models.Refund{
+		OrderID:     orderID,
+		PaymentID:   payment.ID,
+		Amount:      remaining,
+		Reason:      reason,
+		Status:      models.RefundStatusApproved,
+		RequestedBy: actor,
+		RequesterID: actorID,
+		DecidedBy:   actorID,
+		DecidedAt:   &now,
+		Payment:     payment,
+	}
+	if err := tx.Omit(clause.Associations).Create(refund).Error; err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+// FindExecutableWithTx finds up to limit approved or failed refunds, with their
+// payments, last touched before cutoff and tried fewer than maxAttempts times.
+// Refunds pending at the provider are found regardless of their attempts, so
+// they are checked until settled. Rows are not locked: executions are
+// idempotent at the provider and recorded only once by MarkSucceeded.
+func (r *refundRepository) FindExecutableWithTx(tx *gorm.DB, cutoff time.Time, maxAttempts, limit int) ([]models.Refund, error) {
+	var refunds []models.Refund
+	err := tx.Preload("Payment").
+		Where("status IN ? AND updated_at <= ?",
+			[]models.RefundStatus{models.RefundStatusApproved, models.RefundStatusFailed}, cutoff).
+		Where("attempts < ? OR (status = ? AND provider_refund_id IS NOT NULL)", maxAttempts, models.RefundStatusApproved).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&refunds).Error
+	return refunds, err
+}
+
+// lockPaymentWithTx locks the captured payment matching the condition; it
+// returns nil when there is none
+func (r *refundRepository) lockPaymentWithTx(tx *gorm.DB, query string, arg interface{}) (*models.Payment, error) {
+	var payment models.Payment
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(query, arg).
+		Where("status IN ?", []models.PaymentStatus{models.PaymentStatusCaptured, models.PaymentStatusPartiallyRefunded}).
+		First(&payment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// remainingWithTx returns what is left of a locked payment after its approved,
+// failed and succeeded refunds
+func (r *refundRepository) remainingWithTx(tx *gorm.DB, payment *models.Payment) (int, error) {
+	var committed int
+	err := tx.Model(&models.Refund{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("payment_id = ? AND status IN ?", payment.ID, committedRefundStatuses).
+		Scan(&committed).Error
+	if err != nil {
+		return 0, err
+	}
+	return payment.Amount - committed, nil
+}
+
+// containsRefundStatus checks if status is one of statuses
+func containsRefundStatus(statuses []models.RefundStatus, status models.RefundStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+package repositories
+
+import (
+	"time"
+
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReservationRepository interface defines stock reservation data access methods
+type ReservationRepository interface {
+	Reserve(reservation *models.StockReservation, maxActive int) (int, error)
+	ReserveWithTx(tx *gorm.DB, reservation *models.StockReservation, maxActive int) (int, error)
+	Release(id, userID uuid.UUID) (*models.StockReservation, error)
+	FindActiveByUserID(userID uuid.UUID) ([]models.StockReservation, error)
+	FindActiveForUpdateWithTx(tx *gorm.DB, userID uuid.UUID, listingIDs []uuid.UUID) (map[uuid.UUID]*models.StockReservation, error)
+	ConvertWithTx(tx *gorm.DB, ids []uuid.UUID, orderID uuid.UUID) error
+	ExpireWithTx(tx *gorm.DB, now time.Time, limit int) ([]models.StockReservation, error)
+}
+
+// reservationRepository implements ReservationRepository
+type reservationRepository struct {
+	db          *gorm.DB
+	listingRepo ListingRepository
+}
+
+// NewReservationRepository creates a new reservation repository
+func NewReservationRepository(db *gorm.DB, listingRepo ListingRepository) ReservationRepository {
+	return &reservationRepository{
+		db:          db,
+		listingRepo: listingRepo,
+	}
+}
+
+// Reserve holds units of a listing for a customer and returns the listing's new
+// stock. If the customer already holds the listing, that reservation is
+// replaced: its quantity is updated, only the difference is taken from stock
+// and it keeps its original expiry. Otherwise a new reservation is made, unless
+// the customer already holds maxActive others. reservation.ID and ExpiresAt are
+// set to those of the reservation that holds the units.
+func (r *reservationRepository) Reserve(reservation *models.StockReservation, maxActive int) (int, error) {
+	var stock int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		stock, err = r.ReserveWithTx(tx, reservation, maxActive)
+		return err
+	})
+	return stock, err
+}
+
+// ReserveWithTx reserves like Reserve within a transaction
+func (r *reservationRepository) ReserveWithTx(tx *gorm.DB, reservation *models.StockReservation, maxActive int) (int, error) {
+	// Lock the user so concurrent reservations are made one at a time
+	if err := lockUserWithTx(tx, reservation.UserID); err != nil {
+		return 0, err
+	}
+
+	held, err := r.FindActiveForUpdateWithTx(tx, reservation.UserID, []uuid.UUID{reservation.ListingID})
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	existing := held[reservation.ListingID]
+	if existing != nil && !existing.ExpiresAt.After(now) {
+		// Ran out but not swept yet: expire it as the sweeper would and start afresh
+		if err := r.restoreWithTx(tx, []*models.StockReservation{existing}, models.ReservationStatusExpired); err != nil {
+			return 0, err
+		}
+		existing = nil
+	}
+
+	delta := -reservation.Qty
+	if existing != nil {
+		delta += existing.Qty
+	} else {
+		var active int64
+		err := tx.Model(&models.StockReservation{}).
+			Where("user_id = ? AND status = ? AND expires_at > ?", reservation.UserID, models.ReservationStatusActive, now).
+			Count(&active).Error
+		if err != nil {
+			return 0, err
+		}
+		if active >= int64(maxActive) {
+			return 0, models.ErrReservationLimitReached
+		}
+	}
+
+	stock, err := r.listingRepo.UpdateStockWithTx(tx, reservation.ListingID, delta)
+	if err != nil {
+		if err == models.ErrNegativeStock {
+			return 0, models.ErrInsufficientStock
+		}
+		return 0, err
+	}
+
+	if existing == nil {
+		return stock, tx.Omit(clause.Associations).Create(reservation).Error
+	}
+
+	// Replacing a reservation does not extend the hold
+	reservation.ID = existing.ID
+	reservation.Status = existing.Status
+	reservation.ExpiresAt = existing.ExpiresAt
+	reservation.CreatedAt = existing.CreatedAt
+	return stock, tx.Model(existing).Update("qty", reservation.Qty).Error
+}
+
+// Release gives up a customer's active reservation and returns its units to
+// stock. It returns the released reservation with its listing's new stock.
+func (r *reservationRepository) Release(id, userID uuid.UUID) (*models.StockReservation, error) {
+	var reservation models.StockReservation
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the reservation so it is not converted or expired meanwhile
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&reservation).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return models.ErrNotFound
+			}
+			return err
+		}
+
+		if reservation.UserID != userID {
+			return models.ErrUnauthorized
+		}
+		if reservation.Status != models.ReservationStatusActive {
+			return models.ErrReservationNotActive
+		}
+
+		return r.restoreWithTx(tx, []*models.StockReservation{&reservation}, models.ReservationStatusReleased)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// FindActiveByUserID finds a customer's active reservations with listings and
+// restaurants preloaded, soonest expiry first
+func (r *reservationRepository) FindActiveByUserID(userID uuid.UUID) ([]models.StockReservation, error) {
+	var reservations []models.StockReservation
+	err := r.db.Preload("Listing").Preload("Listing.Restaurant").
+		Where("user_id = ? AND status = ?", userID, models.ReservationStatusActive).
+		Order("expires_at ASC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// FindActiveForUpdateWithTx locks a customer's active reservations of the given
+// listings and returns them by listing ID. Reservations that have run out but
+// not been swept yet still hold their units and are included.
+func (r *reservationRepository) FindActiveForUpdateWithTx(tx *gorm.DB, userID uuid.UUID, listingIDs []uuid.UUID) (map[uuid.UUID]*models.StockReservation, error) {
+	var reservations []models.StockReservation
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND listing_id IN ? AND status = ?", userID, listingIDs, models.ReservationStatusActive).
+		Find(&reservations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[uuid.UUID]*models.StockReservation, len(reservations))
+	for i := range reservations {
+		held[reservations[i].ListingID] = &reservations[i]
+	}
+	return held, nil
+}
+
+// ConvertWithTx marks locked reservations as used by an order. Their units
+// have already been accounted for by the order.
+func (r *reservationRepository) ConvertWithTx(tx *gorm.DB, ids []uuid.UUID, orderID uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.Model(&models.StockReservation{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"status":   models.ReservationStatusConverted,
+		"order_id": orderID,
+	}).Error
+}
+
+// ExpireWithTx expires up to limit active reservations that ran out before now
+// and returns their units to stock. Reservations locked by a checkout are
+// skipped. It returns the expired reservations with their listings' new stock.
+func (r *reservationRepository) ExpireWithTx(tx *gorm.DB, now time.Time, limit int) ([]models.StockReservation, error) {
+	var reservations []models.StockReservation
+	err := tx.Where("status = ? AND expires_at <= ?", models.ReservationStatusActive, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Find(&reservations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]*models.StockReservation, len(reservations))
+	for i := range reservations {
+		claimed[i] = &reservations[i]
+	}
+	if err := r.restoreWithTx(tx, claimed, models.ReservationStatusExpired); err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// restoreWithTx returns locked reservations' units to stock and closes them
+// with status. Listings are locked in ID order, like orders do, and loaded
+// into the reservations with their new stock.
+func (r *reservationRepository) restoreWithTx(tx *gorm.DB, reservations []*models.StockReservation, status models.ReservationStatus) error {
+	if len(reservations) == 0 {
+		return nil
+	}
+
+	items := make([]models.OrderItem, len(reservations))
+	for i, reservation := range reservations {
+		items[i] = models.OrderItem{ListingID: reservation.ListingID, Qty: reservation.Qty}
+	}
+	for _, item := range byListingID(items) {
+		if _, err := r.listingRepo.UpdateStockWithTx(tx, item.ListingID, item.Qty); err != nil {
+			return err
+		}
+	}
+
+	ids := make([]uuid.UUID, len(reservations))
+	for i, reservation := range reservations {
+		ids[i] = reservation.ID
+	}
+	if err := tx.Model(&models.StockReservation{}).Where("id IN ?", ids).Update("status", status).Error; err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		reservation.Status = status
+		if err := tx.Where("id = ?", reservation.ListingID).First(&reservation.Listing).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,10 +1,13 @@
 package repositories
 
 import (
+	"time"
+
 	"eatright-backend/internal/app/models"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // RestaurantRepository interface defines restaurant data access methods
@@ -15,6 +18,11 @@ type RestaurantRepository interface {
 	FindByOwnerID(ownerID uuid.UUID) ([]models.Restaurant, error)
 	FindNearby(lat, lng, maxDistance float64) ([]models.Restaurant, error)
 	Update(restaurant *models.Restaurant) error
+	Delete(id uuid.UUID) error
+	SetSuspended(id uuid.UUID, suspendedAt *time.Time, reason *string) error
+	ReplaceOpeningHours(restaurantID uuid.UUID, hours []models.OpeningHours) error
+	CreateClosure(closure *models.RestaurantClosure) error
+	DeleteClosure(restaurantID, closureID uuid.UUID) error
 }
 
 // restaurantRepository implements RestaurantRepository
@@ -27,15 +35,26 @@ func NewRestaurantRepository(db *gorm.DB) RestaurantRepository {
 	return &restaurantRepository{db: db}
 }
 
-// Create creates a new restaurant
+// Create creates a new restaurant and registers its owner as a member
 func (r *restaurantRepository) Create(restaurant *models.Restaurant) error {
-	return r.db.Create(restaurant).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(restaurant).Error; err != nil {
+			return err
+		}
+
+		owner := &models.RestaurantMember{
+			RestaurantID: restaurant.ID,
+			UserID:       restaurant.OwnerID,
+			Role:         models.MemberRoleOwner,
+		}
+		return tx.Create(owner).Error
+	})
 }
 
 // FindByID finds a restaurant by ID with owner and listings preloaded
 func (r *restaurantRepository) FindByID(id uuid.UUID) (*models.Restaurant, error) {
 	var restaurant models.Restaurant
-	err := r.db.Preload("Owner").Preload("Listings").Where("id = ?", id).First(&restaurant).Error
+	err := r.withSchedule(r.db).Preload("Owner").Preload("Listings").Where("id = ?", id).First(&restaurant).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, models.ErrNotFound
@@ -45,17 +64,17 @@ func (r *restaurantRepository) FindByID(id uuid.UUID) (*models.Restaurant, error
 	return &restaurant, nil
 }
 
-// FindAll finds all restaurants
+// FindAll finds all restaurants that are not suspended
 func (r *restaurantRepository) FindAll() ([]models.Restaurant, error) {
 	var restaurants []models.Restaurant
-	err := r.db.Preload("Owner").Find(&restaurants).Error
+	err := r.withSchedule(r.db).Preload("Owner").Where("suspended_at IS NULL").Find(&restaurants).Error
 	return restaurants, err
 }
 
 // FindByOwnerID finds restaurants by owner ID
 func (r *restaurantRepository) FindByOwnerID(ownerID uuid.UUID) ([]models.Restaurant, error) {
 	var restaurants []models.Restaurant
-	err := r.db.Where("owner_id = ?", ownerID).Find(&restaurants).Error
+	err := r.withSchedule(r.db).Where("owner_id = ?", ownerID).Find(&restaurants).Error
 	return restaurants, err
 }
 
@@ -65,11 +84,111 @@ func (r *restaurantRepository) FindNearby(lat, lng, maxDistance float64) ([]mode
 	var restaurants []models.Restaurant
 	// For now, return all restaurants. The service layer will calculate distances
 	// In production, use PostGIS for efficient geospatial queries
-	err := r.db.Preload("Owner").Find(&restaurants).Error
+	err := r.withSchedule(r.db).Preload("Owner").Where("suspended_at IS NULL").Find(&restaurants).Error
 	return restaurants, err
 }
 
-// Update updates a restaurant
+// Update updates a restaurant's own columns, leaving preloaded associations untouched
 func (r *restaurantRepository) Update(restaurant *models.Restaurant) error {
-	return r.db.Save(restaurant).Error
+	return r.db.Omit(clause.Associations).Save(restaurant).Error
+}
+
+// Delete soft-deletes a restaurant and deactivates its listings, refusing if
+// any order is still waiting for its payment or to be picked up
+func (r *restaurantRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var restaurant models.Restaurant
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&restaurant).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return models.ErrNotFound
+			}
+			return err
+		}
+
+		// Deactivate first so the listing row locks serialize with order creation
+		err = tx.Model(&models.Listing{}).
+			Where("restaurant_id = ? AND is_active = ?", id, true).
+			Updates(map[string]interface{}{
+				"is_active":           false,
+				"deactivated_at":      time.Now(),
+				"deactivation_reason": models.DeactivationRestaurantDeleted,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		var pending int64
+		err = tx.Model(&models.Order{}).
+			Where("restaurant_id = ? AND status IN ?", id,
+				[]models.OrderStatus{models.OrderStatusAwaitingPayment, models.OrderStatusPending, models.OrderStatusReady}).
+			Count(&pending).Error
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			return models.ErrHasPendingOrders
+		}
+
+		return tx.Delete(&restaurant).Error
+	})
+}
+
+// SetSuspended suspends (non-nil suspendedAt) or reinstates a restaurant
+func (r *restaurantRepository) SetSuspended(id uuid.UUID, suspendedAt *time.Time, reason *string) error {
+	result := r.db.Model(&models.Restaurant{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"suspended_at":      suspendedAt,
+		"suspension_reason": reason,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// ReplaceOpeningHours replaces a restaurant's weekly schedule
+func (r *restaurantRepository) ReplaceOpeningHours(restaurantID uuid.UUID, hours []models.OpeningHours) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("restaurant_id = ?", restaurantID).Delete(&models.OpeningHours{}).Error
+		if err != nil {
+			return err
+		}
+		if len(hours) == 0 {
+			return nil
+		}
+		for i := range hours {
+			hours[i].RestaurantID = restaurantID
+		}
+		return tx.Create(&hours).Error
+	})
+}
+
+// CreateClosure creates a date-range closure
+func (r *restaurantRepository) CreateClosure(closure *models.RestaurantClosure) error {
+	return r.db.Create(closure).Error
+}
+
+// DeleteClosure deletes a closure belonging to a restaurant
+func (r *restaurantRepository) DeleteClosure(restaurantID, closureID uuid.UUID) error {
+	result := r.db.Where("id = ? AND restaurant_id = ?", closureID, restaurantID).Delete(&models.RestaurantClosure{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// withSchedule preloads the weekly schedule and closures that have not ended yet
+func (r *restaurantRepository) withSchedule(db *gorm.DB) *gorm.DB {
+	return db.Preload("OpeningHours", func(db *gorm.DB) *gorm.DB {
+		return db.Order("weekday ASC, opens_at ASC")
+	}).Preload("Closures", func(db *gorm.DB) *gorm.DB {
+		// One day of slack covers timezones ahead of the database clock
+		return db.Where("end_date >= CURRENT_DATE - 1").Order("start_date ASC")
+	})
 }
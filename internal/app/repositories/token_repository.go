@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"time"
+
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TokenRepository interface defines refresh token and revocation data access methods
+type TokenRepository interface {
+	CreateRefreshToken(token *models.RefreshToken) error
+	FindRefreshTokenByHash(hash string) (*models.RefreshToken, error)
+	RotateRefreshToken(current *models.RefreshToken, next *models.RefreshToken) error
+	RevokeFamily(familyID uuid.UUID, accessTTL time.Duration) error
+	RevokeAllForUser(userID uuid.UUID, accessTTL time.Duration) error
+	RevokeAccessTokensForUserWithTx(tx *gorm.DB, userID uuid.UUID, accessTTL time.Duration) error
+	RevokeAccessToken(token *models.RevokedToken) error
+	IsAccessTokenRevoked(jti uuid.UUID) (bool, error)
+	DeleteExpiredWithTx(tx *gorm.DB, now time.Time, limit int) (int64, error)
+}
+
+// tokenRepository implements TokenRepository
+type tokenRepository struct {
+	db *gorm.DB
+}
+
+// NewTokenRepository creates a new token repository
+func NewTokenRepository(db *gorm.DB) TokenRepository {
+	return &tokenRepository{db: db}
+}
+
+// CreateRefreshToken stores a new refresh token
+func (r *tokenRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindRefreshTokenByHash finds a refresh token by its hash
+func (r *tokenRepository) FindRefreshTokenByHash(hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.db.Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken marks the current token as replaced and stores its successor.
+// Returns ErrRefreshTokenReused if the current token was already rotated or revoked.
+func (r *tokenRepository) RotateRefreshToken(current *models.RefreshToken, next *models.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+
+		// Only one concurrent request can win the rotation
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", current.ID).
+			Updates(map[string]interface{}{
+				"revoked_at":  time.Now(),
+				"replaced_by": next.ID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrRefreshTokenReused
+		}
+
+		return nil
+	})
+}
+
+// RevokeFamily revokes every refresh token in a family together with the
+// access tokens issued alongside them that may still be valid
+func (r *tokenRepository) RevokeFamily(familyID uuid.UUID, accessTTL time.Duration) error {
+	return r.revoke("family_id = ?", familyID, accessTTL)
+}
+
+// RevokeAllForUser revokes every session of a user
+func (r *tokenRepository) RevokeAllForUser(userID uuid.UUID, accessTTL time.Duration) error {
+	return r.revoke("user_id = ?", userID, accessTTL)
+}
+
+// RevokeAccessTokensForUserWithTx denylists a user's outstanding access tokens but
+// keeps their refresh tokens, forcing clients to refresh and pick up updated claims
+func (r *tokenRepository) RevokeAccessTokensForUserWithTx(tx *gorm.DB, userID uuid.UUID, accessTTL time.Duration) error {
+	return r.revokeAccessTokensWithTx(tx, "user_id = ?", userID, accessTTL)
+}
+
+// revoke revokes refresh tokens matching the condition and denylists their access tokens
+func (r *tokenRepository) revoke(condition string, value interface{}, accessTTL time.Duration) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.revokeAccessTokensWithTx(tx, condition, value, accessTTL); err != nil {
+			return err
+		}
+
+		return tx.Model(&models.RefreshToken{}).
+			Where(condition+" AND revoked_at IS NULL", value).
+			Update("revoked_at", time.Now()).Error
+	})
+}
+
+// revokeAccessTokensWithTx denylists the access tokens issued alongside matching
+// refresh tokens that may not have expired yet
+func (r *tokenRepository) revokeAccessTokensWithTx(tx *gorm.DB, condition string, value interface{}, accessTTL time.Duration) error {
+	seconds := int64(accessTTL.Seconds())
+
+	return tx.Exec(`
+		INSERT INTO revoked_tokens (jti, user_id, expires_at, revoked_at)
+		SELECT access_jti, user_id, created_at + make_interval(secs => ?), NOW()
+		FROM refresh_tokens
+		WHERE `+condition+` AND created_at > NOW() - make_interval(secs => ?)
+		ON CONFLICT (jti) DO NOTHING`,
+		seconds, value, seconds,
+	).Error
+}
+
+// RevokeAccessToken adds a single access token to the denylist
+func (r *tokenRepository) RevokeAccessToken(token *models.RevokedToken) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+// IsAccessTokenRevoked checks if an access token ID has been revoked
+func (r *tokenRepository) IsAccessTokenRevoked(jti uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	return count > 0, err
+}
+
+// DeleteExpiredWithTx deletes up to limit expired refresh tokens and up to limit
+// denylist entries whose access tokens have expired. Both are rejected on
+// their own once expired, so the rows are no longer needed.
+func (r *tokenRepository) DeleteExpiredWithTx(tx *gorm.DB, now time.Time, limit int) (int64, error) {
+	refreshTokens := tx.Model(&models.RefreshToken{}).
+		Select("id").
+		Where("expires_at <= ?", now).
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+	result := tx.Where("id IN (?)", refreshTokens).Delete(&models.RefreshToken{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	deleted := result.RowsAffected
+
+	revokedTokens := tx.Model(&models.RevokedToken{}).
+		Select("jti").
+		Where("expires_at <= ?", now).
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+	result = tx.Where("jti IN (?)", revokedTokens).Delete(&models.RevokedToken{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return deleted + result.RowsAffected, nil
+}
@@ -1,6 +1,9 @@
 package repositories
 
 import (
+	"strings"
+	"time"
+
 	"eatright-backend/internal/app/models"
 
 	"github.com/google/uuid"
@@ -13,6 +16,17 @@ type UserRepository interface {
 	FindByID(id uuid.UUID) (*models.User, error)
 	FindByEmail(email string) (*models.User, error)
 	Update(user *models.User) error
+	Search(filter UserFilter) ([]models.User, int64, error)
+	SetSuspended(id uuid.UUID, suspendedAt *time.Time, reason *string) error
+}
+
+// UserFilter describes search criteria for listing users
+type UserFilter struct {
+	Query     string // Matches name or email, case-insensitive
+	Role      models.UserRole
+	Suspended *bool
+	Limit     int
+	Offset    int
 }
 
 // userRepository implements UserRepository
@@ -60,3 +74,50 @@ func (r *userRepository) FindByEmail(email string) (*models.User, error) {
 func (r *userRepository) Update(user *models.User) error {
 	return r.db.Save(user).Error
 }
+
+// likeEscaper escapes LIKE wildcards so search terms match literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// Search finds users matching the filter and returns the total match count
+func (r *userRepository) Search(filter UserFilter) ([]models.User, int64, error) {
+	query := r.db.Model(&models.User{})
+
+	if filter.Query != "" {
+		pattern := "%" + likeEscaper.Replace(strings.ToLower(filter.Query)) + "%"
+		query = query.Where(`LOWER(name) LIKE ? ESCAPE '\' OR LOWER(email) LIKE ? ESCAPE '\'`, pattern, pattern)
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.Suspended != nil {
+		if *filter.Suspended {
+			query = query.Where("suspended_at IS NOT NULL")
+		} else {
+			query = query.Where("suspended_at IS NULL")
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []models.User
+	err := query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&users).Error
+	return users, total, err
+}
+
+// SetSuspended suspends (non-nil suspendedAt) or reinstates a user
+func (r *userRepository) SetSuspended(id uuid.UUID, suspendedAt *time.Time, reason *string) error {
+	result := r.db.Model(&models.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"suspended_at":      suspendedAt,
+		"suspension_reason": reason,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+package repositories
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WaitlistRepository interface defines waitlist and restaurant subscription data access methods
+type WaitlistRepository interface {
+	Join(entry *models.WaitlistEntry) error
+	Leave(userID, listingID uuid.UUID) error
+	Subscribe(subscription *models.RestaurantSubscription) error
+	Unsubscribe(userID, restaurantID uuid.UUID) error
+	FindByUserID(userID uuid.UUID) (*models.Waitlist, error)
+	NotifyRestockedWithTx(tx *gorm.DB, now time.Time, hold time.Duration, maxReservations, limit int) ([]models.WaitlistNotification, error)
+}
+
+// waitlistRepository implements WaitlistRepository
+type waitlistRepository struct {
+	db              *gorm.DB
+	reservationRepo ReservationRepository
+}
+
+// NewWaitlistRepository creates a new waitlist repository
+func NewWaitlistRepository(db *gorm.DB, reservationRepo ReservationRepository) WaitlistRepository {
+	return &waitlistRepository{
+		db:              db,
+		reservationRepo: reservationRepo,
+	}
+}
+
+// Join puts a customer in line for a listing, or updates the quantity they
+// want if they are already waiting, and sets their position
+func (r *waitlistRepository) Join(entry *models.WaitlistEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the user so concurrent joins do not put them in line twice
+		if err := lockUserWithTx(tx, entry.UserID); err != nil {
+			return err
+		}
+
+		var existing models.WaitlistEntry
+		err := tx.Where("user_id = ? AND listing_id = ? AND status = ?",
+			entry.UserID, entry.ListingID, models.WaitlistStatusWaiting).
+			First(&existing).Error
+		switch err {
+		case nil:
+			if err := tx.Model(&existing).Update("qty", entry.Qty).Error; err != nil {
+				return err
+			}
+			entry.ID = existing.ID
+			entry.Status = existing.Status
+			entry.CreatedAt = existing.CreatedAt
+		case gorm.ErrRecordNotFound:
+			if err := tx.Omit(clause.Associations).Create(entry).Error; err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+
+		var ahead int64
+		err = tx.Model(&models.WaitlistEntry{}).
+			Where("listing_id = ? AND status = ? AND created_at < ?", entry.ListingID, models.WaitlistStatusWaiting, entry.CreatedAt).
+			Count(&ahead).Error
+		entry.Position = int(ahead) + 1
+		return err
+	})
+}
+
+// Leave takes a customer out of line for a listing
+func (r *waitlistRepository) Leave(userID, listingID uuid.UUID) error {
+	result := r.db.Where("user_id = ? AND listing_id = ? AND status = ?", userID, listingID, models.WaitlistStatusWaiting).
+		Delete(&models.WaitlistEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// Subscribe subscribes a customer to a restaurant; subscribing twice keeps the
+// original subscription
+func (r *waitlistRepository) Subscribe(subscription *models.RestaurantSubscription) error {
+	err := r.db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "restaurant_id"}},
+		DoNothing: true,
+	}).Create(subscription).Error
+	if err != nil {
+		return err
+	}
+	return r.db.Where("user_id = ? AND restaurant_id = ?", subscription.UserID, subscription.RestaurantID).
+		First(subscription).Error
+}
+
+// Unsubscribe removes a customer's subscription to a restaurant
+func (r *waitlistRepository) Unsubscribe(userID, restaurantID uuid.UUID) error {
+	result := r.db.Where("user_id = ? AND restaurant_id = ?", userID, restaurantID).
+		Delete(&models.RestaurantSubscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// FindByUserID finds the listings a customer is waiting for, with their
+// positions, the listings they were notified of, with the reservations holding
+// their units, and the restaurants they are subscribed to. Listings whose
+// pickup window has ended are left out.
+func (r *waitlistRepository) FindByUserID(userID uuid.UUID) (*models.Waitlist, error) {
+	waitlist := &models.Waitlist{}
+	err := r.db.Preload("Listing").Preload("Listing.Restaurant").Preload("Reservation").
+		Joins("JOIN listings ON listings.id = waitlist_entries.listing_id").
+		Where("waitlist_entries.user_id = ? AND listings.pickup_end > ?", userID, time.Now()).
+		Order("waitlist_entries.created_at ASC").
+		Find(&waitlist.Listings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range waitlist.Listings {
+		waitlist.Listings[i].SetHold()
+	}
+
+	if waitlist.HasWaiting() {
+		var positions []struct {
+			ID       uuid.UUID
+			Position int
+		}
+		err = r.db.Model(&models.WaitlistEntry{}).
+			Select("waitlist_entries.id, (SELECT COUNT(*) FROM waitlist_entries ahead "+
+				"WHERE ahead.listing_id = waitlist_entries.listing_id AND ahead.status = waitlist_entries.status "+
+				"AND ahead.created_at < waitlist_entries.created_at) + 1 AS position").
+			Where("waitlist_entries.user_id = ? AND waitlist_entries.status = ?", userID, models.WaitlistStatusWaiting).
+			Scan(&positions).Error
+		if err != nil {
+			return nil, err
+		}
+
+		byID := make(map[uuid.UUID]int, len(positions))
+		for _, p := range positions {
+			byID[p.ID] = p.Position
+		}
+		for i := range waitlist.Listings {
+			waitlist.Listings[i].Position = byID[waitlist.Listings[i].ID]
+		}
+	}
+
+	err = r.db.Preload("Restaurant").
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&waitlist.Restaurants).Error
+	if err != nil {
+		return nil, err
+	}
+	return waitlist, nil
+}
+
+// NotifyRestockedWithTx handles up to limit listings that came back in stock.
+// For each orderable listing, customers in line are offered the units in the
+// order they joined; with a positive hold each is given a reservation of their
+// units for hold, so nobody else can buy them meanwhile, unless they already
+// hold maxReservations others. Units left over are
+// announced to the restaurant's subscribers. Notified entries leave the line
+// and the listings' restocked marks are cleared. Customers who may be given a
+// hold are locked before any listing, in ID order, so the holds cannot deadlock
+// with their own orders.
+func (r *waitlistRepository) NotifyRestockedWithTx(tx *gorm.DB, now time.Time, hold time.Duration, maxReservations, limit int) ([]models.WaitlistNotification, error) {
+	var listings []models.Listing
+	err := tx.Preload("Restaurant").
+		Where("restocked_at IS NOT NULL").
+		Order("restocked_at ASC").
+		Limit(limit).
+		Find(&listings).Error
+	if err != nil || len(listings) == 0 {
+		return nil, err
+	}
+
+	listingIDs := make([]uuid.UUID, len(listings))
+	restaurantIDs := make([]uuid.UUID, len(listings))
+	for i := range listings {
+		listingIDs[i] = listings[i].ID
+		restaurantIDs[i] = listings[i].RestaurantID
+	}
+
+	var entries []models.WaitlistEntry
+	err = tx.Where("listing_id IN ? AND status = ?", listingIDs, models.WaitlistStatusWaiting).
+		Order("created_at ASC").
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptions []models.RestaurantSubscription
+	err = tx.Where("restaurant_id IN ?", restaurantIDs).Order("created_at ASC").Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if hold > 0 {
+		if err := lockUsersWithTx(tx, entries); err != nil {
+			return nil, err
+		}
+	}
+
+	waiting := make(map[uuid.UUID][]*models.WaitlistEntry, len(listings))
+	for i := range entries {
+		waiting[entries[i].ListingID] = append(waiting[entries[i].ListingID], &entries[i])
+	}
+
+	var notifications []models.WaitlistNotification
+	for i := range listings {
+		listing := &listings[i]
+		if !listing.IsOrderable(now) {
+			continue
+		}
+
+		notified := make(map[uuid.UUID]bool)
+		remaining := listing.Stock
+		for _, entry := range waiting[listing.ID] {
+			if remaining <= 0 {
+				break
+			}
+			notification := models.WaitlistNotification{UserID: entry.UserID, Listing: listing, Qty: entry.Qty}
+			if notification.Qty > remaining {
+				notification.Qty = remaining
+			}
+
+			updates := map[string]interface{}{"status": models.WaitlistStatusNotified, "notified_at": now}
+			if hold > 0 {
+				reservation := &models.StockReservation{
+					UserID:    entry.UserID,
+					ListingID: listing.ID,
+					Qty:       notification.Qty,
+					ExpiresAt: now.Add(hold),
+				}
+				if listing.PickupEnd.Before(reservation.ExpiresAt) {
+					reservation.ExpiresAt = listing.PickupEnd
+				}
+				stock, err := r.reservationRepo.ReserveWithTx(tx, reservation, maxReservations)
+				if err == models.ErrInsufficientStock {
+					break // Bought since the listing was read; wait for the next restock
+				}
+				switch err {
+				case nil:
+					listing.Stock = stock
+					notification.Reservation = reservation
+					updates["reservation_id"] = reservation.ID
+				case models.ErrReservationLimitReached:
+					// The customer holds enough already; only notify them
+				default:
+					return nil, err
+				}
+			}
+
+			if err := tx.Model(entry).Updates(updates).Error; err != nil {
+				return nil, err
+			}
+			remaining -= notification.Qty
+			notified[entry.UserID] = true
+			notifications = append(notifications, notification)
+		}
+
+		if remaining <= 0 {
+			continue
+		}
+		for _, subscription := range subscriptions {
+			if subscription.RestaurantID != listing.RestaurantID || notified[subscription.UserID] {
+				continue
+			}
+			notified[subscription.UserID] = true
+			notifications = append(notifications, models.WaitlistNotification{
+				UserID:  subscription.UserID,
+				Listing: listing,
+				Qty:     remaining,
+			})
+		}
+	}
+
+	// Restocks after now are handled on the next run
+	err = tx.Model(&models.Listing{}).
+		Where("id IN ? AND restocked_at <= ?", listingIDs, now).
+		Update("restocked_at", nil).Error
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// lockUsersWithTx locks the customers of the entries in ID order
+func lockUsersWithTx(tx *gorm.DB, entries []models.WaitlistEntry) error {
+	seen := make(map[uuid.UUID]bool, len(entries))
+	var userIDs []uuid.UUID
+	for _, entry := range entries {
+		if !seen[entry.UserID] {
+			seen[entry.UserID] = true
+			userIDs = append(userIDs, entry.UserID)
+		}
+	}
+	sort.Slice(userIDs, func(i, j int) bool {
+		return bytes.Compare(userIDs[i][:], userIDs[j][:]) < 0
+	})
+
+	for _, userID := range userIDs {
+		if err := lockUserWithTx(tx, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
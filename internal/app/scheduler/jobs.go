@@ -0,0 +1,365 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+
+	"eatright-backend/internal/app/events"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// batchSize caps the rows a job claims per run so transactions stay short
+const batchSize = 500
+
+// ListingExpiryJob deactivates listings whose pickup window has ended or
+// whose restaurant has closed while the window was still open
+type ListingExpiryJob struct {
+	listingRepo repositories.ListingRepository
+}
+
+// NewListingExpiryJob creates a new listing expiry job
+func NewListingExpiryJob(listingRepo repositories.ListingRepository) *ListingExpiryJob {
+	return &ListingExpiryJob{listingRepo: listingRepo}
+}
+
+// Name returns the job name
+func (j *ListingExpiryJob) Name() string {
+	return "listing-expiry"
+}
+
+// Run deactivates ended and closed listings
+func (j *ListingExpiryJob) Run(tx *gorm.DB, now time.Time) (int64, error) {
+	ended, err := j.listingRepo.ExpireEndedWithTx(tx, now, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Opening hours or closures may have changed after the listing was created
+	inProgress, err := j.listingRepo.FindInProgressWithTx(tx, now, batchSize)
+	if err != nil {
+		return ended, err
+	}
+
+	var closed []uuid.UUID
+	for _, listing := range inProgress {
+		if !listing.Restaurant.IsOpenAt(now) {
+			closed = append(closed, listing.ID)
+		}
+	}
+
+	deactivated, err := j.listingRepo.DeactivateManyWithTx(tx, closed, models.DeactivationRestaurantClosed, now)
+	return ended + deactivated, err
+}
+
+// ListingTemplateJob creates today's listings from recurring templates
+type ListingTemplateJob struct {
+	templateRepo repositories.ListingTemplateRepository
+	listingRepo  repositories.ListingRepository
+}
+
+// NewListingTemplateJob creates a new listing template job
+func NewListingTemplateJob(templateRepo repositories.ListingTemplateRepository, listingRepo repositories.ListingRepository) *ListingTemplateJob {
+	return &ListingTemplateJob{templateRepo: templateRepo, listingRepo: listingRepo}
+}
+
+// Name returns the job name
+func (j *ListingTemplateJob) Name() string {
+	return "listing-templates"
+}
+
+// Run creates the occurrence for the current local day of each active template.
+// Occurrences that are skipped, already over, or fall while the restaurant is
+// closed are not created; existing occurrences are left alone.
+func (j *ListingTemplateJob) Run(tx *gorm.DB, now time.Time) (int64, error) {
+	templates, err := j.templateRepo.FindActiveWithTx(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	var created int64
+	for i := range templates {
+		template := &templates[i]
+		listing := template.Occurrence(&template.Restaurant, now)
+		if listing == nil || listing.IsPickupOver(now) {
+			continue
+		}
+		if !template.Restaurant.IsOpenThroughout(listing.PickupStart, listing.PickupEnd) {
+			continue
+		}
+
+		ok, err := j.listingRepo.CreateOccurrenceWithTx(tx, listing)
+		if err != nil {
+			return created, err
+		}
+		if ok {
+			created++
+		}
+	}
+	return created, nil
+}
+
+// NoShowJob marks pending and ready orders that were never picked up as no-shows
+type NoShowJob struct {
+	orderRepo repositories.OrderRepository
+	bus       events.Bus
+	grace     time.Duration
+	closed    []models.Order // Closed by the last run, announced after it commits
+}
+
+// NewNoShowJob creates a new no-show job; orders are closed grace after pickup ends
+func NewNoShowJob(orderRepo repositories.OrderRepository, bus events.Bus, grace time.Duration) *NoShowJob {
+	return &NoShowJob{orderRepo: orderRepo, bus: bus, grace: grace}
+}
+
+// Name returns the job name
+func (j *NoShowJob) Name() string {
+	return "order-no-show"
+}
+
+// Run closes uncollected orders
+func (j *NoShowJob) Run(tx *gorm.DB, now time.Time) (int64, error) {
+	closed, err := j.orderRepo.ExpireUncollectedWithTx(tx, now.Add(-j.grace), batchSize)
+	j.closed = closed
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(closed)), nil
+}
+
+// AfterCommit announces the orders closed by the last run
+func (j *NoShowJob) AfterCommit() {
+	for i := range j.closed {
+		j.bus.Publish(events.OrderEvent(events.OrderStatusChanged, &j.closed[i]))
+	}
+	j.closed = nil
+}
+
+// IdempotencyPurgeJob deletes expired idempotency keys
+type IdempotencyPurgeJob struct {
+	idempotencyRepo repositories.IdempotencyRepository
+}
+
+// NewIdempotencyPurgeJob creates a new idempotency key purge job
+func NewIdempotencyPurgeJob(idempotencyRepo repositories.IdempotencyRepository) *IdempotencyPurgeJob {
+	return &IdempotencyPurgeJob{idempotencyRepo: idempotencyRepo}
+}
+
+// Name returns the job name
+func (j *IdempotencyPurgeJob) Name() string {
+	return "idempotency-purge"
+}
+
+// Run deletes expired keys
+func (j *IdempotencyPurgeJob) Run(tx *gorm.DB, now time.Time) (int64, error) {
+	return j.idempotencyRepo.DeleteExpiredWithTx(tx, now, batchSize)
+}
+
+// TokenPurgeJob deletes expired refresh tokens and access token denylist entries
+type TokenPurgeJob struct {
+	tokenRepo repositories.TokenRepository
+}
+
+// NewTokenPurgeJob creates a new token purge job
+func NewTokenPurgeJob(tokenRepo repositories.TokenRepository) *TokenPurgeJob {
+	return &TokenPurgeJob{tokenRepo: tokenRepo}
+}
+
+// Name returns the job name
+func (j *TokenPurgeJob) Name() string {
+	return "token-purge"
+}
+
+// Run deletes expired tokens
+func (j *TokenPurgeJob) Run(tx *gorm.DB, now time.Time) (int64, error) {
+	return j.tokenRepo.DeleteExpiredWithTx(tx, now, batchSize)
+}
+
+// ReservationExpiryJob returns the units of expired stock reservations to stock
+type ReservationExpiryJob struct {
+	reservationRepo repositories.ReservationRepository
+	bus             events.Bus
+	expired         []models.StockReservation // Expired by the last run, announced after it commits
+}
+
+// NewReservationExpiryJob creates a new reservation expiry job
+func NewReservationExpiryJob(reservationRepo repositories.ReservationRepository, bus events.Bus) *ReservationExpiryJob {
+	return &ReservationExpiryJob{reservationRepo: reservationRepo, bus: bus}
+}
+
+// Name returns the job name
+func (j *ReservationExpiryJob) Name() string {
+	return "reservation-expiry"
+}
+
+// Run expires reservations that ran out
+func (j *ReservationExpiryJob) Run(tx *gorm.DB, now time.Time) (int64, error) {
+	expired, err := j.reservationRepo.ExpireWithTx(tx, now, batchSize)
+	j.expired = expired
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(expired)), nil
+}
+
+// AfterCommit announces the restored stock of the listings whose reservations expired
+func (j *ReservationExpiryJob) AfterCommit() {
+	announced := make(map[uuid.UUID]bool, len(j.expired))
+	for i := range j.expired {
+		listing := &j.expired[i].Listing
+		if announced[listing.ID] {
+			continue
+		}
+		announced[listing.ID] = true
+		j.bus.Publish(events.StockEvent(listing))
+	}
+	j.expired = nil
+}
+
+// WaitlistJob notifies customers waiting for listings that came back in stock
+type WaitlistJob struct {
+	waitlistRepo    repositories.WaitlistRepository
+	bus             events.Bus
+	hold            time.Duration
+	maxReservations int
+	notified        []models.WaitlistNotification // Sent by the last run, announced after it commits
+}
+
+// NewWaitlistJob creates a new waitlist job; the first customers in line get
+// their units held for hold (0 to only notify them) unless they already hold
+// maxReservations reservations
+func NewWaitlistJob(waitlistRepo repositories.WaitlistRepository, bus events.Bus, hold time.Duration, maxReservations int) *WaitlistJob {
+	return &WaitlistJob{waitlistRepo: waitlistRepo, bus: bus, hold: hold, maxReservations: maxReservations}
+}
+
+// Name returns the job name
+func (j *WaitlistJob) Name() string {
+	return "waitlist-notify"
+}
+
+// Run notifies the waitlists of restocked listings
+func (j *WaitlistJob) Run(tx *gorm.DB, now time.Time) (int64, error) {
+	notified, err := j.waitlistRepo.NotifyRestockedWithTx(tx, now, j.hold, j.maxReservations, batchSize)
+	j.notified = notified
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(notified)), nil
+}
+
+// AfterCommit sends the notifications and announces stock taken by holds
+func (j *WaitlistJob) AfterCommit() {
+	held := make(map[uuid.UUID]*models.Listing)
+	for _, notification := range j.notified {
+		j.bus.Publish(events.WaitlistEvent(notification))
+		if notification.Reservation != nil {
+			held[notification.Listing.ID] = notification.Listing
+		}
+	}
+	for _, listing := range held {
+		j.bus.Publish(events.StockEvent(listing))
+	}
+	j.notified = nil
+}
+
+// PaymentExpiryJob cancels orders that were not paid in time, returning their stock
+type PaymentExpiryJob struct {
+	orderRepo repositories.OrderRepository
+	bus       events.Bus
+	timeout   time.Duration
+	cancelled []models.Order // Cancelled by the last run, announced after it commits
+}
+
+// NewPaymentExpiryJob creates a new payment expiry job; orders are cancelled
+// timeout after they were placed
+func NewPaymentExpiryJob(orderRepo repositories.OrderRepository, bus events.Bus, timeout time.Duration) *PaymentExpiryJob {
+	return &PaymentExpiryJob{orderRepo: orderRepo, bus: bus, timeout: timeout}
+}
+
+// Name returns the job name
+func (j *PaymentExpiryJob) Name() string {
+	return "payment-expiry"
+}
+
+// Run cancels unpaid orders
+func (j *PaymentExpiryJob) Run(tx *gorm.DB, now time.Time) (int64, error) {
+	cancelled, err := j.orderRepo.ExpireUnpaidWithTx(tx, now.Add(-j.timeout), batchSize)
+	j.cancelled = cancelled
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(cancelled)), nil
+}
+
+// AfterCommit announces the cancelled orders and their listings' restored stock
+func (j *PaymentExpiryJob) AfterCommit() {
+	for i := range j.cancelled {
+		j.bus.Publish(events.OrderEvent(events.OrderStatusChanged, &j.cancelled[i]))
+		for k := range j.cancelled[i].Items {
+			j.bus.Publish(events.StockEvent(&j.cancelled[i].Items[k].Listing))
+		}
+	}
+	j.cancelled = nil
+}
+
+// RefundExecutor sends approved refunds to the payment provider
+type RefundExecutor interface {
+	ExecuteRefund(refund *models.Refund) (*models.Refund, error)
+}
+
+// refundPendingWarnAfter is how long a refund may wait at the payment provider
+// before each check logs it as stuck
+const refundPendingWarnAfter = 24 * time.Hour
+
+// RefundJob retries refunds that were not executed when they were approved, or
+// whose execution failed, and checks on refunds pending at the provider
+type RefundJob struct {
+	refundRepo  repositories.RefundRepository
+	executor    RefundExecutor
+	delay       time.Duration
+	maxAttempts int
+	queued      []models.Refund // Loaded by the last run, executed after it commits
+}
+
+// NewRefundJob creates a new refund job; refunds are picked up once untouched
+// for delay, and given up after maxAttempts unless pending at the provider
+func NewRefundJob(refundRepo repositories.RefundRepository, executor RefundExecutor, delay time.Duration, maxAttempts int) *RefundJob {
+	return &RefundJob{refundRepo: refundRepo, executor: executor, delay: delay, maxAttempts: maxAttempts}
+}
+
+// Name returns the job name
+func (j *RefundJob) Name() string {
+	return "refund-retry"
+}
+
+// Run loads the queued refunds and returns how many there are. They are sent
+// to the provider after the run commits, so provider calls never hold the
+// scheduler lock.
+func (j *RefundJob) Run(tx *gorm.DB, now time.Time) (int64, error) {
+	refunds, err := j.refundRepo.FindExecutableWithTx(tx, now.Add(-j.delay), j.maxAttempts, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	j.queued = refunds
+	return int64(len(refunds)), nil
+}
+
+// AfterCommit executes the refunds loaded by the last run. Executions are
+// idempotent at the provider and record their own outcome, so a refund another
+// instance executes at the same time is only applied once.
+func (j *RefundJob) AfterCommit() {
+	for i := range j.queued {
+		executed, err := j.executor.ExecuteRefund(&j.queued[i])
+		if err != nil {
+			log.Printf("⚠️  Refund %s failed: %v", j.queued[i].ID, err)
+			continue
+		}
+		if executed.IsPendingAtProvider() && time.Since(executed.CreatedAt) > refundPendingWarnAfter {
+			log.Printf("⚠️  Refund %s is still pending at the payment provider since %s", executed.ID, executed.CreatedAt.Format(time.RFC3339))
+		}
+	}
+	j.queued = nil
+}
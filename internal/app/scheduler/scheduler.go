@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Job is a unit of background work that runs periodically
+type Job interface {
+	// Name identifies the job in logs and in its advisory lock
+	Name() string
+	// Run performs one pass inside tx and returns how many rows it changed
+	Run(tx *gorm.DB, now time.Time) (int64, error)
+}
+
+// CommitHook is implemented by jobs that act once a run has committed, e.g. to
+// publish events about the rows it changed. It is not called when the run fails
+// or is skipped.
+type CommitHook interface {
+	AfterCommit()
+}
+
+// Scheduler runs registered jobs on their own tickers.
+// Each run happens inside a transaction holding a Postgres advisory lock keyed
+// by the job name, so with several replicas at most one runs a job at a time;
+// the others skip that tick.
+type Scheduler struct {
+	db      *gorm.DB
+	entries []entry
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// entry is a registered job and its interval
+type entry struct {
+	job      Job
+	interval time.Duration
+}
+
+// New creates a new scheduler
+func New(db *gorm.DB) *Scheduler {
+	return &Scheduler{
+		db:   db,
+		stop: make(chan struct{}),
+	}
+}
+
+// Register adds a job that runs every interval; call before Start
+func (s *Scheduler) Register(job Job, interval time.Duration) {
+	s.entries = append(s.entries, entry{job: job, interval: interval})
+}
+
+// Start runs every registered job once immediately and then on its interval
+func (s *Scheduler) Start() {
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go s.loop(e)
+	}
+	log.Printf("⏱️  Scheduler started with %d job(s)", len(s.entries))
+}
+
+// Stop signals all jobs to stop and waits for in-flight runs to finish
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// loop runs a job until the scheduler is stopped
+func (s *Scheduler) loop(e entry) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runJob(e.job)
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runJob runs one pass of a job if no other replica is running it
+func (s *Scheduler) runJob(job Job) {
+	var changed int64
+	ran := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var acquired bool
+		err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", "eatright:"+job.Name()).
+			Scan(&acquired).Error
+		if err != nil || !acquired {
+			return err
+		}
+
+		changed, err = job.Run(tx, time.Now())
+		ran = err == nil
+		return err
+	})
+	if err != nil {
+		log.Printf("❌ Job %s failed: %v", job.Name(), err)
+		return
+	}
+	if hook, ok := job.(CommitHook); ok && ran {
+		hook.AfterCommit()
+	}
+	if changed > 0 {
+		log.Printf("⏱️  Job %s updated %d row(s)", job.Name(), changed)
+	}
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"time"
+
+	"eatright-backend/internal/app/events"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+// AdminService handles platform moderation
+type AdminService interface {
+	SearchUsers(filter repositories.UserFilter) ([]models.User, int64, error)
+	GetUser(id uuid.UUID) (*models.User, error)
+	SuspendUser(id uuid.UUID, adminID uuid.UUID, reason string) error
+	UnsuspendUser(id uuid.UUID) error
+	SuspendRestaurant(id uuid.UUID, reason string) error
+	UnsuspendRestaurant(id uuid.UUID) error
+	DeactivateListing(id uuid.UUID) error
+	CancelOrder(id uuid.UUID, adminID uuid.UUID, reason string) error
+}
+
+// adminService implements AdminService
+type adminService struct {
+	userRepo       repositories.UserRepository
+	restaurantRepo repositories.RestaurantRepository
+	listingRepo    repositories.ListingRepository
+	orderRepo      repositories.OrderRepository
+	paymentService PaymentService
+	bus            events.Bus
+}
+
+// NewAdminService creates a new admin service
+func NewAdminService(
+	userRepo repositories.UserRepository,
+	restaurantRepo repositories.RestaurantRepository,
+	listingRepo repositories.ListingRepository,
+	orderRepo repositories.OrderRepository,
+	paymentService PaymentService,
+	bus events.Bus,
+) AdminService {
+	return &adminService{
+		userRepo:       userRepo,
+		restaurantRepo: restaurantRepo,
+		listingRepo:    listingRepo,
+		orderRepo:      orderRepo,
+		paymentService: paymentService,
+		bus:            bus,
+	}
+}
+
+// SearchUsers lists users matching the filter
+func (s *adminService) SearchUsers(filter repositories.UserFilter) ([]models.User, int64, error) {
+	return s.userRepo.Search(filter)
+}
+
+// GetUser retrieves a user by ID
+func (s *adminService) GetUser(id uuid.UUID) (*models.User, error) {
+	return s.userRepo.FindByID(id)
+}
+
+// SuspendUser suspends a user; suspended users are rejected by AuthMiddleware
+func (s *adminService) SuspendUser(id uuid.UUID, adminID uuid.UUID, reason string) error {
+	if id == adminID {
+		return models.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	// Admins are managed outside the moderation API
+	if user.IsAdmin() {
+		return models.ErrUnauthorized
+	}
+
+	now := time.Now()
+	return s.userRepo.SetSuspended(id, &now, optionalString(reason))
+}
+
+// UnsuspendUser reinstates a suspended user
+func (s *adminService) UnsuspendUser(id uuid.UUID) error {
+	return s.userRepo.SetSuspended(id, nil, nil)
+}
+
+// SuspendRestaurant hides a restaurant and its listings and blocks new orders
+func (s *adminService) SuspendRestaurant(id uuid.UUID, reason string) error {
+	now := time.Now()
+	return s.restaurantRepo.SetSuspended(id, &now, optionalString(reason))
+}
+
+// UnsuspendRestaurant reinstates a suspended restaurant
+func (s *adminService) UnsuspendRestaurant(id uuid.UUID) error {
+	return s.restaurantRepo.SetSuspended(id, nil, nil)
+}
+
+// DeactivateListing force-deactivates a listing regardless of ownership
+func (s *adminService) DeactivateListing(id uuid.UUID) error {
+	return s.listingRepo.Deactivate(id, models.DeactivationAdmin)
+}
+
+// CancelOrder cancels an order regardless of ownership and restores its stock;
+// paid orders are refunded in full
+func (s *adminService) CancelOrder(id uuid.UUID, adminID uuid.UUID, reason string) error {
+	order, err := s.orderRepo.Transition(id, models.OrderTransition{
+		To:      models.OrderStatusCancelled,
+		Actor:   models.OrderActorAdmin,
+		ActorID: &adminID,
+		Reason:  optionalString(reason),
+	})
+	if err != nil {
+		return err
+	}
+
+	publishTransition(s.bus, order)
+	s.paymentService.SettleRefunds(order)
+	return nil
+}
+
+// optionalString returns nil for empty strings
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
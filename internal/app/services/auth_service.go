@@ -1,7 +1,10 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"time"
 
 	"eatright-backend/internal/app/config"
 	"eatright-backend/internal/app/models"
@@ -9,17 +12,34 @@ import (
 	"eatright-backend/internal/app/utils"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 // AuthService handles authentication logic
 type AuthService interface {
-	VerifySupabaseToken(token string) (*models.User, string, error)
+	VerifySupabaseToken(token string, meta models.SessionMetadata) (*models.User, *TokenPair, error)
+	RefreshSession(refreshToken string, meta models.SessionMetadata) (*TokenPair, error)
+	Logout(userID uuid.UUID, refreshToken string, accessJTI uuid.UUID, accessExpiresAt time.Time) error
+	LogoutAll(userID uuid.UUID) error
+	ValidateAccessToken(claims *utils.Claims) error
+	ValidateSession(userID uuid.UUID, tokenID uuid.UUID) error
 }
 
 // authService implements AuthService
 type authService struct {
-	userRepo repositories.UserRepository
-	config   *config.Config
+	userRepo  repositories.UserRepository
+	tokenRepo repositories.TokenRepository
+	config    *config.Config
+	jwks      *utils.JWKSProvider
+}
+
+// TokenPair represents an access token and its rotating refresh token
+type TokenPair struct {
+	AccessToken      string    `json:"token"`
+	RefreshToken     string    `json:"refresh_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresIn        int64     `json:"expires_in"` // Access token lifetime in seconds
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
 }
 
 // SupabaseClaims represents Supabase JWT claims
@@ -34,34 +54,95 @@ type SupabaseClaims struct {
 }
 
 // NewAuthService creates a new auth service
-func NewAuthService(userRepo repositories.UserRepository, cfg *config.Config) (AuthService, error) {
-	return &authService{
-		userRepo: userRepo,
-		config:   cfg,
-	}, nil
-}
+func NewAuthService(userRepo repositories.UserRepository, tokenRepo repositories.TokenRepository, cfg *config.Config) (AuthService, error) {
+	service := &authService{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		config:    cfg,
+	}
 
-// VerifySupabaseToken verifies a Supabase OAuth token and returns user + JWT
-func (s *authService) VerifySupabaseToken(token string) (*models.User, string, error) {
-	// Parse Supabase JWT token
-	// Note: In production, you should verify the signature using Supabase JWT secret
-	// For now, we'll parse it without verification (unsafe for production)
-	// You should use SUPABASE_JWT_SECRET from your Supabase dashboard
+	// Load the JWKS up front so a misconfigured key source fails at startup
+	if cfg.Supabase.JWKSURL != "" || cfg.Supabase.JWKSFile != "" {
+		service.jwks = utils.NewJWKSProvider(cfg.Supabase.JWKSURL, cfg.Supabase.JWKSFile, cfg.Supabase.JWKSCacheTTL)
+		if err := service.jwks.Refresh(); err != nil {
+			if cfg.Supabase.JWKSFile != "" {
+				return nil, fmt.Errorf("failed to load supabase jwks: %w", err)
+			}
+			log.Printf("⚠️  Failed to prefetch Supabase JWKS, will retry on demand: %v", err)
+		}
+	}
+
+	return service, nil
+}
 
+// parseSupabaseToken verifies the signature and registered claims of a Supabase JWT
+func (s *authService) parseSupabaseToken(token string) (*SupabaseClaims, error) {
 	claims := &SupabaseClaims{}
-	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
-		// For production, use your Supabase JWT secret here
-		// return []byte(s.config.Supabase.ServiceKey), nil
 
-		// For now, we'll skip verification to keep things simple
-		// This means we trust the token is from Supabase
-		return []byte(""), nil
-	})
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"HS256", "RS256", "ES256"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if s.config.Supabase.Audience != "" {
+		options = append(options, jwt.WithAudience(s.config.Supabase.Audience))
+	}
+	if s.config.Supabase.Issuer != "" {
+		options = append(options, jwt.WithIssuer(s.config.Supabase.Issuer))
+	}
+
+	_, err := jwt.ParseWithClaims(token, claims, s.supabaseKeyFunc, options...)
+	if err != nil {
+		return nil, classifyTokenError(err)
+	}
+
+	return claims, nil
+}
+
+// supabaseKeyFunc selects the verification key based on the token's signing method
+func (s *authService) supabaseKeyFunc(t *jwt.Token) (interface{}, error) {
+	switch t.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if s.config.Supabase.JWTSecret == "" {
+			return nil, fmt.Errorf("hs256 tokens are not accepted: SUPABASE_JWT_SECRET is not configured")
+		}
+		return []byte(s.config.Supabase.JWTSecret), nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		if s.jwks == nil {
+			return nil, fmt.Errorf("%s tokens are not accepted: no JWKS source is configured", t.Method.Alg())
+		}
+		kid, _ := t.Header["kid"].(string)
+		return s.jwks.Key(kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+}
+
+// classifyTokenError maps jwt parsing errors to model-level token errors
+func classifyTokenError(err error) error {
+	switch {
+	case errors.Is(err, utils.ErrJWKSUnavailable):
+		return fmt.Errorf("%w: %v", models.ErrIdentityUnavailable, err)
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return fmt.Errorf("%w: %v", models.ErrTokenMalformed, err)
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return fmt.Errorf("%w: %v", models.ErrTokenExpired, err)
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable):
+		return fmt.Errorf("%w: %v", models.ErrTokenInvalidSignature, err)
+	default:
+		return fmt.Errorf("%w: %v", models.ErrTokenInvalidClaims, err)
+	}
+}
+
+// VerifySupabaseToken verifies a Supabase OAuth token and returns user + JWT
+func (s *authService) VerifySupabaseToken(token string, meta models.SessionMetadata) (*models.User, *TokenPair, error) {
+	claims, err := s.parseSupabaseToken(token)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// We ignore signature verification error for simplicity
-	// In production, you MUST verify the signature
 	if claims.Email == "" {
-		return nil, "", fmt.Errorf("invalid token: no email found")
+		return nil, nil, fmt.Errorf("%w: no email found", models.ErrTokenInvalidClaims)
 	}
 
 	email := claims.Email
@@ -93,18 +174,177 @@ func (s *authService) VerifySupabaseToken(token string) (*models.User, string, e
 
 			err = s.userRepo.Create(user)
 			if err != nil {
-				return nil, "", fmt.Errorf("failed to create user: %w", err)
+				return nil, nil, fmt.Errorf("failed to create user: %w", err)
 			}
 		} else {
-			return nil, "", fmt.Errorf("failed to find user: %w", err)
+			return nil, nil, fmt.Errorf("failed to find user: %w", err)
+		}
+	}
+
+	if user.IsSuspended() {
+		return nil, nil, models.ErrAccountSuspended
+	}
+
+	// Start a new session (token family) for this login
+	next, pair, err := s.issueTokens(user, uuid.New(), meta)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.tokenRepo.CreateRefreshToken(next); err != nil {
+		return nil, nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return user, pair, nil
+}
+
+// RefreshSession rotates a refresh token and issues a new access token.
+// Presenting a token that was already rotated revokes its whole family.
+func (s *authService) RefreshSession(refreshToken string, meta models.SessionMetadata) (*TokenPair, error) {
+	current, err := s.tokenRepo.FindRefreshTokenByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		if err == models.ErrNotFound {
+			return nil, models.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if current.IsRevoked() {
+		if current.ReplacedBy != nil {
+			// A rotated token was presented again: assume it was stolen
+			if err := s.tokenRepo.RevokeFamily(current.FamilyID, s.config.JWT.Expiry); err != nil {
+				return nil, err
+			}
+			return nil, models.ErrRefreshTokenReused
 		}
+		return nil, models.ErrInvalidRefreshToken
 	}
 
-	// Generate JWT token for our backend
-	jwtToken, err := utils.GenerateToken(user, s.config.JWT.Secret, s.config.JWT.Expiry)
+	if current.IsExpired() {
+		return nil, models.ErrTokenExpired
+	}
+
+	// Reload the user so role changes are reflected in the new access token
+	user, err := s.userRepo.FindByID(current.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsSuspended() {
+		return nil, models.ErrAccountSuspended
+	}
+
+	next, pair, err := s.issueTokens(user, current.FamilyID, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenRepo.RotateRefreshToken(current, next); err != nil {
+		if err == models.ErrRefreshTokenReused {
+			// Lost a race with another use of the same token
+			if revokeErr := s.tokenRepo.RevokeFamily(current.FamilyID, s.config.JWT.Expiry); revokeErr != nil {
+				return nil, revokeErr
+			}
+		}
+		return nil, err
+	}
+
+	return pair, nil
+}
+
+// Logout revokes the current access token and, if given, the session of the refresh token
+func (s *authService) Logout(userID uuid.UUID, refreshToken string, accessJTI uuid.UUID, accessExpiresAt time.Time) error {
+	if refreshToken != "" {
+		token, err := s.tokenRepo.FindRefreshTokenByHash(utils.HashToken(refreshToken))
+		if err != nil {
+			if err == models.ErrNotFound {
+				return models.ErrInvalidRefreshToken
+			}
+			return err
+		}
+		if token.UserID != userID {
+			return models.ErrUnauthorized
+		}
+		if err := s.tokenRepo.RevokeFamily(token.FamilyID, s.config.JWT.Expiry); err != nil {
+			return err
+		}
+	}
+
+	return s.tokenRepo.RevokeAccessToken(&models.RevokedToken{
+		JTI:       accessJTI,
+		UserID:    userID,
+		ExpiresAt: accessExpiresAt,
+	})
+}
+
+// LogoutAll revokes every session of a user
+func (s *authService) LogoutAll(userID uuid.UUID) error {
+	return s.tokenRepo.RevokeAllForUser(userID, s.config.JWT.Expiry)
+}
+
+// ValidateAccessToken checks that an otherwise valid access token has not been
+// revoked and that its user is not suspended
+func (s *authService) ValidateAccessToken(claims *utils.Claims) error {
+	jti, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return fmt.Errorf("%w: missing token id", models.ErrTokenInvalidClaims)
+	}
+
+	return s.ValidateSession(claims.UserID, jti)
+}
+
+// ValidateSession checks that the access token tokenID of a user has not been
+// revoked and that the user is not suspended. Long-lived connections call it
+// again while they stay open.
+func (s *authService) ValidateSession(userID uuid.UUID, tokenID uuid.UUID) error {
+	revoked, err := s.tokenRepo.IsAccessTokenRevoked(tokenID)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return models.ErrTokenRevoked
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.IsSuspended() {
+		return models.ErrAccountSuspended
+	}
+
+	return nil
+}
+
+// issueTokens generates an access token and a refresh token belonging to the given family
+func (s *authService) issueTokens(user *models.User, familyID uuid.UUID, meta models.SessionMetadata) (*models.RefreshToken, *TokenPair, error) {
+	accessJTI := uuid.New()
+	accessToken, err := utils.GenerateToken(user, accessJTI, s.config.JWT.Secret, s.config.JWT.Expiry)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate jwt: %w", err)
+	}
+
+	refreshToken, err := utils.GenerateRefreshToken()
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to generate jwt: %w", err)
+		return nil, nil, err
+	}
+
+	record := &models.RefreshToken{
+		UserID:     user.ID,
+		FamilyID:   familyID,
+		TokenHash:  utils.HashToken(refreshToken),
+		AccessJTI:  accessJTI,
+		UserAgent:  meta.UserAgent,
+		DeviceName: meta.DeviceName,
+		IPAddress:  meta.IPAddress,
+		ExpiresAt:  time.Now().Add(s.config.JWT.RefreshExpiry),
+	}
+
+	pair := &TokenPair{
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(s.config.JWT.Expiry.Seconds()),
+		RefreshExpiresAt: record.ExpiresAt,
 	}
 
-	return user, jwtToken, nil
+	return record, pair, nil
 }
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testSupabaseSecret = "test-supabase-secret"
+	testAudience       = "authenticated"
+	testIssuer         = "https://project.supabase.co/auth/v1"
+	testKeyID          = "test-key"
+)
+
+// newTokenTestService creates an auth service verifying HS256 tokens with
+// testSupabaseSecret and RS256 tokens against jwksURL, if set
+func newTokenTestService(jwksURL string) *authService {
+	service := &authService{config: &config.Config{
+		Supabase: config.SupabaseConfig{
+			JWTSecret: testSupabaseSecret,
+			Audience:  testAudience,
+			Issuer:    testIssuer,
+		},
+	}}
+	if jwksURL != "" {
+		service.jwks = utils.NewJWKSProvider(jwksURL, "", time.Minute)
+	}
+	return service
+}
+
+// validClaims returns claims the test service accepts
+func validClaims() SupabaseClaims {
+	now := time.Now()
+	return SupabaseClaims{
+		Email: "customer@example.com",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user",
+			Audience:  jwt.ClaimStrings{testAudience},
+			Issuer:    testIssuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}
+}
+
+// signHS256 signs claims with secret
+func signHS256(t *testing.T, claims SupabaseClaims, secret string) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+// signRS256 signs claims with key under testKeyID
+func signRS256(t *testing.T, claims SupabaseClaims, key *rsa.PrivateKey) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testKeyID
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+// serveJWKS serves key's public half as a JWKS document
+func serveJWKS(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	t.Helper()
+	set := utils.JWKSet{Keys: []utils.JWK{{
+		Kid: testKeyID,
+		Kty: "RSA",
+		Alg: "RS256",
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestParseSupabaseTokenHS256(t *testing.T) {
+	service := newTokenTestService("")
+
+	claims, err := service.parseSupabaseToken(signHS256(t, validClaims(), testSupabaseSecret))
+	if err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+	if claims.Email != "customer@example.com" {
+		t.Errorf("email = %q, want customer@example.com", claims.Email)
+	}
+
+	_, err = service.parseSupabaseToken(signHS256(t, validClaims(), "another-secret"))
+	if !errors.Is(err, models.ErrTokenInvalidSignature) {
+		t.Errorf("wrong secret: err = %v, want ErrTokenInvalidSignature", err)
+	}
+}
+
+func TestParseSupabaseTokenRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	service := newTokenTestService(serveJWKS(t, key).URL)
+
+	if _, err := service.parseSupabaseToken(signRS256(t, validClaims(), key)); err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	_, err = service.parseSupabaseToken(signRS256(t, validClaims(), other))
+	if !errors.Is(err, models.ErrTokenInvalidSignature) {
+		t.Errorf("foreign key: err = %v, want ErrTokenInvalidSignature", err)
+	}
+}
+
+func TestParseSupabaseTokenRejectsClaims(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *SupabaseClaims)
+		want   error
+	}{
+		{
+			name: "expired",
+			modify: func(c *SupabaseClaims) {
+				c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+			},
+			want: models.ErrTokenExpired,
+		},
+		{
+			name: "missing expiry",
+			modify: func(c *SupabaseClaims) {
+				c.ExpiresAt = nil
+			},
+			want: models.ErrTokenInvalidClaims,
+		},
+		{
+			name: "wrong audience",
+			modify: func(c *SupabaseClaims) {
+				c.Audience = jwt.ClaimStrings{"anon"}
+			},
+			want: models.ErrTokenInvalidClaims,
+		},
+		{
+			name: "wrong issuer",
+			modify: func(c *SupabaseClaims) {
+				c.Issuer = "https://other.supabase.co/auth/v1"
+			},
+			want: models.ErrTokenInvalidClaims,
+		},
+	}
+
+	service := newTokenTestService("")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			tt.modify(&claims)
+			_, err := service.parseSupabaseToken(signHS256(t, claims, testSupabaseSecret))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSupabaseTokenJWKSUnavailable(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	service := newTokenTestService(server.URL)
+
+	_, err = service.parseSupabaseToken(signRS256(t, validClaims(), key))
+	if !errors.Is(err, models.ErrIdentityUnavailable) {
+		t.Errorf("err = %v, want ErrIdentityUnavailable", err)
+	}
+}
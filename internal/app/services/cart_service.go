@@ -0,0 +1,115 @@
+package services
+
+import (
+	"time"
+
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+// CartService handles cart-related business logic
+type CartService interface {
+	GetCart(userID uuid.UUID) (*models.Cart, error)
+	SetItem(userID, listingID uuid.UUID, qty int) (*models.Cart, error)
+	RemoveItem(userID, listingID uuid.UUID) (*models.Cart, error)
+	Clear(userID uuid.UUID) error
+}
+
+// cartService implements CartService
+type cartService struct {
+	cartRepo        repositories.CartRepository
+	listingRepo     repositories.ListingRepository
+	reservationRepo repositories.ReservationRepository
+}
+
+// NewCartService creates a new cart service
+func NewCartService(
+	cartRepo repositories.CartRepository,
+	listingRepo repositories.ListingRepository,
+	reservationRepo repositories.ReservationRepository,
+) CartService {
+	return &cartService{
+		cartRepo:        cartRepo,
+		listingRepo:     listingRepo,
+		reservationRepo: reservationRepo,
+	}
+}
+
+// GetCart retrieves a user's cart priced at current listing prices
+func (s *cartService) GetCart(userID uuid.UUID) (*models.Cart, error) {
+	items, err := s.cartRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range items {
+		items[i].Listing.LocalizePickup()
+		items[i].Listing.SetPricing(now)
+	}
+	return models.NewCart(items, now), nil
+}
+
+// SetItem puts qty of a listing in the user's cart, replacing any quantity
+// already there. Stock is only taken at checkout, unless the user reserves it.
+func (s *cartService) SetItem(userID, listingID uuid.UUID, qty int) (*models.Cart, error) {
+	if qty <= 0 {
+		return nil, models.ErrInvalidQuantity
+	}
+
+	listing, err := s.listingRepo.FindByID(listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !listing.IsActive {
+		return nil, models.ErrInvalidInput
+	}
+	if listing.Restaurant.IsSuspended() {
+		return nil, models.ErrRestaurantSuspended
+	}
+	if listing.IsPickupOver(time.Now()) {
+		return nil, models.ErrPickupWindowClosed
+	}
+
+	// Past orders are counted at checkout
+	if listing.ExceedsMaxPerCustomer(0, qty) {
+		return nil, models.ErrListingLimitReached
+	}
+
+	// Units the user holds count as available to them
+	reservations, err := s.reservationRepo.FindActiveByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	held := 0
+	for _, reservation := range reservations {
+		if reservation.ListingID == listingID {
+			held = reservation.Qty
+		}
+	}
+	if !listing.HasStock(qty - held) {
+		return nil, models.ErrInsufficientStock
+	}
+
+	item := &models.CartItem{UserID: userID, ListingID: listingID, Qty: qty}
+	if err := s.cartRepo.SetItem(item, listing.RestaurantID); err != nil {
+		return nil, err
+	}
+	return s.GetCart(userID)
+}
+
+// RemoveItem removes a listing from the user's cart
+func (s *cartService) RemoveItem(userID, listingID uuid.UUID) (*models.Cart, error) {
+	if err := s.cartRepo.RemoveItem(userID, listingID); err != nil {
+		return nil, err
+	}
+	return s.GetCart(userID)
+}
+
+// Clear empties the user's cart
+func (s *cartService) Clear(userID uuid.UUID) error {
+	return s.cartRepo.Clear(userID)
+}
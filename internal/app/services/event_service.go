@@ -0,0 +1,73 @@
+package services
+
+import (
+	"eatright-backend/internal/app/events"
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+)
+
+// EventService subscribes clients to real-time updates they may see
+type EventService interface {
+	SubscribeRestaurantOrders(restaurantID, requesterID uuid.UUID) (*events.Subscription, error)
+	AuthorizeRestaurantOrders(restaurantID, requesterID uuid.UUID) error
+	SubscribeUserOrders(userID uuid.UUID) *events.Subscription
+	SubscribeListingStock(restaurantID *uuid.UUID) *events.Subscription
+	SubscribeWaitlist(userID uuid.UUID) *events.Subscription
+}
+
+// eventService implements EventService
+type eventService struct {
+	bus        events.Bus
+	authorizer RestaurantAuthorizer
+}
+
+// NewEventService creates a new event service
+func NewEventService(bus events.Bus, authorizer RestaurantAuthorizer) EventService {
+	return &eventService{
+		bus:        bus,
+		authorizer: authorizer,
+	}
+}
+
+// SubscribeRestaurantOrders streams a restaurant's order events to members who may view orders
+func (s *eventService) SubscribeRestaurantOrders(restaurantID, requesterID uuid.UUID) (*events.Subscription, error) {
+	if err := s.AuthorizeRestaurantOrders(restaurantID, requesterID); err != nil {
+		return nil, err
+	}
+
+	return s.bus.Subscribe(func(event events.Event) bool {
+		return event.OrderID != nil && event.RestaurantID == restaurantID
+	}), nil
+}
+
+// AuthorizeRestaurantOrders checks that the requester may still view the
+// restaurant's orders; open streams call it again while they stay open
+func (s *eventService) AuthorizeRestaurantOrders(restaurantID, requesterID uuid.UUID) error {
+	_, err := s.authorizer.Authorize(restaurantID, requesterID, models.PermViewOrders)
+	return err
+}
+
+// SubscribeUserOrders streams events about a customer's own orders
+func (s *eventService) SubscribeUserOrders(userID uuid.UUID) *events.Subscription {
+	return s.bus.Subscribe(func(event events.Event) bool {
+		return event.OrderID != nil && event.UserID != nil && *event.UserID == userID
+	})
+}
+
+// SubscribeListingStock streams stock changes, optionally for one restaurant's listings
+func (s *eventService) SubscribeListingStock(restaurantID *uuid.UUID) *events.Subscription {
+	return s.bus.Subscribe(func(event events.Event) bool {
+		if event.Type != events.ListingStockChanged {
+			return false
+		}
+		return restaurantID == nil || event.RestaurantID == *restaurantID
+	})
+}
+
+// SubscribeWaitlist streams a customer's back-in-stock notifications
+func (s *eventService) SubscribeWaitlist(userID uuid.UUID) *events.Subscription {
+	return s.bus.Subscribe(func(event events.Event) bool {
+		return event.Type == events.ListingBackInStock && event.UserID != nil && *event.UserID == userID
+	})
+}
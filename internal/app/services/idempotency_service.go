@@ -0,0 +1,113 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+// idempotencyStaleAfter is how long an in-progress key blocks retries. A request
+// that has not finished by then is assumed to have died with its instance.
+const idempotencyStaleAfter = 2 * time.Minute
+
+// maxIdempotencyKeyLength bounds the Idempotency-Key header
+const maxIdempotencyKeyLength = 255
+
+// idempotencyCompleteAttempts is how often storing a response is tried, with
+// idempotencyCompleteBackoff growing between attempts
+const (
+	idempotencyCompleteAttempts = 3
+	idempotencyCompleteBackoff  = 100 * time.Millisecond
+)
+
+// IdempotencyService handles idempotent replay of requests
+type IdempotencyService interface {
+	Begin(userID uuid.UUID, key, fingerprint string) (*models.IdempotencyKey, error)
+	Complete(key *models.IdempotencyKey, statusCode int, response []byte) error
+	Release(key *models.IdempotencyKey) error
+}
+
+// idempotencyService implements IdempotencyService
+type idempotencyService struct {
+	idempotencyRepo repositories.IdempotencyRepository
+	ttl             time.Duration
+}
+
+// NewIdempotencyService creates a new idempotency service
+func NewIdempotencyService(idempotencyRepo repositories.IdempotencyRepository, cfg *config.Config) IdempotencyService {
+	return &idempotencyService{
+		idempotencyRepo: idempotencyRepo,
+		ttl:             cfg.Orders.IdempotencyKeyTTL,
+	}
+}
+
+// Begin claims a user's idempotency key for a request with the given
+// fingerprint. If the key already holds a completed response for the same
+// request, that record is returned for replay; otherwise the caller owns the
+// returned in-progress key and must Complete or Release it.
+func (s *idempotencyService) Begin(userID uuid.UUID, key, fingerprint string) (*models.IdempotencyKey, error) {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return nil, models.ErrInvalidInput
+	}
+
+	now := time.Now()
+	record := &models.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+
+	existing, err := s.idempotencyRepo.Reserve(record, now.Add(-idempotencyStaleAfter))
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return record, nil
+	}
+
+	if existing.Fingerprint != fingerprint {
+		return nil, models.ErrIdempotencyKeyReused
+	}
+	if existing.IsInProgress() {
+		return nil, models.ErrIdempotencyKeyInUse
+	}
+	if !existing.IsCompleted() {
+		return nil, models.ErrIdempotencyKeyUnknown
+	}
+	return existing, nil
+}
+
+// Complete stores the response of a request so retries replay it. If it cannot
+// be stored, the key is marked as having an unknown outcome instead, so that
+// retries are refused rather than taking over the key and repeating the request.
+func (s *idempotencyService) Complete(key *models.IdempotencyKey, statusCode int, response []byte) error {
+	key.StatusCode = statusCode
+	key.Response = response
+
+	var err error
+	for attempt := 1; attempt <= idempotencyCompleteAttempts; attempt++ {
+		if err = s.idempotencyRepo.Complete(key); err == nil {
+			return nil
+		}
+		if attempt < idempotencyCompleteAttempts {
+			time.Sleep(time.Duration(attempt) * idempotencyCompleteBackoff)
+		}
+	}
+
+	if markErr := s.idempotencyRepo.MarkUnknown(key); markErr != nil {
+		return fmt.Errorf("%w; marking the key failed too: %v", err, markErr)
+	}
+	return err
+}
+
+// Release frees a key whose request failed, so it can be retried
+func (s *idempotencyService) Release(key *models.IdempotencyKey) error {
+	return s.idempotencyRepo.Release(key)
+}
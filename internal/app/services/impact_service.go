@@ -0,0 +1,166 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+// maxImpactBuckets caps the length of an impact time series
+const maxImpactBuckets = 366
+
+// ImpactService estimates food waste avoided by completed orders
+type ImpactService interface {
+	GetUserImpact(userID uuid.UUID) (*models.Impact, error)
+	GetRestaurantImpact(restaurantID uuid.UUID) (*models.Impact, error)
+	SumRestaurantImpact(restaurantID uuid.UUID) (*models.Impact, error)
+	GetPlatformImpact(period models.ImpactPeriod, from, to *models.DateOnly) (*models.PlatformImpact, error)
+}
+
+// impactService implements ImpactService
+type impactService struct {
+	impactRepo     repositories.ImpactRepository
+	restaurantRepo repositories.RestaurantRepository
+	factors        map[models.ListingType]models.ImpactFactor
+}
+
+// NewImpactService creates a new impact service
+func NewImpactService(
+	impactRepo repositories.ImpactRepository,
+	restaurantRepo repositories.RestaurantRepository,
+	cfg *config.Config,
+) ImpactService {
+	return &impactService{
+		impactRepo:     impactRepo,
+		restaurantRepo: restaurantRepo,
+		factors: map[models.ListingType]models.ImpactFactor{
+			models.ListingTypeMysteryBox: {WeightKg: cfg.Impact.MysteryBoxKg, CO2ePerKg: cfg.Impact.MysteryBoxCO2ePerKg},
+			models.ListingTypeReveal:     {WeightKg: cfg.Impact.RevealKg, CO2ePerKg: cfg.Impact.RevealCO2ePerKg},
+		},
+	}
+}
+
+// GetUserImpact estimates the impact of a user's completed orders
+func (s *impactService) GetUserImpact(userID uuid.UUID) (*models.Impact, error) {
+	rows, err := s.impactRepo.ItemsByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	impact := s.sum(rows)
+	return &impact, nil
+}
+
+// GetRestaurantImpact estimates the impact of a restaurant's completed orders
+func (s *impactService) GetRestaurantImpact(restaurantID uuid.UUID) (*models.Impact, error) {
+	if _, err := s.restaurantRepo.FindByID(restaurantID); err != nil {
+		return nil, err
+	}
+
+	return s.SumRestaurantImpact(restaurantID)
+}
+
+// SumRestaurantImpact estimates the impact of a restaurant's completed orders
+// without checking that the restaurant exists
+func (s *impactService) SumRestaurantImpact(restaurantID uuid.UUID) (*models.Impact, error) {
+	rows, err := s.impactRepo.ItemsByRestaurant(restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	impact := s.sum(rows)
+	return &impact, nil
+}
+
+// GetPlatformImpact estimates the platform-wide impact with a time series between
+// from and to (inclusive dates in the platform timezone). Missing bounds default
+// to the last 30 days, 12 weeks or 12 months.
+func (s *impactService) GetPlatformImpact(period models.ImpactPeriod, from, to *models.DateOnly) (*models.PlatformImpact, error) {
+	if !period.IsValid() {
+		return nil, models.ErrInvalidInput
+	}
+
+	loc, err := time.LoadLocation(models.DefaultTimezone)
+	if err != nil {
+		return nil, err
+	}
+
+	end := period.Next(period.Start(time.Now().In(loc)))
+	if to != nil {
+		end = period.Next(period.Start(time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc)))
+	}
+
+	var start time.Time
+	if from != nil {
+		start = period.Start(time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc))
+	} else {
+		switch period {
+		case models.ImpactPeriodDay:
+			start = end.AddDate(0, 0, -30)
+		case models.ImpactPeriodWeek:
+			start = end.AddDate(0, 0, -12*7)
+		case models.ImpactPeriodMonth:
+			start = end.AddDate(0, -12, 0)
+		}
+	}
+
+	// Build empty buckets so periods without orders show as zero
+	var series []models.ImpactPoint
+	index := map[string]int{}
+	for bucket := start; bucket.Before(end); bucket = period.Next(bucket) {
+		if len(series) == maxImpactBuckets {
+			return nil, models.ErrInvalidInput
+		}
+		index[bucket.Format("2006-01-02")] = len(series)
+		series = append(series, models.ImpactPoint{PeriodStart: models.NewDateOnly(bucket)})
+	}
+	if len(series) == 0 {
+		return nil, models.ErrInvalidInput
+	}
+
+	total, err := s.impactRepo.ItemsTotal()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.impactRepo.ItemsSeries(period, models.DefaultTimezone, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		// period_start is a local wall-clock time scanned without a zone
+		if i, ok := index[row.PeriodStart.Format("2006-01-02")]; ok {
+			series[i].Add(row.Items, s.factors[row.Type])
+		}
+	}
+	for i := range series {
+		series[i].Impact = roundImpact(series[i].Impact)
+	}
+
+	return &models.PlatformImpact{
+		Total:  s.sum(total),
+		Period: period,
+		From:   models.NewDateOnly(start),
+		To:     models.NewDateOnly(end.AddDate(0, 0, -1)),
+		Series: series,
+	}, nil
+}
+
+// sum estimates the impact of item counts per listing type
+func (s *impactService) sum(rows []models.ItemsByType) models.Impact {
+	var impact models.Impact
+	for _, row := range rows {
+		impact.Add(row.Items, s.factors[row.Type])
+	}
+	return roundImpact(impact)
+}
+
+// roundImpact rounds the estimates to two decimals
+func roundImpact(impact models.Impact) models.Impact {
+	impact.FoodSavedKg = math.Round(impact.FoodSavedKg*100) / 100
+	impact.CO2eAvoidedKg = math.Round(impact.CO2eAvoidedKg*100) / 100
+	return impact
+}
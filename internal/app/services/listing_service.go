@@ -1,6 +1,9 @@
 package services
 
 import (
+	"time"
+
+	"eatright-backend/internal/app/events"
 	"eatright-backend/internal/app/models"
 	"eatright-backend/internal/app/repositories"
 
@@ -9,38 +12,53 @@ import (
 
 // ListingService handles listing-related business logic
 type ListingService interface {
-	CreateListing(listing *models.Listing, ownerID uuid.UUID) error
+	CreateListing(listing *models.Listing, requesterID uuid.UUID) error
 	GetListingByID(id uuid.UUID) (*models.Listing, error)
 	GetAllListings(activeOnly bool) ([]models.Listing, error)
 	GetListingsByRestaurant(restaurantID uuid.UUID) ([]models.Listing, error)
-	UpdateStock(id uuid.UUID, qty int, ownerID uuid.UUID) error
-	ToggleActive(id uuid.UUID, active bool, ownerID uuid.UUID) error
+	UpdateStock(id uuid.UUID, qty int, requesterID uuid.UUID) error
+	ToggleActive(id uuid.UUID, active bool, requesterID uuid.UUID) error
+	SetPriceSchedule(id uuid.UUID, schedule *models.PriceSchedule, requesterID uuid.UUID) (*models.Listing, error)
+	SetMaxPerCustomer(id uuid.UUID, limit *int, requesterID uuid.UUID) (*models.Listing, error)
 }
 
 // listingService implements ListingService
 type listingService struct {
 	listingRepo    repositories.ListingRepository
 	restaurantRepo repositories.RestaurantRepository
+	authorizer     RestaurantAuthorizer
+	bus            events.Bus
 }
 
 // NewListingService creates a new listing service
-func NewListingService(listingRepo repositories.ListingRepository, restaurantRepo repositories.RestaurantRepository) ListingService {
+func NewListingService(
+	listingRepo repositories.ListingRepository,
+	restaurantRepo repositories.RestaurantRepository,
+	authorizer RestaurantAuthorizer,
+	bus events.Bus,
+) ListingService {
 	return &listingService{
 		listingRepo:    listingRepo,
 		restaurantRepo: restaurantRepo,
+		authorizer:     authorizer,
+		bus:            bus,
 	}
 }
 
 // CreateListing creates a new listing
-func (s *listingService) CreateListing(listing *models.Listing, ownerID uuid.UUID) error {
-	// Verify restaurant exists and belongs to owner
+func (s *listingService) CreateListing(listing *models.Listing, requesterID uuid.UUID) error {
+	// Verify restaurant exists and requester may manage its listings
 	restaurant, err := s.restaurantRepo.FindByID(listing.RestaurantID)
 	if err != nil {
 		return err
 	}
 
-	if restaurant.OwnerID != ownerID {
-		return models.ErrUnauthorized
+	if _, err := s.authorizer.Authorize(restaurant.ID, requesterID, models.PermManageListings); err != nil {
+		return err
+	}
+
+	if restaurant.IsSuspended() {
+		return models.ErrRestaurantSuspended
 	}
 
 	// Validate stock
@@ -48,35 +66,81 @@ func (s *listingService) CreateListing(listing *models.Listing, ownerID uuid.UUI
 		return models.ErrNegativeStock
 	}
 
-	return s.listingRepo.Create(listing)
+	// Pickup window must be in the future and within a single opening interval
+	if !listing.PickupEnd.After(listing.PickupStart) || listing.IsPickupOver(time.Now()) {
+		return models.ErrInvalidPickupWindow
+	}
+	if !restaurant.IsOpenAt(listing.PickupStart) {
+		return models.ErrRestaurantClosed
+	}
+	if !restaurant.IsOpenThroughout(listing.PickupStart, listing.PickupEnd) {
+		return models.ErrInvalidPickupWindow
+	}
+
+	if err := listing.ValidateOriginalValue(); err != nil {
+		return err
+	}
+	if err := listing.ValidateMaxPerCustomer(); err != nil {
+		return err
+	}
+	if listing.PriceSchedule != nil {
+		if err := listing.PriceSchedule.Validate(listing.Price); err != nil {
+			return err
+		}
+	}
+
+	if err := s.listingRepo.Create(listing); err != nil {
+		return err
+	}
+
+	listing.Restaurant = *restaurant
+	listing.LocalizePickup()
+	listing.SetPricing(time.Now())
+	return nil
 }
 
 // GetListingByID retrieves a listing by ID
 func (s *listingService) GetListingByID(id uuid.UUID) (*models.Listing, error) {
-	return s.listingRepo.FindByID(id)
+	listing, err := s.listingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	listing.LocalizePickup()
+	listing.SetPricing(time.Now())
+	return listing, nil
 }
 
 // GetAllListings retrieves all listings
 func (s *listingService) GetAllListings(activeOnly bool) ([]models.Listing, error) {
-	return s.listingRepo.FindAll(activeOnly)
+	listings, err := s.listingRepo.FindAll(activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	presentListings(listings)
+	return listings, nil
 }
 
 // GetListingsByRestaurant retrieves all listings for a restaurant
 func (s *listingService) GetListingsByRestaurant(restaurantID uuid.UUID) ([]models.Listing, error) {
-	return s.listingRepo.FindByRestaurantID(restaurantID)
+	listings, err := s.listingRepo.FindByRestaurantID(restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	presentListings(listings)
+	return listings, nil
 }
 
 // UpdateStock updates the stock of a listing
-func (s *listingService) UpdateStock(id uuid.UUID, qty int, ownerID uuid.UUID) error {
+func (s *listingService) UpdateStock(id uuid.UUID, qty int, requesterID uuid.UUID) error {
 	// Get listing with restaurant
 	listing, err := s.listingRepo.FindByID(id)
 	if err != nil {
 		return err
 	}
 
-	// Verify ownership
-	if listing.Restaurant.OwnerID != ownerID {
-		return models.ErrUnauthorized
+	// Verify permission
+	if err := s.authorizeChange(listing, requesterID, models.PermUpdateStock); err != nil {
+		return err
 	}
 
 	// Validate stock won't go negative
@@ -84,21 +148,119 @@ func (s *listingService) UpdateStock(id uuid.UUID, qty int, ownerID uuid.UUID) e
 		return models.ErrNegativeStock
 	}
 
-	return s.listingRepo.UpdateStock(id, qty)
+	stock, err := s.listingRepo.UpdateStock(id, qty)
+	if err != nil {
+		return err
+	}
+
+	listing.Stock = stock
+	s.bus.Publish(events.StockEvent(listing))
+	return nil
 }
 
-// ToggleActive toggles the active status of a listing
-func (s *listingService) ToggleActive(id uuid.UUID, active bool, ownerID uuid.UUID) error {
+// ToggleActive toggles the active status of a listing. Listings of deleted
+// restaurants cannot be reactivated, and listings an admin force-deactivated
+// cannot be changed by the restaurant at all.
+func (s *listingService) ToggleActive(id uuid.UUID, active bool, requesterID uuid.UUID) error {
 	// Get listing with restaurant
 	listing, err := s.listingRepo.FindByID(id)
 	if err != nil {
 		return err
 	}
 
-	// Verify ownership
-	if listing.Restaurant.OwnerID != ownerID {
-		return models.ErrUnauthorized
+	// Verify permission
+	if err := s.authorizeChange(listing, requesterID, models.PermManageListings); err != nil {
+		return err
+	}
+
+	if listing.IsDeactivatedByAdmin() {
+		return models.ErrDeactivatedByAdmin
+	}
+
+	// Verify restaurant still exists, as when creating a listing
+	if active {
+		if _, err := s.restaurantRepo.FindByID(listing.RestaurantID); err != nil {
+			return err
+		}
 	}
 
 	return s.listingRepo.ToggleActive(id, active)
 }
+
+// SetPriceSchedule sets or, with a nil schedule, removes a listing's markdown schedule
+func (s *listingService) SetPriceSchedule(id uuid.UUID, schedule *models.PriceSchedule, requesterID uuid.UUID) (*models.Listing, error) {
+	listing, err := s.listingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeChange(listing, requesterID, models.PermManageListings); err != nil {
+		return nil, err
+	}
+
+	if schedule != nil {
+		if err := schedule.Validate(listing.Price); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.listingRepo.SetPriceSchedule(id, schedule); err != nil {
+		return nil, err
+	}
+
+	listing.PriceSchedule = schedule
+	listing.LocalizePickup()
+	listing.SetPricing(time.Now())
+	return listing, nil
+}
+
+// SetMaxPerCustomer sets or, with a nil limit, removes a listing's per-customer
+// limit. Orders already placed are kept even if they exceed a lowered limit.
+func (s *listingService) SetMaxPerCustomer(id uuid.UUID, limit *int, requesterID uuid.UUID) (*models.Listing, error) {
+	listing, err := s.listingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeChange(listing, requesterID, models.PermManageListings); err != nil {
+		return nil, err
+	}
+
+	listing.MaxPerCustomer = limit
+	if err := listing.ValidateMaxPerCustomer(); err != nil {
+		return nil, err
+	}
+
+	if err := s.listingRepo.SetMaxPerCustomer(id, limit); err != nil {
+		return nil, err
+	}
+
+	listing.LocalizePickup()
+	listing.SetPricing(time.Now())
+	return listing, nil
+}
+
+// authorizeChange checks that the requester holds permission for the listing's
+// restaurant, loaded with the listing, and that the restaurant has not been
+// suspended; listings of suspended restaurants cannot be changed
+func (s *listingService) authorizeChange(listing *models.Listing, requesterID uuid.UUID, permission models.Permission) error {
+	if _, err := s.authorizer.Authorize(listing.RestaurantID, requesterID, permission); err != nil {
+		return err
+	}
+
+	if listing.Restaurant.IsSuspended() {
+		return models.ErrRestaurantSuspended
+	}
+
+	return nil
+}
+
+// presentListings expresses each listing's pickup window in its restaurant's
+// timezone and computes its current price
+func presentListings(listings []models.Listing) {
+	now := time.Now()
+	for i := range listings {
+		listings[i].LocalizePickup()
+		listings[i].SetPricing(now)
+	}
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"testing"
+
+	"eatright-backend/internal/app/events"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// newListingService makes the fixture's owner a member of the restaurant and
+// creates a listing service checking permissions against memberships
+func (f *paymentFixture) newListingService(t *testing.T) ListingService {
+	t.Helper()
+	member := models.RestaurantMember{RestaurantID: f.restaurant.ID, UserID: f.owner.ID, Role: models.MemberRoleOwner}
+	if err := f.db.Omit(clause.Associations).Create(&member).Error; err != nil {
+		t.Fatalf("create membership: %v", err)
+	}
+	return NewListingService(
+		repositories.NewListingRepository(f.db),
+		repositories.NewRestaurantRepository(f.db),
+		NewRestaurantAuthorizer(repositories.NewMembershipRepository(f.db)),
+		events.NewLocalBus(),
+	)
+}
+
+// findListing loads the fixture's listing
+func (f *paymentFixture) findListing(t *testing.T) *models.Listing {
+	t.Helper()
+	var listing models.Listing
+	if err := f.db.Where("id = ?", f.listing.ID).First(&listing).Error; err != nil {
+		t.Fatalf("find listing: %v", err)
+	}
+	return &listing
+}
+
+func TestToggleActiveRefusesAdminDeactivatedListing(t *testing.T) {
+	f := newPaymentFixture(t, 5)
+	listings := f.newListingService(t)
+	listingRepo := repositories.NewListingRepository(f.db)
+
+	// The restaurant may switch its own listing off and on again
+	if err := listings.ToggleActive(f.listing.ID, false, f.owner.ID); err != nil {
+		t.Fatalf("deactivate listing: %v", err)
+	}
+	if err := listings.ToggleActive(f.listing.ID, true, f.owner.ID); err != nil {
+		t.Fatalf("reactivate listing: %v", err)
+	}
+
+	if err := listingRepo.Deactivate(f.listing.ID, models.DeactivationAdmin); err != nil {
+		t.Fatalf("force-deactivate listing: %v", err)
+	}
+	for _, active := range []bool{true, false} {
+		if err := listings.ToggleActive(f.listing.ID, active, f.owner.ID); err != models.ErrDeactivatedByAdmin {
+			t.Errorf("toggle to %v: err = %v, want ErrDeactivatedByAdmin", active, err)
+		}
+	}
+
+	listing := f.findListing(t)
+	if listing.IsActive || !listing.IsDeactivatedByAdmin() {
+		t.Errorf("listing active = %v, reason = %v, want deactivated by an admin", listing.IsActive, listing.DeactivationReason)
+	}
+}
+
+// addListing creates another listing of the fixture's restaurant
+func (f *paymentFixture) addListing(t *testing.T, stock int) models.Listing {
+	t.Helper()
+	listing := f.listing
+	listing.ID = uuid.Nil
+	listing.Description = "Day-old bread"
+	listing.Stock = stock
+	if err := f.db.Omit(clause.Associations).Create(&listing).Error; err != nil {
+		t.Fatalf("create listing: %v", err)
+	}
+	return listing
+}
+
+// addCustomer creates another customer, deleted when the test ends
+func (f *paymentFixture) addCustomer(t *testing.T) models.User {
+	t.Helper()
+	customer := models.User{Name: "Other customer", Email: "other-" + uuid.New().String() + "@example.com"}
+	if err := f.db.Omit(clause.Associations).Create(&customer).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	t.Cleanup(func() {
+		f.db.Where("id = ?", customer.ID).Delete(&models.User{})
+	})
+	return customer
+}
+
+// orderFor creates an order of items for customerID against the listings as
+// currently stored, including their per-customer limits
+func (f *paymentFixture) orderFor(t *testing.T, customerID uuid.UUID, items ...models.OrderItem) (*models.Order, error) {
+	t.Helper()
+	listingRepo := repositories.NewListingRepository(f.db)
+	listings := make(map[uuid.UUID]*models.Listing, len(items))
+	for _, item := range items {
+		listing, err := listingRepo.FindByID(item.ListingID)
+		if err != nil {
+			t.Fatalf("find listing: %v", err)
+		}
+		listings[item.ListingID] = listing
+	}
+	order := &models.Order{
+		UserID:       customerID,
+		RestaurantID: f.restaurant.ID,
+		Restaurant:   f.restaurant,
+		Status:       models.OrderStatusAwaitingPayment,
+		PickupStart:  f.listing.PickupStart,
+		PickupEnd:    f.listing.PickupEnd,
+		PickupCode:   "TEST01",
+		Items:        items,
+	}
+	if err := f.orderRepo.Create(order, listings); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func TestMaxPerCustomerCountsOnlyCustomersOpenOrders(t *testing.T) {
+	f := newPaymentFixture(t, 20)
+	listings := f.newListingService(t)
+	bread := f.addListing(t, 20)
+	other := f.addCustomer(t)
+
+	limit := 3
+	if _, err := listings.SetMaxPerCustomer(f.listing.ID, &limit, f.owner.ID); err != nil {
+		t.Fatalf("set limit: %v", err)
+	}
+	box := func(qty int) models.OrderItem { return models.OrderItem{ListingID: f.listing.ID, Qty: qty} }
+	loaves := func(qty int) models.OrderItem { return models.OrderItem{ListingID: bread.ID, Qty: qty} }
+
+	// Units of other listings in the same order do not count
+	first, err := f.orderFor(t, f.customer.ID, box(2), loaves(5))
+	if err != nil {
+		t.Fatalf("first order: %v", err)
+	}
+	// Neither do other customers' orders
+	if _, err := f.orderFor(t, other.ID, box(3)); err != nil {
+		t.Fatalf("other customer's order: %v", err)
+	}
+
+	if _, err := f.orderFor(t, f.customer.ID, box(2)); err != models.ErrListingLimitReached {
+		t.Errorf("order over the limit: err = %v, want ErrListingLimitReached", err)
+	}
+	if _, err := f.orderFor(t, f.customer.ID, loaves(1), box(1)); err != nil {
+		t.Fatalf("order up to the limit: %v", err)
+	}
+	if _, err := f.orderFor(t, f.customer.ID, box(1)); err != models.ErrListingLimitReached {
+		t.Errorf("order past the limit: err = %v, want ErrListingLimitReached", err)
+	}
+
+	// Cancelled orders no longer count
+	_, err = f.orderRepo.Transition(first.ID, models.OrderTransition{
+		To:      models.OrderStatusCancelled,
+		Actor:   models.OrderActorCustomer,
+		ActorID: &f.customer.ID,
+	})
+	if err != nil {
+		t.Fatalf("cancel order: %v", err)
+	}
+	if _, err := f.orderFor(t, f.customer.ID, box(2)); err != nil {
+		t.Errorf("order after cancelling: %v", err)
+	}
+}
+
+func TestLoweringMaxPerCustomerKeepsExistingOrders(t *testing.T) {
+	f := newPaymentFixture(t, 20)
+	listings := f.newListingService(t)
+
+	order, err := f.orderFor(t, f.customer.ID, models.OrderItem{ListingID: f.listing.ID, Qty: 4})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	limit := 2
+	listing, err := listings.SetMaxPerCustomer(f.listing.ID, &limit, f.owner.ID)
+	if err != nil {
+		t.Fatalf("lower limit: %v", err)
+	}
+	if listing.MaxPerCustomer == nil || *listing.MaxPerCustomer != 2 {
+		t.Errorf("limit = %v, want 2", listing.MaxPerCustomer)
+	}
+
+	kept := f.reload(t, order.ID)
+	if kept.Status != models.OrderStatusAwaitingPayment || len(kept.Items) != 1 || kept.Items[0].Qty != 4 {
+		t.Errorf("existing order: status = %s, items = %+v, want it unchanged", kept.Status, kept.Items)
+	}
+	if stock := f.stock(t); stock != 16 {
+		t.Errorf("stock = %d, want 16", stock)
+	}
+
+	// The customer is already past the new limit, so further units are refused
+	if _, err := f.orderFor(t, f.customer.ID, models.OrderItem{ListingID: f.listing.ID, Qty: 1}); err != models.ErrListingLimitReached {
+		t.Errorf("order after lowering: err = %v, want ErrListingLimitReached", err)
+	}
+
+	if _, err := listings.SetMaxPerCustomer(f.listing.ID, nil, f.owner.ID); err != nil {
+		t.Fatalf("remove limit: %v", err)
+	}
+	if _, err := f.orderFor(t, f.customer.ID, models.OrderItem{ListingID: f.listing.ID, Qty: 1}); err != nil {
+		t.Errorf("order after removing the limit: %v", err)
+	}
+}
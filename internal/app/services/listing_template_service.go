@@ -0,0 +1,161 @@
+package services
+
+import (
+	"time"
+
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+// ListingTemplateService handles recurring listing templates
+type ListingTemplateService interface {
+	CreateTemplate(template *models.ListingTemplate, requesterID uuid.UUID) error
+	GetTemplates(restaurantID uuid.UUID, requesterID uuid.UUID) ([]models.ListingTemplate, error)
+	UpdateTemplate(id uuid.UUID, update models.ListingTemplateUpdate, requesterID uuid.UUID) (*models.ListingTemplate, error)
+	DeleteTemplate(id uuid.UUID, requesterID uuid.UUID) error
+	SetOverride(override *models.ListingOccurrenceOverride, requesterID uuid.UUID) error
+	RemoveOverride(templateID uuid.UUID, date models.DateOnly, requesterID uuid.UUID) error
+}
+
+// listingTemplateService implements ListingTemplateService
+type listingTemplateService struct {
+	templateRepo   repositories.ListingTemplateRepository
+	listingRepo    repositories.ListingRepository
+	restaurantRepo repositories.RestaurantRepository
+	authorizer     RestaurantAuthorizer
+}
+
+// NewListingTemplateService creates a new listing template service
+func NewListingTemplateService(
+	templateRepo repositories.ListingTemplateRepository,
+	listingRepo repositories.ListingRepository,
+	restaurantRepo repositories.RestaurantRepository,
+	authorizer RestaurantAuthorizer,
+) ListingTemplateService {
+	return &listingTemplateService{
+		templateRepo:   templateRepo,
+		listingRepo:    listingRepo,
+		restaurantRepo: restaurantRepo,
+		authorizer:     authorizer,
+	}
+}
+
+// CreateTemplate creates a new template; occurrences are created by the scheduler
+func (s *listingTemplateService) CreateTemplate(template *models.ListingTemplate, requesterID uuid.UUID) error {
+	restaurant, err := s.restaurantRepo.FindByID(template.RestaurantID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.authorizer.Authorize(restaurant.ID, requesterID, models.PermManageListings); err != nil {
+		return err
+	}
+
+	if restaurant.IsSuspended() {
+		return models.ErrRestaurantSuspended
+	}
+
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
+	template.CreatedBy = requesterID
+	return s.templateRepo.Create(template)
+}
+
+// GetTemplates retrieves a restaurant's templates
+func (s *listingTemplateService) GetTemplates(restaurantID uuid.UUID, requesterID uuid.UUID) ([]models.ListingTemplate, error) {
+	if _, err := s.authorizer.Authorize(restaurantID, requesterID, models.PermManageListings); err != nil {
+		return nil, err
+	}
+
+	return s.templateRepo.FindByRestaurantID(restaurantID)
+}
+
+// UpdateTemplate updates a template; occurrences already created are not changed
+func (s *listingTemplateService) UpdateTemplate(id uuid.UUID, update models.ListingTemplateUpdate, requesterID uuid.UUID) (*models.ListingTemplate, error) {
+	template, err := s.templateRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.authorizer.Authorize(template.RestaurantID, requesterID, models.PermManageListings); err != nil {
+		return nil, err
+	}
+
+	update.Apply(template)
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Update(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// DeleteTemplate deletes a template; listings already created from it are kept
+func (s *listingTemplateService) DeleteTemplate(id uuid.UUID, requesterID uuid.UUID) error {
+	template, err := s.templateRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.authorizer.Authorize(template.RestaurantID, requesterID, models.PermManageListings); err != nil {
+		return err
+	}
+
+	return s.templateRepo.Delete(id)
+}
+
+// SetOverride skips or changes the stock of a single upcoming occurrence
+func (s *listingTemplateService) SetOverride(override *models.ListingOccurrenceOverride, requesterID uuid.UUID) error {
+	template, err := s.templateRepo.FindByID(override.TemplateID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.authorizer.Authorize(template.RestaurantID, requesterID, models.PermManageListings); err != nil {
+		return err
+	}
+
+	if override.Stock != nil && *override.Stock < 0 {
+		return models.ErrNegativeStock
+	}
+
+	restaurant, err := s.restaurantRepo.FindByID(template.RestaurantID)
+	if err != nil {
+		return err
+	}
+
+	// Only today and later, in the restaurant's timezone
+	today := models.NewDateOnly(time.Now().In(restaurant.Location()))
+	if override.Date.Before(today.Time) {
+		return models.ErrInvalidInput
+	}
+
+	// Once created, the occurrence is an ordinary listing and is managed as such
+	if _, err := s.listingRepo.FindOccurrence(template.ID, override.Date); err == nil {
+		return models.ErrOccurrenceCreated
+	} else if err != models.ErrNotFound {
+		return err
+	}
+
+	return s.templateRepo.UpsertOverride(override)
+}
+
+// RemoveOverride restores the template defaults for an occurrence
+func (s *listingTemplateService) RemoveOverride(templateID uuid.UUID, date models.DateOnly, requesterID uuid.UUID) error {
+	template, err := s.templateRepo.FindByID(templateID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.authorizer.Authorize(template.RestaurantID, requesterID, models.PermManageListings); err != nil {
+		return err
+	}
+
+	return s.templateRepo.DeleteOverride(templateID, date)
+}
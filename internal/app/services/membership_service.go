@@ -0,0 +1,256 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+// invitationTTL is how long an invitation can be accepted
+const invitationTTL = 7 * 24 * time.Hour
+
+// MembershipService handles restaurant staff membership and invitations
+type MembershipService interface {
+	GetMembers(restaurantID uuid.UUID, requesterID uuid.UUID) ([]models.RestaurantMember, error)
+	InviteMember(restaurantID uuid.UUID, email string, role models.MemberRole, requesterID uuid.UUID) (*models.RestaurantInvitation, error)
+	GetRestaurantInvitations(restaurantID uuid.UUID, requesterID uuid.UUID) ([]models.RestaurantInvitation, error)
+	RevokeInvitation(restaurantID uuid.UUID, invitationID uuid.UUID, requesterID uuid.UUID) error
+	GetMyInvitations(userID uuid.UUID) ([]models.RestaurantInvitation, error)
+	AcceptInvitation(invitationID uuid.UUID, userID uuid.UUID) (*models.RestaurantMember, error)
+	DeclineInvitation(invitationID uuid.UUID, userID uuid.UUID) error
+	UpdateMemberRole(restaurantID uuid.UUID, userID uuid.UUID, role models.MemberRole, requesterID uuid.UUID) error
+	RemoveMember(restaurantID uuid.UUID, userID uuid.UUID, requesterID uuid.UUID) error
+}
+
+// membershipService implements MembershipService
+type membershipService struct {
+	membershipRepo repositories.MembershipRepository
+	userRepo       repositories.UserRepository
+	authorizer     RestaurantAuthorizer
+}
+
+// NewMembershipService creates a new membership service
+func NewMembershipService(
+	membershipRepo repositories.MembershipRepository,
+	userRepo repositories.UserRepository,
+	authorizer RestaurantAuthorizer,
+) MembershipService {
+	return &membershipService{
+		membershipRepo: membershipRepo,
+		userRepo:       userRepo,
+		authorizer:     authorizer,
+	}
+}
+
+// GetMembers lists the members of a restaurant
+func (s *membershipService) GetMembers(restaurantID uuid.UUID, requesterID uuid.UUID) ([]models.RestaurantMember, error) {
+	if _, err := s.authorizer.Authorize(restaurantID, requesterID, models.PermViewMembers); err != nil {
+		return nil, err
+	}
+	return s.membershipRepo.FindMembers(restaurantID)
+}
+
+// InviteMember invites a user by email; inviters can only grant roles below their own
+func (s *membershipService) InviteMember(restaurantID uuid.UUID, email string, role models.MemberRole, requesterID uuid.UUID) (*models.RestaurantInvitation, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || !role.IsValid() || role == models.MemberRoleOwner {
+		return nil, models.ErrInvalidInput
+	}
+
+	inviter, err := s.authorizer.Authorize(restaurantID, requesterID, models.PermManageMembers)
+	if err != nil {
+		return nil, err
+	}
+	if !inviter.Role.Outranks(role) {
+		return nil, models.ErrUnauthorized
+	}
+
+	// Existing members cannot be invited again
+	if user, err := s.userRepo.FindByEmail(email); err == nil {
+		if _, err := s.membershipRepo.FindMember(restaurantID, user.ID); err == nil {
+			return nil, models.ErrDuplicateEntry
+		} else if err != models.ErrNotFound {
+			return nil, err
+		}
+	} else if err != models.ErrNotFound {
+		return nil, err
+	}
+
+	pending, err := s.membershipRepo.HasPendingInvitation(restaurantID, email)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, models.ErrDuplicateEntry
+	}
+
+	invitation := &models.RestaurantInvitation{
+		RestaurantID: restaurantID,
+		Email:        email,
+		Role:         role,
+		Status:       models.InvitationStatusPending,
+		InvitedBy:    requesterID,
+		ExpiresAt:    time.Now().Add(invitationTTL),
+	}
+	if err := s.membershipRepo.CreateInvitation(invitation); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// GetRestaurantInvitations lists the invitations sent by a restaurant
+func (s *membershipService) GetRestaurantInvitations(restaurantID uuid.UUID, requesterID uuid.UUID) ([]models.RestaurantInvitation, error) {
+	if _, err := s.authorizer.Authorize(restaurantID, requesterID, models.PermManageMembers); err != nil {
+		return nil, err
+	}
+	return s.membershipRepo.FindInvitationsByRestaurant(restaurantID)
+}
+
+// RevokeInvitation cancels a pending invitation
+func (s *membershipService) RevokeInvitation(restaurantID uuid.UUID, invitationID uuid.UUID, requesterID uuid.UUID) error {
+	if _, err := s.authorizer.Authorize(restaurantID, requesterID, models.PermManageMembers); err != nil {
+		return err
+	}
+
+	invitation, err := s.membershipRepo.FindInvitationByID(invitationID)
+	if err != nil {
+		return err
+	}
+	if invitation.RestaurantID != restaurantID {
+		return models.ErrNotFound
+	}
+
+	return s.respond(invitation, models.InvitationStatusRevoked)
+}
+
+// GetMyInvitations lists the pending invitations addressed to the user's email
+func (s *membershipService) GetMyInvitations(userID uuid.UUID) ([]models.RestaurantInvitation, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.membershipRepo.FindPendingInvitationsByEmail(user.Email)
+}
+
+// AcceptInvitation adds the user to the restaurant with the invited role
+func (s *membershipService) AcceptInvitation(invitationID uuid.UUID, userID uuid.UUID) (*models.RestaurantMember, error) {
+	invitation, err := s.invitationForUser(invitationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.IsExpired() {
+		return nil, models.ErrInvitationExpired
+	}
+
+	now := time.Now()
+	invitation.Status = models.InvitationStatusAccepted
+	invitation.RespondedAt = &now
+
+	inviterID := invitation.InvitedBy
+	member := &models.RestaurantMember{
+		RestaurantID: invitation.RestaurantID,
+		UserID:       userID,
+		Role:         invitation.Role,
+		InvitedBy:    &inviterID,
+	}
+	if err := s.membershipRepo.AcceptInvitation(invitation, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// DeclineInvitation declines an invitation addressed to the user
+func (s *membershipService) DeclineInvitation(invitationID uuid.UUID, userID uuid.UUID) error {
+	invitation, err := s.invitationForUser(invitationID, userID)
+	if err != nil {
+		return err
+	}
+	return s.respond(invitation, models.InvitationStatusDeclined)
+}
+
+// UpdateMemberRole changes a member's role; the requester must outrank both the current and new role
+func (s *membershipService) UpdateMemberRole(restaurantID uuid.UUID, userID uuid.UUID, role models.MemberRole, requesterID uuid.UUID) error {
+	if !role.IsValid() {
+		return models.ErrInvalidInput
+	}
+	if role == models.MemberRoleOwner {
+		return models.ErrOwnerRequired
+	}
+
+	requester, err := s.authorizer.Authorize(restaurantID, requesterID, models.PermManageMembers)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.membershipRepo.FindMember(restaurantID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role == models.MemberRoleOwner {
+		return models.ErrOwnerRequired
+	}
+	if !requester.Role.Outranks(member.Role) || !requester.Role.Outranks(role) {
+		return models.ErrUnauthorized
+	}
+
+	return s.membershipRepo.UpdateMemberRole(restaurantID, userID, role)
+}
+
+// RemoveMember removes a member; members may always remove themselves, except the owner
+func (s *membershipService) RemoveMember(restaurantID uuid.UUID, userID uuid.UUID, requesterID uuid.UUID) error {
+	member, err := s.membershipRepo.FindMember(restaurantID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role == models.MemberRoleOwner {
+		return models.ErrOwnerRequired
+	}
+
+	if userID != requesterID {
+		requester, err := s.authorizer.Authorize(restaurantID, requesterID, models.PermManageMembers)
+		if err != nil {
+			return err
+		}
+		if !requester.Role.Outranks(member.Role) {
+			return models.ErrUnauthorized
+		}
+	}
+
+	return s.membershipRepo.RemoveMember(restaurantID, userID)
+}
+
+// invitationForUser loads an invitation and checks it was addressed to the user
+func (s *membershipService) invitationForUser(invitationID uuid.UUID, userID uuid.UUID) (*models.RestaurantInvitation, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := s.membershipRepo.FindInvitationByID(invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if !invitation.IsFor(user.Email) {
+		return nil, models.ErrNotFound
+	}
+
+	return invitation, nil
+}
+
+// respond moves a pending invitation to a final status
+func (s *membershipService) respond(invitation *models.RestaurantInvitation, status models.InvitationStatus) error {
+	if invitation.Status != models.InvitationStatusPending {
+		return models.ErrInvalidStatusTransition
+	}
+
+	now := time.Now()
+	invitation.Status = status
+	invitation.RespondedAt = &now
+	return s.membershipRepo.UpdateInvitationStatus(invitation, models.InvitationStatusPending)
+}
@@ -1,8 +1,14 @@
 package services
 
 import (
+	"log"
+	"time"
+
+	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/events"
 	"eatright-backend/internal/app/models"
 	"eatright-backend/internal/app/repositories"
+	"eatright-backend/internal/app/utils"
 
 	"github.com/google/uuid"
 )
@@ -10,57 +16,249 @@ import (
 // OrderService handles order-related business logic
 type OrderService interface {
 	CreateOrder(order *models.Order) error
+	Checkout(userID uuid.UUID) (*models.Order, error)
 	GetOrderByID(id uuid.UUID) (*models.Order, error)
+	GetOrderForUser(id uuid.UUID, requesterID uuid.UUID) (*models.Order, error)
 	GetUserOrders(userID uuid.UUID) ([]models.Order, error)
-	GetRestaurantOrders(restaurantID uuid.UUID) ([]models.Order, error)
-	UpdateOrderStatus(id uuid.UUID, status models.OrderStatus, requesterID uuid.UUID) error
+	GetRestaurantOrders(restaurantID uuid.UUID, filter repositories.OrderFilter, requesterID uuid.UUID) ([]models.Order, int64, error)
+	GetOrderBoard(restaurantID uuid.UUID, date *models.DateOnly, requesterID uuid.UUID) (*models.OrderBoard, error)
+	UpdateOrderStatus(id uuid.UUID, status models.OrderStatus, reason string, requesterID uuid.UUID) error
+	CancelOrder(id uuid.UUID, reason string, requesterID uuid.UUID) error
+	VerifyPickup(id uuid.UUID, code, qrPayload string, requesterID uuid.UUID) (*models.Order, error)
 }
 
 // orderService implements OrderService
 type orderService struct {
-	orderRepo      repositories.OrderRepository
-	listingRepo    repositories.ListingRepository
-	restaurantRepo repositories.RestaurantRepository
+	orderRepo       repositories.OrderRepository
+	listingRepo     repositories.ListingRepository
+	cartRepo        repositories.CartRepository
+	reservationRepo repositories.ReservationRepository
+	restaurantRepo  repositories.RestaurantRepository
+	paymentService  PaymentService
+	authorizer      RestaurantAuthorizer
+	bus             events.Bus
+	cfg             config.OrderConfig
 }
 
 // NewOrderService creates a new order service
 func NewOrderService(
 	orderRepo repositories.OrderRepository,
 	listingRepo repositories.ListingRepository,
+	cartRepo repositories.CartRepository,
+	reservationRepo repositories.ReservationRepository,
 	restaurantRepo repositories.RestaurantRepository,
+	paymentService PaymentService,
+	authorizer RestaurantAuthorizer,
+	bus events.Bus,
+	cfg *config.Config,
 ) OrderService {
 	return &orderService{
-		orderRepo:      orderRepo,
-		listingRepo:    listingRepo,
-		restaurantRepo: restaurantRepo,
+		orderRepo:       orderRepo,
+		listingRepo:     listingRepo,
+		cartRepo:        cartRepo,
+		reservationRepo: reservationRepo,
+		restaurantRepo:  restaurantRepo,
+		paymentService:  paymentService,
+		authorizer:      authorizer,
+		bus:             bus,
+		cfg:             cfg.Orders,
 	}
 }
 
-// CreateOrder creates a new order
+// CreateOrder creates a new order for the items in order.Items
 func (s *orderService) CreateOrder(order *models.Order) error {
-	// Get listing to check stock and get price
-	listing, err := s.listingRepo.FindByID(order.ListingID)
+	listings, err := s.prepareOrder(order)
 	if err != nil {
 		return err
 	}
 
-	// Validate listing is active
-	if !listing.IsActive {
-		return models.ErrInvalidInput
+	// Create order (repository will handle stock decrement in transaction)
+	if err := s.orderRepo.Create(order, listings); err != nil {
+		return err
 	}
 
-	// Validate quantity
-	if order.Qty <= 0 {
-		return models.ErrInvalidQuantity
+	if err := s.startPayment(order); err != nil {
+		return err
 	}
 
-	// Check stock availability
-	if !listing.HasStock(order.Qty) {
-		return models.ErrInsufficientStock
+	s.publishCreated(order)
+	s.presentPickup(order)
+	return nil
+}
+
+// Checkout orders everything in the user's cart and empties it
+func (s *orderService) Checkout(userID uuid.UUID) (*models.Order, error) {
+	cart, err := s.cartRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart) == 0 {
+		return nil, models.ErrCartEmpty
 	}
 
-	// Create order (repository will handle stock decrement in transaction)
-	return s.orderRepo.Create(order, listing)
+	order := &models.Order{UserID: userID}
+	for _, item := range cart {
+		order.Items = append(order.Items, models.OrderItem{ListingID: item.ListingID, Qty: item.Qty})
+	}
+
+	listings, err := s.prepareOrder(order)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stock is decremented and the cart emptied in one transaction
+	if err := s.orderRepo.Checkout(order, listings); err != nil {
+		return nil, err
+	}
+
+	if err := s.startPayment(order); err != nil {
+		return nil, err
+	}
+
+	s.publishCreated(order)
+	s.presentPickup(order)
+	return order, nil
+}
+
+// prepareOrder validates an order's items, which must all be orderable listings
+// of one restaurant with a pickup time in common, and sets the restaurant,
+// pickup window, pickup code and, when payments are enabled, the
+// awaiting_payment status. It returns the items' listings by ID.
+func (s *orderService) prepareOrder(order *models.Order) (map[uuid.UUID]*models.Listing, error) {
+	if len(order.Items) == 0 {
+		return nil, models.ErrInvalidQuantity
+	}
+
+	// Units the customer holds are no longer in stock but are theirs to order
+	held, err := s.heldByListing(order.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	listings := make(map[uuid.UUID]*models.Listing, len(order.Items))
+	var restaurant *models.Restaurant
+	for i, item := range order.Items {
+		// Validate quantity
+		if item.Qty <= 0 {
+			return nil, models.ErrInvalidQuantity
+		}
+		if _, ok := listings[item.ListingID]; ok {
+			return nil, models.ErrInvalidInput
+		}
+
+		// Get listing to check stock and get price
+		listing, err := s.listingRepo.FindByID(item.ListingID)
+		if err != nil {
+			return nil, err
+		}
+		listings[item.ListingID] = listing
+
+		// Validate listing is active
+		if !listing.IsActive {
+			return nil, models.ErrInvalidInput
+		}
+
+		if listing.Restaurant.IsSuspended() {
+			return nil, models.ErrRestaurantSuspended
+		}
+
+		// Refuse orders once the pickup window has ended
+		if listing.IsPickupOver(now) {
+			return nil, models.ErrPickupWindowClosed
+		}
+
+		// Check stock availability
+		if !listing.HasStock(item.Qty - held[item.ListingID]) {
+			return nil, models.ErrInsufficientStock
+		}
+
+		// Every item is collected together, so the order's window is the
+		// intersection of the listings' windows
+		if i == 0 {
+			restaurant = &listing.Restaurant
+			order.PickupStart = listing.PickupStart
+			order.PickupEnd = listing.PickupEnd
+			continue
+		}
+		if listing.RestaurantID != restaurant.ID {
+			return nil, models.ErrCartRestaurantMismatch
+		}
+		if listing.PickupStart.After(order.PickupStart) {
+			order.PickupStart = listing.PickupStart
+		}
+		if listing.PickupEnd.Before(order.PickupEnd) {
+			order.PickupEnd = listing.PickupEnd
+		}
+	}
+
+	if !order.PickupStart.Before(order.PickupEnd) {
+		return nil, models.ErrPickupWindowsDisjoint
+	}
+
+	// Refuse orders whose pickup falls outside opening hours, e.g. on a holiday
+	if !restaurant.IsOpenAt(order.PickupStart) {
+		return nil, models.ErrRestaurantClosed
+	}
+
+	// Customer shows this code at the counter to collect the order
+	code, err := utils.GeneratePickupCode()
+	if err != nil {
+		return nil, err
+	}
+	order.PickupCode = code
+	order.RestaurantID = restaurant.ID
+	order.Restaurant = *restaurant
+
+	// Held until paid; the repository makes free orders pending
+	if s.paymentService.Enabled() {
+		order.Status = models.OrderStatusAwaitingPayment
+	}
+	return listings, nil
+}
+
+// startPayment starts the payment of a new order awaiting payment. If the
+// payment cannot be started the order is cancelled, restoring its stock.
+func (s *orderService) startPayment(order *models.Order) error {
+	if order.Status != models.OrderStatusAwaitingPayment {
+		return nil
+	}
+
+	err := s.paymentService.StartPayment(order)
+	if err == nil {
+		return nil
+	}
+
+	reason := "Payment could not be started"
+	if _, cancelErr := s.orderRepo.Transition(order.ID, models.OrderTransition{
+		To:     models.OrderStatusCancelled,
+		Actor:  models.OrderActorSystem,
+		Reason: &reason,
+	}); cancelErr != nil {
+		log.Printf("⚠️  Failed to cancel order %s after its payment failed to start: %v", order.ID, cancelErr)
+	}
+	return err
+}
+
+// heldByListing returns the units a customer holds in active reservations by listing ID
+func (s *orderService) heldByListing(userID uuid.UUID) (map[uuid.UUID]int, error) {
+	reservations, err := s.reservationRepo.FindActiveByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[uuid.UUID]int, len(reservations))
+	for _, reservation := range reservations {
+		held[reservation.ListingID] = reservation.Qty
+	}
+	return held, nil
+}
+
+// publishCreated announces a new order and its listings' reduced stock
+func (s *orderService) publishCreated(order *models.Order) {
+	s.bus.Publish(events.OrderEvent(events.OrderCreated, order))
+	for i := range order.Items {
+		s.bus.Publish(events.StockEvent(&order.Items[i].Listing))
+	}
 }
 
 // GetOrderByID retrieves an order by ID
@@ -68,35 +266,227 @@ func (s *orderService) GetOrderByID(id uuid.UUID) (*models.Order, error) {
 	return s.orderRepo.FindByID(id)
 }
 
+// GetOrderForUser retrieves an order with its status timeline for its customer
+// or a member of the restaurant who may view orders
+func (s *orderService) GetOrderForUser(id uuid.UUID, requesterID uuid.UUID) (*models.Order, error) {
+	order, err := s.orderRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.UserID != requesterID {
+		if _, err := s.authorizer.Authorize(order.RestaurantID, requesterID, models.PermViewOrders); err != nil {
+			return nil, err
+		}
+		if order.Payment != nil {
+			order.Payment.ClientSecret = ""
+		}
+		return order, nil
+	}
+
+	s.presentPickup(order)
+	return order, nil
+}
+
 // GetUserOrders retrieves all orders for a user
 func (s *orderService) GetUserOrders(userID uuid.UUID) ([]models.Order, error) {
-	return s.orderRepo.FindByUserID(userID)
+	orders, err := s.orderRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range orders {
+		s.presentPickup(&orders[i])
+	}
+	return orders, nil
 }
 
-// GetRestaurantOrders retrieves all orders for a restaurant
-func (s *orderService) GetRestaurantOrders(restaurantID uuid.UUID) ([]models.Order, error) {
-	return s.orderRepo.FindByRestaurantID(restaurantID)
+// GetRestaurantOrders retrieves a restaurant's orders matching the filter for
+// members who may view orders
+func (s *orderService) GetRestaurantOrders(restaurantID uuid.UUID, filter repositories.OrderFilter, requesterID uuid.UUID) ([]models.Order, int64, error) {
+	restaurant, err := s.authorizeOrderView(restaurantID, requesterID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orders, total, err := s.orderRepo.FindByRestaurantID(restaurantID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	localizeOrders(restaurant, orders)
+	return orders, total, nil
 }
 
-// UpdateOrderStatus updates the status of an order
-func (s *orderService) UpdateOrderStatus(id uuid.UUID, status models.OrderStatus, requesterID uuid.UUID) error {
+// GetOrderBoard groups a restaurant's pending, ready and completed orders for a
+// pickup date, today in the restaurant's timezone by default
+func (s *orderService) GetOrderBoard(restaurantID uuid.UUID, date *models.DateOnly, requesterID uuid.UUID) (*models.OrderBoard, error) {
+	restaurant, err := s.authorizeOrderView(restaurantID, requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	day := models.NewDateOnly(time.Now().In(restaurant.Location()))
+	if date != nil {
+		day = *date
+	}
+
+	orders, _, err := s.orderRepo.FindByRestaurantID(restaurantID, repositories.OrderFilter{
+		Statuses:   []models.OrderStatus{models.OrderStatusPending, models.OrderStatusReady, models.OrderStatusCompleted},
+		PickupDate: &day,
+	})
+	if err != nil {
+		return nil, err
+	}
+	localizeOrders(restaurant, orders)
+	return models.NewOrderBoard(day, orders), nil
+}
+
+// authorizeOrderView checks the requester may view the restaurant's orders and
+// returns the restaurant
+func (s *orderService) authorizeOrderView(restaurantID, requesterID uuid.UUID) (*models.Restaurant, error) {
+	if _, err := s.authorizer.Authorize(restaurantID, requesterID, models.PermViewOrders); err != nil {
+		return nil, err
+	}
+	return s.restaurantRepo.FindByID(restaurantID)
+}
+
+// localizeOrders expresses the orders' pickup windows in the restaurant's timezone
+func localizeOrders(restaurant *models.Restaurant, orders []models.Order) {
+	loc := restaurant.Location()
+	for i := range orders {
+		orders[i].LocalizePickup(loc)
+	}
+}
+
+// UpdateOrderStatus moves an order along on behalf of the restaurant; cancellations
+// restore stock and refund paid orders in full
+func (s *orderService) UpdateOrderStatus(id uuid.UUID, status models.OrderStatus, reason string, requesterID uuid.UUID) error {
 	// Get order
 	order, err := s.orderRepo.FindByID(id)
 	if err != nil {
 		return err
 	}
 
-	// Get restaurant for listing
-	restaurant, err := s.restaurantRepo.FindByID(order.Listing.RestaurantID)
+	// Verify requester may manage the restaurant's orders
+	if _, err := s.authorizer.Authorize(order.RestaurantID, requesterID, models.PermManageOrders); err != nil {
+		return err
+	}
+
+	// Completion requires the customer's pickup code
+	if status == models.OrderStatusCompleted {
+		return models.ErrPickupCodeRequired
+	}
+
+	// Customers have until the end of the pickup window to collect
+	if status == models.OrderStatusNoShow && !order.IsPickupOver(time.Now()) {
+		return models.ErrInvalidStatusTransition
+	}
+
+	updated, err := s.orderRepo.Transition(id, models.OrderTransition{
+		To:      status,
+		Actor:   models.OrderActorRestaurant,
+		ActorID: &requesterID,
+		Reason:  optionalString(reason),
+	})
+	if err != nil {
+		return err
+	}
+
+	publishTransition(s.bus, updated)
+	s.paymentService.SettleRefunds(updated)
+	return nil
+}
+
+// CancelOrder lets the customer cancel their own order until the cutoff before
+// pickup, or at any time before paying for it; paid orders are refunded in full
+func (s *orderService) CancelOrder(id uuid.UUID, reason string, requesterID uuid.UUID) error {
+	order, err := s.orderRepo.FindByID(id)
 	if err != nil {
 		return err
 	}
 
-	// Verify requester is the restaurant owner
-	if restaurant.OwnerID != requesterID {
+	if order.UserID != requesterID {
 		return models.ErrUnauthorized
 	}
 
-	// Update status
-	return s.orderRepo.UpdateStatus(id, status)
+	if !order.CanUpdateStatus(models.OrderStatusCancelled) {
+		return models.ErrInvalidStatusTransition
+	}
+
+	if order.Status != models.OrderStatusAwaitingPayment && !time.Now().Before(order.PickupStart.Add(-s.cfg.CancelCutoff)) {
+		return models.ErrCancellationClosed
+	}
+
+	updated, err := s.orderRepo.Transition(id, models.OrderTransition{
+		To:      models.OrderStatusCancelled,
+		Actor:   models.OrderActorCustomer,
+		ActorID: &requesterID,
+		Reason:  optionalString(reason),
+	})
+	if err != nil {
+		return err
+	}
+
+	publishTransition(s.bus, updated)
+	s.paymentService.SettleRefunds(updated)
+	return nil
+}
+
+// VerifyPickup completes an order once restaurant staff type its pickup code or
+// scan its QR payload
+func (s *orderService) VerifyPickup(id uuid.UUID, code, qrPayload string, requesterID uuid.UUID) (*models.Order, error) {
+	order, err := s.orderRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify requester may manage the restaurant's orders
+	if _, err := s.authorizer.Authorize(order.RestaurantID, requesterID, models.PermManageOrders); err != nil {
+		return nil, err
+	}
+
+	if qrPayload != "" {
+		orderID, payloadCode, err := utils.ParsePickupPayload(qrPayload, s.cfg.PickupSecret)
+		if err != nil {
+			return nil, err
+		}
+		if orderID != id {
+			return nil, models.ErrInvalidPickupCode
+		}
+		code = payloadCode
+	}
+
+	err = s.orderRepo.CompletePickup(id, utils.NormalizePickupCode(code), requesterID, s.cfg.PickupMaxAttempts, s.cfg.PickupLockout)
+	if err != nil {
+		return nil, err
+	}
+
+	completed, err := s.orderRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	publishTransition(s.bus, completed)
+	return completed, nil
+}
+
+// presentPickup adds the pickup code and QR payload to the customer's open order
+func (s *orderService) presentPickup(order *models.Order) {
+	if !order.IsAwaitingPickup() || order.PickupCode == "" {
+		return
+	}
+	order.Pickup = &models.PickupPass{
+		Code:      order.PickupCode,
+		QRPayload: utils.SignPickupPayload(order.ID, order.PickupCode, s.cfg.PickupSecret),
+	}
+}
+
+// publishTransition announces an order's new status and, for cancellations,
+// its listings' restored stock. The items' listings must be loaded.
+func publishTransition(bus events.Bus, order *models.Order) {
+	bus.Publish(events.OrderEvent(events.OrderStatusChanged, order))
+	if order.Status == models.OrderStatusCancelled {
+		for i := range order.Items {
+			bus.Publish(events.StockEvent(&order.Items[i].Listing))
+		}
+	}
 }
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"time"
+
+	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+// PartnerApplicationService handles the restaurant partner onboarding workflow
+type PartnerApplicationService interface {
+	Submit(application *models.PartnerApplication) error
+	GetApplicationByID(id uuid.UUID, requesterID uuid.UUID, requesterRole models.UserRole) (*models.PartnerApplication, error)
+	GetUserApplications(userID uuid.UUID) ([]models.PartnerApplication, error)
+	GetApplications(status models.PartnerApplicationStatus) ([]models.PartnerApplication, error)
+	Withdraw(id uuid.UUID, userID uuid.UUID) (*models.PartnerApplication, error)
+	Approve(id uuid.UUID, adminID uuid.UUID, note string) (*models.PartnerApplication, error)
+	Reject(id uuid.UUID, adminID uuid.UUID, note string) (*models.PartnerApplication, error)
+}
+
+// partnerApplicationService implements PartnerApplicationService
+type partnerApplicationService struct {
+	applicationRepo repositories.PartnerApplicationRepository
+	userRepo        repositories.UserRepository
+	accessTTL       time.Duration
+}
+
+// NewPartnerApplicationService creates a new partner application service
+func NewPartnerApplicationService(
+	applicationRepo repositories.PartnerApplicationRepository,
+	userRepo repositories.UserRepository,
+	cfg *config.Config,
+) PartnerApplicationService {
+	return &partnerApplicationService{
+		applicationRepo: applicationRepo,
+		userRepo:        userRepo,
+		accessTTL:       cfg.JWT.Expiry,
+	}
+}
+
+// Submit creates a new pending application for the applicant
+func (s *partnerApplicationService) Submit(application *models.PartnerApplication) error {
+	applicant, err := s.userRepo.FindByID(application.UserID)
+	if err != nil {
+		return err
+	}
+
+	if applicant.Role != models.RoleUser {
+		return models.ErrAlreadyPartner
+	}
+
+	// Only one application can be under review at a time
+	pending, err := s.applicationRepo.HasPending(application.UserID)
+	if err != nil {
+		return err
+	}
+	if pending {
+		return models.ErrDuplicateEntry
+	}
+
+	application.Status = models.ApplicationStatusPending
+	return s.applicationRepo.Create(application)
+}
+
+// GetApplicationByID retrieves an application visible to the applicant or an admin
+func (s *partnerApplicationService) GetApplicationByID(id uuid.UUID, requesterID uuid.UUID, requesterRole models.UserRole) (*models.PartnerApplication, error) {
+	application, err := s.applicationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.UserID != requesterID && requesterRole != models.RoleAdmin {
+		return nil, models.ErrUnauthorized
+	}
+
+	return application, nil
+}
+
+// GetUserApplications retrieves all applications of a user
+func (s *partnerApplicationService) GetUserApplications(userID uuid.UUID) ([]models.PartnerApplication, error) {
+	return s.applicationRepo.FindByUserID(userID)
+}
+
+// GetApplications retrieves applications for review
+func (s *partnerApplicationService) GetApplications(status models.PartnerApplicationStatus) ([]models.PartnerApplication, error) {
+	return s.applicationRepo.FindAll(status)
+}
+
+// Withdraw lets the applicant cancel a pending application
+func (s *partnerApplicationService) Withdraw(id uuid.UUID, userID uuid.UUID) (*models.PartnerApplication, error) {
+	application, err := s.applicationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.UserID != userID {
+		return nil, models.ErrUnauthorized
+	}
+
+	previous := application.Status
+	if err := application.TransitionTo(models.ApplicationStatusWithdrawn); err != nil {
+		return nil, err
+	}
+
+	if err := s.applicationRepo.UpdateStatus(application, previous); err != nil {
+		return nil, err
+	}
+
+	return application, nil
+}
+
+// Approve approves a pending application and upgrades the applicant to restaurant role.
+// The applicant's current access tokens are revoked in the same transaction;
+// their sessions are kept, so refreshing issues a fresh token carrying the
+// restaurant role.
+func (s *partnerApplicationService) Approve(id uuid.UUID, adminID uuid.UUID, note string) (*models.PartnerApplication, error) {
+	application, err := s.review(id, adminID, note, models.ApplicationStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applicationRepo.Approve(application, s.accessTTL); err != nil {
+		return nil, err
+	}
+
+	application.User.Role = models.RoleRestaurant
+	return application, nil
+}
+
+// Reject rejects a pending application
+func (s *partnerApplicationService) Reject(id uuid.UUID, adminID uuid.UUID, note string) (*models.PartnerApplication, error) {
+	application, err := s.review(id, adminID, note, models.ApplicationStatusRejected)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applicationRepo.UpdateStatus(application, models.ApplicationStatusPending); err != nil {
+		return nil, err
+	}
+
+	return application, nil
+}
+
+// review loads an application and applies an admin decision to it in memory
+func (s *partnerApplicationService) review(id uuid.UUID, adminID uuid.UUID, note string, decision models.PartnerApplicationStatus) (*models.PartnerApplication, error) {
+	application, err := s.applicationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := application.TransitionTo(decision); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	application.ReviewedBy = &adminID
+	application.ReviewedAt = &now
+	if note != "" {
+		application.ReviewNote = &note
+	}
+
+	return application, nil
+}
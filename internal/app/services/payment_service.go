@@ -0,0 +1,202 @@
+package services
+
+import (
+	"log"
+
+	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/events"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/payments"
+	"eatright-backend/internal/app/repositories"
+)
+
+// refundFailureReason is recorded on refunds the provider could not process.
+// Refunds are shown to customers, so the provider's error is only returned for
+// callers to log.
+const refundFailureReason = "The payment provider could not process the refund"
+
+// PaymentService handles payment business logic
+type PaymentService interface {
+	Enabled() bool
+	StartPayment(order *models.Order) error
+	HandleWebhook(payload []byte, signature string) error
+	SignatureHeader() string
+	ExecuteRefund(refund *models.Refund) (*models.Refund, error)
+	SettleRefunds(order *models.Order)
+}
+
+// paymentService implements PaymentService
+type paymentService struct {
+	paymentRepo repositories.PaymentRepository
+	orderRepo   repositories.OrderRepository
+	refundRepo  repositories.RefundRepository
+	provider    payments.PaymentProvider // Nil when orders are taken without payment
+	bus         events.Bus
+	currency    string
+}
+
+// NewPaymentService creates a new payment service; provider may be nil to take
+// orders without payment
+func NewPaymentService(
+	paymentRepo repositories.PaymentRepository,
+	orderRepo repositories.OrderRepository,
+	refundRepo repositories.RefundRepository,
+	provider payments.PaymentProvider,
+	bus events.Bus,
+	cfg *config.Config,
+) PaymentService {
+	return &paymentService{
+		paymentRepo: paymentRepo,
+		orderRepo:   orderRepo,
+		refundRepo:  refundRepo,
+		provider:    provider,
+		bus:         bus,
+		currency:    cfg.Payments.Currency,
+	}
+}
+
+// Enabled reports whether orders must be paid
+func (s *paymentService) Enabled() bool {
+	return s.provider != nil
+}
+
+// SignatureHeader returns the request header carrying the provider's webhook signature
+func (s *paymentService) SignatureHeader() string {
+	return s.provider.SignatureHeader()
+}
+
+// StartPayment creates a payment intent for an order awaiting payment and sets
+// order.Payment
+func (s *paymentService) StartPayment(order *models.Order) error {
+	intent, err := s.provider.CreateIntent(payments.IntentRequest{
+		OrderID:  order.ID,
+		Amount:   order.TotalPrice,
+		Currency: s.currency,
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create payment intent for order %s: %v", order.ID, err)
+		return models.ErrPaymentUnavailable
+	}
+
+	payment := &models.Payment{
+		OrderID:          order.ID,
+		Provider:         s.provider.Name(),
+		ProviderIntentID: intent.ID,
+		ClientSecret:     intent.ClientSecret,
+		Amount:           order.TotalPrice,
+		Currency:         s.currency,
+	}
+	if err := s.paymentRepo.Create(payment); err != nil {
+		return err
+	}
+	order.Payment = payment
+	return nil
+}
+
+// HandleWebhook verifies and applies a provider webhook. Authorized payments
+// are captured and their orders move to pending; a capture for an order that
+// was cancelled in the meantime is refunded in full. Redelivered webhooks are
+// ignored once applied.
+func (s *paymentService) HandleWebhook(payload []byte, signature string) error {
+	event, err := s.provider.ParseWebhook(payload, signature)
+	if err != nil {
+		if err == payments.ErrInvalidSignature {
+			return models.ErrInvalidWebhook
+		}
+		return err
+	}
+
+	payment, err := s.paymentRepo.FindByIntentID(s.provider.Name(), event.IntentID)
+	if err != nil {
+		return err
+	}
+
+	switch event.Type {
+	case payments.EventPaymentAuthorized:
+		// Authorizations for closed orders lapse without a capture
+		if !payment.IsOpen() {
+			return nil
+		}
+		if err := s.provider.Capture(payment.ProviderIntentID, payment.Amount); err != nil {
+			return err
+		}
+
+		order, err := s.orderRepo.MarkPaid(payment.OrderID)
+		if err == models.ErrInvalidStatusTransition {
+			s.SettleRefunds(order)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if order.Status == models.OrderStatusPending {
+			publishTransition(s.bus, order)
+		}
+		return nil
+	case payments.EventPaymentFailed:
+		return s.paymentRepo.MarkFailed(payment.ID, event.FailureReason)
+	}
+	return nil
+}
+
+// ExecuteRefund sends an approved or failed refund to the provider and records
+// the outcome; refund.Payment must be loaded. The refund ID is the provider's
+// idempotency key, so retries never return the money twice. It returns the
+// updated refund.
+func (s *paymentService) ExecuteRefund(refund *models.Refund) (*models.Refund, error) {
+	if !refund.IsExecutable() {
+		return refund, nil
+	}
+	if s.provider == nil || refund.Payment.Provider != s.provider.Name() {
+		return nil, models.ErrPaymentUnavailable
+	}
+
+	result, err := s.provider.Refund(payments.RefundRequest{
+		IntentID:       refund.Payment.ProviderIntentID,
+		Amount:         refund.Amount,
+		IdempotencyKey: "refund-" + refund.ID.String(),
+	})
+	if err != nil {
+		if markErr := s.refundRepo.MarkFailed(refund.ID, refundFailureReason); markErr != nil {
+			log.Printf("⚠️  Payment provider could not refund %s: %v", refund.ID, err)
+			return nil, markErr
+		}
+		return nil, err
+	}
+
+	switch result.Status {
+	case payments.RefundSucceeded:
+		return s.refundRepo.MarkSucceeded(refund.ID, result.ID)
+	case payments.RefundPending:
+		if err := s.refundRepo.MarkPending(refund.ID, result.ID); err != nil {
+			return nil, err
+		}
+		return s.refundRepo.FindByID(refund.ID)
+	}
+	if err := s.refundRepo.MarkFailed(refund.ID, refundFailureReason); err != nil {
+		return nil, err
+	}
+	return s.refundRepo.FindByID(refund.ID)
+}
+
+// SettleRefunds executes the refunds queued in order.Refunds, whose payments
+// must be loaded, and adds what was returned to order.RefundedAmount. Failures
+// are logged and left to the refund job.
+func (s *paymentService) SettleRefunds(order *models.Order) {
+	for i := range order.Refunds {
+		refund := &order.Refunds[i]
+		if !refund.IsExecutable() {
+			continue
+		}
+
+		executed, err := s.ExecuteRefund(refund)
+		if err != nil {
+			log.Printf("⚠️  Failed to refund %d for order %s: %v", refund.Amount, order.ID, err)
+			continue
+		}
+		if executed.Status == models.RefundStatusSucceeded {
+			order.RefundedAmount += executed.Amount
+		}
+		*refund = *executed
+	}
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/events"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/payments"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// paymentFixture is a restaurant with one listing and a customer, backed by the
+// database at TEST_DATABASE_URL with all migrations applied
+type paymentFixture struct {
+	db          *gorm.DB
+	provider    *payments.FakeProvider
+	orderRepo   repositories.OrderRepository
+	paymentRepo repositories.PaymentRepository
+	refundRepo  repositories.RefundRepository
+	payments    PaymentService
+
+	owner      models.User
+	customer   models.User
+	restaurant models.Restaurant
+	listing    models.Listing
+}
+
+// newPaymentFixture creates the fixture, or skips the test when no test
+// database is configured. Its rows are deleted when the test ends.
+func newPaymentFixture(t *testing.T, stock int) *paymentFixture {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := gorm.Open(postgres.Open(url), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connect to test database: %v", err)
+	}
+
+	f := &paymentFixture{db: db, provider: payments.NewFakeProvider("test-webhook-secret")}
+	listingRepo := repositories.NewListingRepository(db)
+	reservationRepo := repositories.NewReservationRepository(db, listingRepo)
+	f.refundRepo = repositories.NewRefundRepository(db)
+	f.orderRepo = repositories.NewOrderRepository(db, listingRepo, reservationRepo, f.refundRepo)
+	f.paymentRepo = repositories.NewPaymentRepository(db)
+	f.payments = NewPaymentService(f.paymentRepo, f.orderRepo, f.refundRepo, f.provider, events.NewLocalBus(), &config.Config{
+		Payments: config.PaymentConfig{Currency: "IDR"},
+	})
+
+	suffix := uuid.New().String()
+	f.owner = models.User{Name: "Owner", Email: "owner-" + suffix + "@example.com", Role: models.RoleRestaurant}
+	f.customer = models.User{Name: "Customer", Email: "customer-" + suffix + "@example.com"}
+	for _, user := range []*models.User{&f.owner, &f.customer} {
+		if err := db.Omit(clause.Associations).Create(user).Error; err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		db.Where("id IN ?", []uuid.UUID{f.owner.ID, f.customer.ID}).Delete(&models.User{})
+	})
+
+	f.restaurant = models.Restaurant{
+		OwnerID:     f.owner.ID,
+		Name:        "Bakery",
+		Address:     "Jl. Test 1",
+		ClosingTime: models.TimeOnly{Time: time.Date(0, 1, 1, 22, 0, 0, 0, time.UTC)},
+		Timezone:    "Asia/Jakarta",
+	}
+	if err := db.Omit(clause.Associations).Create(&f.restaurant).Error; err != nil {
+		t.Fatalf("create restaurant: %v", err)
+	}
+
+	now := time.Now()
+	f.listing = models.Listing{
+		RestaurantID: f.restaurant.ID,
+		Type:         models.ListingTypeMysteryBox,
+		Description:  "End of day bakery box",
+		Price:        25000,
+		Stock:        stock,
+		PickupStart:  now.Add(time.Hour),
+		PickupEnd:    now.Add(2 * time.Hour),
+		IsActive:     true,
+	}
+	if err := db.Omit(clause.Associations).Create(&f.listing).Error; err != nil {
+		t.Fatalf("create listing: %v", err)
+	}
+	f.listing.Restaurant = f.restaurant
+	return f
+}
+
+// placeOrder orders qty of the listing and starts its payment
+func (f *paymentFixture) placeOrder(t *testing.T, qty int) *models.Order {
+	t.Helper()
+	listing := f.listing
+	order := &models.Order{
+		UserID:       f.customer.ID,
+		RestaurantID: f.restaurant.ID,
+		Restaurant:   f.restaurant,
+		Status:       models.OrderStatusAwaitingPayment,
+		PickupStart:  listing.PickupStart,
+		PickupEnd:    listing.PickupEnd,
+		PickupCode:   "TEST01",
+		Items:        []models.OrderItem{{ListingID: listing.ID, Qty: qty}},
+	}
+	if err := f.orderRepo.Create(order, map[uuid.UUID]*models.Listing{listing.ID: &listing}); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := f.payments.StartPayment(order); err != nil {
+		t.Fatalf("start payment: %v", err)
+	}
+	return order
+}
+
+// pay settles the order's payment as the customer's client would and delivers
+// the webhook
+func (f *paymentFixture) pay(t *testing.T, order *models.Order) {
+	t.Helper()
+	payload, signature, err := f.provider.Simulate(order.Payment.ProviderIntentID, true)
+	if err != nil {
+		t.Fatalf("simulate payment: %v", err)
+	}
+	if err := f.payments.HandleWebhook(payload, signature); err != nil {
+		t.Fatalf("handle webhook: %v", err)
+	}
+}
+
+// reload loads the order with its payment and refunds
+func (f *paymentFixture) reload(t *testing.T, id uuid.UUID) *models.Order {
+	t.Helper()
+	order, err := f.orderRepo.FindByID(id)
+	if err != nil {
+		t.Fatalf("find order: %v", err)
+	}
+	return order
+}
+
+// stock returns the listing's current stock
+func (f *paymentFixture) stock(t *testing.T) int {
+	t.Helper()
+	var listing models.Listing
+	if err := f.db.Select("stock").Where("id = ?", f.listing.ID).First(&listing).Error; err != nil {
+		t.Fatalf("find listing: %v", err)
+	}
+	return listing.Stock
+}
+
+func TestWebhookCapturesAndMarksPaid(t *testing.T) {
+	f := newPaymentFixture(t, 5)
+	order := f.placeOrder(t, 2)
+	if order.Status != models.OrderStatusAwaitingPayment {
+		t.Fatalf("status = %s, want awaiting_payment", order.Status)
+	}
+
+	payload, signature, err := f.provider.Simulate(order.Payment.ProviderIntentID, true)
+	if err != nil {
+		t.Fatalf("simulate payment: %v", err)
+	}
+	if err := f.payments.HandleWebhook(payload, signature); err != nil {
+		t.Fatalf("handle webhook: %v", err)
+	}
+
+	paid := f.reload(t, order.ID)
+	if paid.Status != models.OrderStatusPending {
+		t.Errorf("status = %s, want pending", paid.Status)
+	}
+	if paid.Payment == nil || paid.Payment.Status != models.PaymentStatusCaptured || paid.Payment.CapturedAt == nil {
+		t.Fatalf("payment = %+v, want captured", paid.Payment)
+	}
+
+	// Redelivered webhooks are ignored
+	if err := f.payments.HandleWebhook(payload, signature); err != nil {
+		t.Fatalf("redelivered webhook: %v", err)
+	}
+	if again := f.reload(t, order.ID); again.Status != models.OrderStatusPending || len(again.Refunds) != 0 {
+		t.Errorf("after redelivery: status = %s, refunds = %d, want pending without refunds", again.Status, len(again.Refunds))
+	}
+	if stock := f.stock(t); stock != 3 {
+		t.Errorf("stock = %d, want 3", stock)
+	}
+}
+
+func TestWebhookRejectsInvalidSignature(t *testing.T) {
+	f := newPaymentFixture(t, 5)
+	order := f.placeOrder(t, 1)
+
+	payload, _, err := f.provider.Simulate(order.Payment.ProviderIntentID, true)
+	if err != nil {
+		t.Fatalf("simulate payment: %v", err)
+	}
+	if err := f.payments.HandleWebhook(payload, "t=1,v1=00"); err != models.ErrInvalidWebhook {
+		t.Fatalf("err = %v, want ErrInvalidWebhook", err)
+	}
+	if status := f.reload(t, order.ID).Status; status != models.OrderStatusAwaitingPayment {
+		t.Errorf("status = %s, want awaiting_payment", status)
+	}
+}
+
+func TestCaptureAfterCancelRefunds(t *testing.T) {
+	f := newPaymentFixture(t, 5)
+	order := f.placeOrder(t, 2)
+
+	// The customer pays while the order is being cancelled: the capture goes
+	// through at the provider before the order is marked paid
+	if _, _, err := f.provider.Simulate(order.Payment.ProviderIntentID, true); err != nil {
+		t.Fatalf("simulate payment: %v", err)
+	}
+	if err := f.provider.Capture(order.Payment.ProviderIntentID, order.Payment.Amount); err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	_, err := f.orderRepo.Transition(order.ID, models.OrderTransition{
+		To:      models.OrderStatusCancelled,
+		Actor:   models.OrderActorCustomer,
+		ActorID: &f.customer.ID,
+	})
+	if err != nil {
+		t.Fatalf("cancel order: %v", err)
+	}
+
+	paid, err := f.orderRepo.MarkPaid(order.ID)
+	if err != models.ErrInvalidStatusTransition {
+		t.Fatalf("mark paid: err = %v, want ErrInvalidStatusTransition", err)
+	}
+	if len(paid.Refunds) != 1 || paid.Refunds[0].Amount != order.TotalPrice {
+		t.Fatalf("refunds = %+v, want one full refund of %d", paid.Refunds, order.TotalPrice)
+	}
+
+	f.payments.SettleRefunds(paid)
+	settled := f.reload(t, order.ID)
+	if settled.Status != models.OrderStatusCancelled {
+		t.Errorf("status = %s, want cancelled", settled.Status)
+	}
+	if settled.Payment.Status != models.PaymentStatusRefunded || settled.RefundedAmount != order.TotalPrice {
+		t.Errorf("payment = %s, refunded = %d, want refunded in full", settled.Payment.Status, settled.RefundedAmount)
+	}
+	if stock := f.stock(t); stock != 5 {
+		t.Errorf("stock = %d, want 5", stock)
+	}
+}
+
+func TestExpireUnpaidRestoresStock(t *testing.T) {
+	f := newPaymentFixture(t, 5)
+	order := f.placeOrder(t, 2)
+	if stock := f.stock(t); stock != 3 {
+		t.Fatalf("stock after ordering = %d, want 3", stock)
+	}
+
+	var expired []models.Order
+	err := f.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		expired, err = f.orderRepo.ExpireUnpaidWithTx(tx, time.Now().Add(time.Minute), 100)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("expire unpaid orders: %v", err)
+	}
+
+	found := false
+	for _, o := range expired {
+		found = found || o.ID == order.ID
+	}
+	if !found {
+		t.Fatalf("order %s was not expired", order.ID)
+	}
+
+	cancelled := f.reload(t, order.ID)
+	if cancelled.Status != models.OrderStatusCancelled {
+		t.Errorf("status = %s, want cancelled", cancelled.Status)
+	}
+	if cancelled.Payment.Status != models.PaymentStatusCancelled {
+		t.Errorf("payment = %s, want cancelled", cancelled.Payment.Status)
+	}
+	if stock := f.stock(t); stock != 5 {
+		t.Errorf("stock = %d, want 5", stock)
+	}
+
+	// A late authorization of the expired order lapses without a capture
+	f.pay(t, order)
+	if late := f.reload(t, order.ID); late.Status != models.OrderStatusCancelled || late.Payment.Status != models.PaymentStatusCancelled {
+		t.Errorf("after late payment: status = %s, payment = %s, want cancelled", late.Status, late.Payment.Status)
+	}
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+// RefundService handles refund requests, their approval and execution
+type RefundService interface {
+	RequestRefund(orderID uuid.UUID, amount int, reason string, requesterID uuid.UUID) (*models.Refund, error)
+	GetRestaurantRefunds(restaurantID uuid.UUID, filter repositories.RefundFilter, requesterID uuid.UUID) ([]models.Refund, int64, error)
+	DecideRefund(id uuid.UUID, approve bool, note string, requesterID uuid.UUID) (*models.Refund, error)
+	IssueRefund(orderID uuid.UUID, amount int, reason string, adminID uuid.UUID) (*models.Refund, error)
+	GetRefunds(filter repositories.RefundFilter) ([]models.Refund, int64, error)
+	ReviewRefund(id uuid.UUID, approve bool, note string, adminID uuid.UUID) (*models.Refund, error)
+	RetryRefund(id uuid.UUID) (*models.Refund, error)
+}
+
+// refundService implements RefundService
+type refundService struct {
+	refundRepo     repositories.RefundRepository
+	orderRepo      repositories.OrderRepository
+	paymentService PaymentService
+	authorizer     RestaurantAuthorizer
+	window         time.Duration
+}
+
+// NewRefundService creates a new refund service
+func NewRefundService(
+	refundRepo repositories.RefundRepository,
+	orderRepo repositories.OrderRepository,
+	paymentService PaymentService,
+	authorizer RestaurantAuthorizer,
+	cfg *config.Config,
+) RefundService {
+	return &refundService{
+		refundRepo:     refundRepo,
+		orderRepo:      orderRepo,
+		paymentService: paymentService,
+		authorizer:     authorizer,
+		window:         cfg.Payments.RefundWindow,
+	}
+}
+
+// RequestRefund asks for part or all of an order's payment back. Customers may
+// request refunds of completed or missed orders until the refund window after
+// pickup closes; their requests wait for the restaurant or an admin. Members
+// who may manage the restaurant's orders issue refunds of any paid order, which
+// are approved and executed at once.
+func (s *refundService) RequestRefund(orderID uuid.UUID, amount int, reason string, requesterID uuid.UUID) (*models.Refund, error) {
+	order, refund, err := s.prepareRefund(orderID, amount, reason, requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.UserID == requesterID {
+		if order.Status != models.OrderStatusCompleted && order.Status != models.OrderStatusNoShow {
+			return nil, models.ErrInvalidStatusTransition
+		}
+		if time.Now().After(order.PickupEnd.Add(s.window)) {
+			return nil, models.ErrRefundWindowClosed
+		}
+		refund.RequestedBy = models.OrderActorCustomer
+		refund.Status = models.RefundStatusRequested
+		if err := s.refundRepo.Create(refund); err != nil {
+			return nil, err
+		}
+		return refund, nil
+	}
+
+	if _, err := s.authorizer.Authorize(order.RestaurantID, requesterID, models.PermManageOrders); err != nil {
+		return nil, err
+	}
+	refund.RequestedBy = models.OrderActorRestaurant
+	return s.issue(order, refund, requesterID)
+}
+
+// GetRestaurantRefunds lists refunds of a restaurant's orders for members who
+// may view orders
+func (s *refundService) GetRestaurantRefunds(restaurantID uuid.UUID, filter repositories.RefundFilter, requesterID uuid.UUID) ([]models.Refund, int64, error) {
+	if _, err := s.authorizer.Authorize(restaurantID, requesterID, models.PermViewOrders); err != nil {
+		return nil, 0, err
+	}
+	filter.RestaurantID = &restaurantID
+	return s.refundRepo.FindAll(filter)
+}
+
+// DecideRefund approves or rejects a customer's refund request on behalf of the
+// restaurant; approved refunds are executed at once
+func (s *refundService) DecideRefund(id uuid.UUID, approve bool, note string, requesterID uuid.UUID) (*models.Refund, error) {
+	refund, err := s.refundRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderRepo.FindByID(refund.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.authorizer.Authorize(order.RestaurantID, requesterID, models.PermManageOrders); err != nil {
+		return nil, err
+	}
+
+	decision := repositories.RefundDecision{
+		From:      []models.RefundStatus{models.RefundStatusRequested},
+		To:        models.RefundStatusRejected,
+		DeciderID: requesterID,
+		Note:      optionalString(note),
+	}
+	if approve {
+		decision.To = models.RefundStatusApproved
+	}
+	return s.decide(refund, decision)
+}
+
+// IssueRefund refunds part or all of any paid order on behalf of an admin
+func (s *refundService) IssueRefund(orderID uuid.UUID, amount int, reason string, adminID uuid.UUID) (*models.Refund, error) {
+	order, refund, err := s.prepareRefund(orderID, amount, reason, adminID)
+	if err != nil {
+		return nil, err
+	}
+	refund.RequestedBy = models.OrderActorAdmin
+	return s.issue(order, refund, adminID)
+}
+
+// GetRefunds lists refunds across all restaurants
+func (s *refundService) GetRefunds(filter repositories.RefundFilter) ([]models.Refund, int64, error) {
+	return s.refundRepo.FindAll(filter)
+}
+
+// ReviewRefund approves or rejects a refund request on behalf of an admin.
+// Admins have the final say: they may also approve requests the restaurant
+// rejected.
+func (s *refundService) ReviewRefund(id uuid.UUID, approve bool, note string, adminID uuid.UUID) (*models.Refund, error) {
+	refund, err := s.refundRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := repositories.RefundDecision{
+		From:      []models.RefundStatus{models.RefundStatusRequested},
+		To:        models.RefundStatusRejected,
+		DeciderID: adminID,
+		Note:      optionalString(note),
+	}
+	if approve {
+		decision.From = append(decision.From, models.RefundStatusRejected)
+		decision.To = models.RefundStatusApproved
+	}
+	return s.decide(refund, decision)
+}
+
+// RetryRefund sends an approved or failed refund to the payment provider again
+// on behalf of an admin, with its attempts reset so the refund job keeps
+// retrying it. Refunds that used up REFUND_MAX_ATTEMPTS are retried this way.
+func (s *refundService) RetryRefund(id uuid.UUID) (*models.Refund, error) {
+	refund, err := s.refundRepo.Retry(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.execute(refund), nil
+}
+
+// prepareRefund validates a refund of a paid order and returns the order and
+// the unsaved refund
+func (s *refundService) prepareRefund(orderID uuid.UUID, amount int, reason string, requesterID uuid.UUID) (*models.Order, *models.Refund, error) {
+	reason = strings.TrimSpace(reason)
+	if amount <= 0 || reason == "" {
+		return nil, nil, models.ErrInvalidInput
+	}
+
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if order.Payment == nil || !order.Payment.IsCaptured() {
+		return nil, nil, models.ErrNotRefundable
+	}
+
+	return order, &models.Refund{
+		OrderID:     order.ID,
+		PaymentID:   order.Payment.ID,
+		Amount:      amount,
+		Reason:      reason,
+		RequesterID: &requesterID,
+	}, nil
+}
+
+// issue saves an approved refund and executes it
+func (s *refundService) issue(order *models.Order, refund *models.Refund, deciderID uuid.UUID) (*models.Refund, error) {
+	now := time.Now()
+	refund.Status = models.RefundStatusApproved
+	refund.DecidedBy = &deciderID
+	refund.DecidedAt = &now
+	if err := s.refundRepo.Create(refund); err != nil {
+		return nil, err
+	}
+
+	refund.Payment = order.Payment
+	return s.execute(refund), nil
+}
+
+// decide applies a decision to a refund loaded with its payment and executes
+// it when approved
+func (s *refundService) decide(refund *models.Refund, decision repositories.RefundDecision) (*models.Refund, error) {
+	decided, err := s.refundRepo.Decide(refund.ID, decision)
+	if err != nil {
+		return nil, err
+	}
+	if decided.Status != models.RefundStatusApproved {
+		return decided, nil
+	}
+
+	decided.Payment = refund.Payment
+	return s.execute(decided), nil
+}
+
+// execute sends an approved refund to the payment provider. Failures are logged
+// and left to the refund job; the refund is returned as it stands.
+func (s *refundService) execute(refund *models.Refund) *models.Refund {
+	executed, err := s.paymentService.ExecuteRefund(refund)
+	if err != nil {
+		log.Printf("⚠️  Failed to execute refund %s: %v", refund.ID, err)
+		if current, err := s.refundRepo.FindByID(refund.ID); err == nil {
+			return current
+		}
+		return refund
+	}
+	return executed
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// newRefundService creates a refund service over the fixture; admins and
+// customers do not need an authorizer
+func (f *paymentFixture) newRefundService() RefundService {
+	return NewRefundService(f.refundRepo, f.orderRepo, f.payments, nil, &config.Config{
+		Payments: config.PaymentConfig{RefundWindow: 72 * time.Hour},
+	})
+}
+
+// paidOrder places an order of qty and pays for it
+func (f *paymentFixture) paidOrder(t *testing.T, qty int) *models.Order {
+	t.Helper()
+	order := f.placeOrder(t, qty)
+	f.pay(t, order)
+	return f.reload(t, order.ID)
+}
+
+// approvedRefund creates an approved refund of amount without executing it,
+// and returns it with its payment
+func (f *paymentFixture) approvedRefund(t *testing.T, order *models.Order, amount int) *models.Refund {
+	t.Helper()
+	refund := &models.Refund{
+		OrderID:     order.ID,
+		PaymentID:   order.Payment.ID,
+		Amount:      amount,
+		Reason:      "Goodwill",
+		Status:      models.RefundStatusApproved,
+		RequestedBy: models.OrderActorAdmin,
+	}
+	if err := f.refundRepo.Create(refund); err != nil {
+		t.Fatalf("create refund: %v", err)
+	}
+	found, err := f.refundRepo.FindByID(refund.ID)
+	if err != nil {
+		t.Fatalf("find refund: %v", err)
+	}
+	return found
+}
+
+// findExecutable returns the IDs of the refunds the refund job would pick up
+func (f *paymentFixture) findExecutable(t *testing.T, maxAttempts int) map[uuid.UUID]bool {
+	t.Helper()
+	found := make(map[uuid.UUID]bool)
+	err := f.db.Transaction(func(tx *gorm.DB) error {
+		refunds, err := f.refundRepo.FindExecutableWithTx(tx, time.Now().Add(time.Minute), maxAttempts, 1000)
+		for _, refund := range refunds {
+			found[refund.ID] = true
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("find executable refunds: %v", err)
+	}
+	return found
+}
+
+func TestConcurrentRefundsStayWithinPayment(t *testing.T) {
+	f := newPaymentFixture(t, 5)
+	refunds := f.newRefundService()
+	order := f.paidOrder(t, 2)
+	amount := order.TotalPrice * 2 / 5
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := refunds.IssueRefund(order.ID, amount, "Goodwill", f.owner.ID)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	issued := 0
+	for err := range errs {
+		switch err {
+		case nil:
+			issued++
+		case models.ErrRefundExceedsPayment:
+		default:
+			t.Errorf("issue refund: %v", err)
+		}
+	}
+	if issued != 2 {
+		t.Errorf("issued = %d, want 2", issued)
+	}
+
+	refunded := f.reload(t, order.ID)
+	if refunded.RefundedAmount != 2*amount || refunded.Payment.RefundedAmount != 2*amount {
+		t.Errorf("refunded = %d on the order and %d on the payment, want %d", refunded.RefundedAmount, refunded.Payment.RefundedAmount, 2*amount)
+	}
+}
+
+func TestOneOpenRefundRequest(t *testing.T) {
+	f := newPaymentFixture(t, 5)
+	refunds := f.newRefundService()
+	order := f.paidOrder(t, 2)
+	if err := f.db.Model(&models.Order{}).Where("id = ?", order.ID).Update("status", models.OrderStatusCompleted).Error; err != nil {
+		t.Fatalf("complete order: %v", err)
+	}
+
+	requested, err := refunds.RequestRefund(order.ID, 10000, "Two items were missing", f.customer.ID)
+	if err != nil {
+		t.Fatalf("request refund: %v", err)
+	}
+	if requested.Status != models.RefundStatusRequested {
+		t.Fatalf("status = %s, want requested", requested.Status)
+	}
+	if _, err := refunds.RequestRefund(order.ID, 5000, "One more", f.customer.ID); err != models.ErrRefundAlreadyRequested {
+		t.Fatalf("second request: err = %v, want ErrRefundAlreadyRequested", err)
+	}
+
+	// Once decided, the customer may ask again
+	if _, err := refunds.ReviewRefund(requested.ID, false, "Items were all there", f.owner.ID); err != nil {
+		t.Fatalf("reject refund: %v", err)
+	}
+	if _, err := refunds.RequestRefund(order.ID, 5000, "One more", f.customer.ID); err != nil {
+		t.Errorf("request after rejection: %v", err)
+	}
+}
+
+func TestCancelPaidOrderRefundsInFull(t *testing.T) {
+	f := newPaymentFixture(t, 5)
+	order := f.paidOrder(t, 2)
+
+	cancelled, err := f.orderRepo.Transition(order.ID, models.OrderTransition{
+		To:      models.OrderStatusCancelled,
+		Actor:   models.OrderActorCustomer,
+		ActorID: &f.customer.ID,
+	})
+	if err != nil {
+		t.Fatalf("cancel order: %v", err)
+	}
+	if len(cancelled.Refunds) != 1 || cancelled.Refunds[0].Amount != order.TotalPrice ||
+		cancelled.Refunds[0].Status != models.RefundStatusApproved {
+		t.Fatalf("refunds = %+v, want one approved refund of %d", cancelled.Refunds, order.TotalPrice)
+	}
+
+	f.payments.SettleRefunds(cancelled)
+	settled := f.reload(t, order.ID)
+	if settled.Payment.Status != models.PaymentStatusRefunded || settled.RefundedAmount != order.TotalPrice {
+		t.Errorf("payment = %s, refunded = %d, want refunded in full", settled.Payment.Status, settled.RefundedAmount)
+	}
+	if stock := f.stock(t); stock != 5 {
+		t.Errorf("stock = %d, want 5", stock)
+	}
+}
+
+func TestLateCaptureQueuesOneRefund(t *testing.T) {
+	f := newPaymentFixture(t, 5)
+	order := f.placeOrder(t, 1)
+
+	if _, _, err := f.provider.Simulate(order.Payment.ProviderIntentID, true); err != nil {
+		t.Fatalf("simulate payment: %v", err)
+	}
+	if err := f.provider.Capture(order.Payment.ProviderIntentID, order.Payment.Amount); err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	_, err := f.orderRepo.Transition(order.ID, models.OrderTransition{
+		To:      models.OrderStatusCancelled,
+		Actor:   models.OrderActorCustomer,
+		ActorID: &f.customer.ID,
+	})
+	if err != nil {
+		t.Fatalf("cancel order: %v", err)
+	}
+
+	// A redelivered webhook marks the order paid twice
+	for i := 0; i < 2; i++ {
+		if _, err := f.orderRepo.MarkPaid(order.ID); err != models.ErrInvalidStatusTransition {
+			t.Fatalf("mark paid #%d: err = %v, want ErrInvalidStatusTransition", i+1, err)
+		}
+	}
+	if refunds := f.reload(t, order.ID).Refunds; len(refunds) != 1 || refunds[0].Amount != order.TotalPrice {
+		t.Errorf("refunds = %+v, want one full refund of %d", refunds, order.TotalPrice)
+	}
+}
+
+func TestRetriedRefundAppliedOnce(t *testing.T) {
+	f := newPaymentFixture(t, 5)
+	order := f.paidOrder(t, 2)
+	stale := f.approvedRefund(t, order, 10000)
+
+	// Executing the same approved copy twice, as a retry racing the first
+	// execution would, sends the same idempotency key
+	for i := 0; i < 2; i++ {
+		executed, err := f.payments.ExecuteRefund(stale)
+		if err != nil {
+			t.Fatalf("execute refund #%d: %v", i+1, err)
+		}
+		if executed.Status != models.RefundStatusSucceeded {
+			t.Fatalf("execute refund #%d: status = %s, want succeeded", i+1, executed.Status)
+		}
+	}
+
+	refunded := f.reload(t, order.ID)
+	if refunded.RefundedAmount != 10000 || refunded.Payment.RefundedAmount != 10000 {
+		t.Errorf("refunded = %d on the order and %d on the payment, want 10000", refunded.RefundedAmount, refunded.Payment.RefundedAmount)
+	}
+}
+
+func TestRetryExhaustedRefund(t *testing.T) {
+	f := newPaymentFixture(t, 5)
+	refunds := f.newRefundService()
+	order := f.paidOrder(t, 2)
+	refund := f.approvedRefund(t, order, 10000)
+
+	if err := f.refundRepo.MarkFailed(refund.ID, refundFailureReason); err != nil {
+		t.Fatalf("mark failed: %v", err)
+	}
+	if err := f.db.Model(&models.Refund{}).Where("id = ?", refund.ID).Update("attempts", 5).Error; err != nil {
+		t.Fatalf("exhaust attempts: %v", err)
+	}
+	if found := f.findExecutable(t, 5); found[refund.ID] {
+		t.Fatalf("refund with exhausted attempts is still executable")
+	}
+
+	retried, err := refunds.RetryRefund(refund.ID)
+	if err != nil {
+		t.Fatalf("retry refund: %v", err)
+	}
+	if retried.Status != models.RefundStatusSucceeded || retried.FailureReason != nil {
+		t.Errorf("status = %s, failure = %v, want succeeded without a failure", retried.Status, retried.FailureReason)
+	}
+	if _, err := refunds.RetryRefund(refund.ID); err != models.ErrInvalidStatusTransition {
+		t.Errorf("retry succeeded refund: err = %v, want ErrInvalidStatusTransition", err)
+	}
+}
+
+func TestPendingRefundKeepsBeingChecked(t *testing.T) {
+	f := newPaymentFixture(t, 5)
+	order := f.paidOrder(t, 2)
+	refund := f.approvedRefund(t, order, 10000)
+
+	if err := f.db.Model(&models.Refund{}).Where("id = ?", refund.ID).Update("attempts", 5).Error; err != nil {
+		t.Fatalf("exhaust attempts: %v", err)
+	}
+	if err := f.refundRepo.MarkPending(refund.ID, "re_pending"); err != nil {
+		t.Fatalf("mark pending: %v", err)
+	}
+	if found := f.findExecutable(t, 5); !found[refund.ID] {
+		t.Errorf("refund pending at the provider is no longer checked")
+	}
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"time"
+
+	"eatright-backend/internal/app/config"
+	"eatright-backend/internal/app/events"
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+// ReservationService handles stock reservation business logic
+type ReservationService interface {
+	Reserve(userID, listingID uuid.UUID, qty int) (*models.StockReservation, error)
+	GetMyReservations(userID uuid.UUID) ([]models.StockReservation, error)
+	Release(id, userID uuid.UUID) error
+}
+
+// reservationService implements ReservationService
+type reservationService struct {
+	reservationRepo repositories.ReservationRepository
+	listingRepo     repositories.ListingRepository
+	bus             events.Bus
+	ttl             time.Duration
+	maxActive       int
+}
+
+// NewReservationService creates a new reservation service
+func NewReservationService(
+	reservationRepo repositories.ReservationRepository,
+	listingRepo repositories.ListingRepository,
+	bus events.Bus,
+	cfg *config.Config,
+) ReservationService {
+	return &reservationService{
+		reservationRepo: reservationRepo,
+		listingRepo:     listingRepo,
+		bus:             bus,
+		ttl:             cfg.Orders.ReservationTTL,
+		maxActive:       cfg.Orders.MaxReservations,
+	}
+}
+
+// Reserve holds qty of a listing for the user until the reservation TTL runs out
+// or the pickup window ends, whichever is first. Reserving a listing the user
+// already holds changes the quantity of that reservation but not its expiry.
+func (s *reservationService) Reserve(userID, listingID uuid.UUID, qty int) (*models.StockReservation, error) {
+	if qty <= 0 {
+		return nil, models.ErrInvalidQuantity
+	}
+
+	listing, err := s.listingRepo.FindByID(listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !listing.IsActive {
+		return nil, models.ErrInvalidInput
+	}
+	if listing.Restaurant.IsSuspended() {
+		return nil, models.ErrRestaurantSuspended
+	}
+	if listing.IsPickupOver(now) {
+		return nil, models.ErrPickupWindowClosed
+	}
+
+	// Past orders are counted when the reservation is ordered
+	if listing.ExceedsMaxPerCustomer(0, qty) {
+		return nil, models.ErrListingLimitReached
+	}
+
+	expiresAt := now.Add(s.ttl)
+	if listing.PickupEnd.Before(expiresAt) {
+		expiresAt = listing.PickupEnd
+	}
+
+	reservation := &models.StockReservation{
+		UserID:    userID,
+		ListingID: listingID,
+		Qty:       qty,
+		ExpiresAt: expiresAt,
+	}
+	stock, err := s.reservationRepo.Reserve(reservation, s.maxActive)
+	if err != nil {
+		return nil, err
+	}
+
+	listing.Stock = stock
+	s.bus.Publish(events.StockEvent(listing))
+
+	listing.LocalizePickup()
+	listing.SetPricing(now)
+	reservation.Listing = *listing
+	return reservation, nil
+}
+
+// GetMyReservations retrieves the user's active reservations
+func (s *reservationService) GetMyReservations(userID uuid.UUID) ([]models.StockReservation, error) {
+	reservations, err := s.reservationRepo.FindActiveByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range reservations {
+		reservations[i].Listing.LocalizePickup()
+		reservations[i].Listing.SetPricing(now)
+	}
+	return reservations, nil
+}
+
+// Release gives up the user's reservation and returns its units to stock
+func (s *reservationService) Release(id, userID uuid.UUID) error {
+	reservation, err := s.reservationRepo.Release(id, userID)
+	if err != nil {
+		return err
+	}
+
+	s.bus.Publish(events.StockEvent(&reservation.Listing))
+	return nil
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+// RestaurantAuthorizer is the single place that decides what a user may do in a restaurant
+type RestaurantAuthorizer interface {
+	Authorize(restaurantID, userID uuid.UUID, permission models.Permission) (*models.RestaurantMember, error)
+}
+
+// restaurantAuthorizer implements RestaurantAuthorizer using restaurant memberships
+type restaurantAuthorizer struct {
+	membershipRepo repositories.MembershipRepository
+}
+
+// NewRestaurantAuthorizer creates a new restaurant authorizer
+func NewRestaurantAuthorizer(membershipRepo repositories.MembershipRepository) RestaurantAuthorizer {
+	return &restaurantAuthorizer{membershipRepo: membershipRepo}
+}
+
+// Authorize returns the user's membership if their role grants the permission,
+// or ErrUnauthorized if they are not a member or lack the permission
+func (a *restaurantAuthorizer) Authorize(restaurantID, userID uuid.UUID, permission models.Permission) (*models.RestaurantMember, error) {
+	member, err := a.membershipRepo.FindMember(restaurantID, userID)
+	if err != nil {
+		if err == models.ErrNotFound {
+			return nil, models.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	if !member.Role.Can(permission) {
+		return nil, models.ErrUnauthorized
+	}
+
+	return member, nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"sort"
+	"time"
 
 	"eatright-backend/internal/app/models"
 	"eatright-backend/internal/app/repositories"
@@ -14,22 +15,40 @@ import (
 type RestaurantService interface {
 	CreateRestaurant(restaurant *models.Restaurant, ownerID uuid.UUID) error
 	GetRestaurantByID(id uuid.UUID) (*models.Restaurant, error)
+	GetRestaurantSavings(id uuid.UUID, requesterID uuid.UUID, requesterRole models.UserRole) (*models.SavingsSummary, error)
 	GetAllRestaurants() ([]models.Restaurant, error)
 	GetNearbyRestaurants(lat, lng, maxDistance float64) ([]models.Restaurant, error)
-	UpdateRestaurant(restaurant *models.Restaurant) error
+	GetOwnedRestaurants(ownerID uuid.UUID) ([]models.Restaurant, error)
+	UpdateRestaurant(id uuid.UUID, update models.RestaurantUpdate, requesterID uuid.UUID) (*models.Restaurant, error)
+	DeleteRestaurant(id uuid.UUID, requesterID uuid.UUID) error
+	SetOpeningHours(id uuid.UUID, hours []models.OpeningHours, requesterID uuid.UUID) (*models.Restaurant, error)
+	AddClosure(closure *models.RestaurantClosure, requesterID uuid.UUID) error
+	RemoveClosure(restaurantID uuid.UUID, closureID uuid.UUID, requesterID uuid.UUID) error
 }
 
 // restaurantService implements RestaurantService
 type restaurantService struct {
 	restaurantRepo repositories.RestaurantRepository
 	userRepo       repositories.UserRepository
+	orderRepo      repositories.OrderRepository
+	impactService  ImpactService
+	authorizer     RestaurantAuthorizer
 }
 
 // NewRestaurantService creates a new restaurant service
-func NewRestaurantService(restaurantRepo repositories.RestaurantRepository, userRepo repositories.UserRepository) RestaurantService {
+func NewRestaurantService(
+	restaurantRepo repositories.RestaurantRepository,
+	userRepo repositories.UserRepository,
+	orderRepo repositories.OrderRepository,
+	impactService ImpactService,
+	authorizer RestaurantAuthorizer,
+) RestaurantService {
 	return &restaurantService{
 		restaurantRepo: restaurantRepo,
 		userRepo:       userRepo,
+		orderRepo:      orderRepo,
+		impactService:  impactService,
+		authorizer:     authorizer,
 	}
 }
 
@@ -46,17 +65,67 @@ func (s *restaurantService) CreateRestaurant(restaurant *models.Restaurant, owne
 	}
 
 	restaurant.OwnerID = ownerID
+	if restaurant.Timezone == "" {
+		restaurant.Timezone = models.DefaultTimezone
+	}
 	return s.restaurantRepo.Create(restaurant)
 }
 
-// GetRestaurantByID retrieves a restaurant by ID
+// GetRestaurantByID retrieves a restaurant by ID with the public impact of its
+// completed orders. Suspended restaurants are hidden, as in public lists; their
+// owners still find them among their own restaurants.
 func (s *restaurantService) GetRestaurantByID(id uuid.UUID) (*models.Restaurant, error) {
-	return s.restaurantRepo.FindByID(id)
+	restaurant, err := s.restaurantRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if restaurant.IsSuspended() {
+		return nil, models.ErrNotFound
+	}
+	return s.presentRestaurant(restaurant)
+}
+
+// presentRestaurant computes a restaurant's open status, its listings' current
+// prices and the public impact of its completed orders
+func (s *restaurantService) presentRestaurant(restaurant *models.Restaurant) (*models.Restaurant, error) {
+	now := time.Now()
+	restaurant.SetOpenStatus(now)
+	for i := range restaurant.Listings {
+		restaurant.Listings[i].SetPricing(now)
+	}
+
+	impact, err := s.impactService.SumRestaurantImpact(restaurant.ID)
+	if err != nil {
+		return nil, err
+	}
+	restaurant.Impact = impact
+	return restaurant, nil
+}
+
+// GetRestaurantSavings totals a restaurant's completed orders, including what
+// customers paid, for admins and members who may view its orders
+func (s *restaurantService) GetRestaurantSavings(id uuid.UUID, requesterID uuid.UUID, requesterRole models.UserRole) (*models.SavingsSummary, error) {
+	if _, err := s.restaurantRepo.FindByID(id); err != nil {
+		return nil, err
+	}
+
+	if requesterRole != models.RoleAdmin {
+		if _, err := s.authorizer.Authorize(id, requesterID, models.PermViewOrders); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.orderRepo.SumSavingsByRestaurant(id)
 }
 
 // GetAllRestaurants retrieves all restaurants
 func (s *restaurantService) GetAllRestaurants() ([]models.Restaurant, error) {
-	return s.restaurantRepo.FindAll()
+	restaurants, err := s.restaurantRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	setOpenStatus(restaurants)
+	return restaurants, nil
 }
 
 // GetNearbyRestaurants retrieves restaurants within maxDistance (in km)
@@ -67,6 +136,8 @@ func (s *restaurantService) GetNearbyRestaurants(lat, lng, maxDistance float64)
 		return nil, err
 	}
 
+	setOpenStatus(restaurants)
+
 	// Calculate distances and filter
 	type restaurantWithDistance struct {
 		restaurant models.Restaurant
@@ -98,7 +169,108 @@ func (s *restaurantService) GetNearbyRestaurants(lat, lng, maxDistance float64)
 	return result, nil
 }
 
-// UpdateRestaurant updates a restaurant
-func (s *restaurantService) UpdateRestaurant(restaurant *models.Restaurant) error {
-	return s.restaurantRepo.Update(restaurant)
+// GetOwnedRestaurants retrieves all restaurants owned by a user, including suspended ones
+func (s *restaurantService) GetOwnedRestaurants(ownerID uuid.UUID) ([]models.Restaurant, error) {
+	restaurants, err := s.restaurantRepo.FindByOwnerID(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	setOpenStatus(restaurants)
+	return restaurants, nil
+}
+
+// UpdateRestaurant applies a partial update to a restaurant
+func (s *restaurantService) UpdateRestaurant(id uuid.UUID, update models.RestaurantUpdate, requesterID uuid.UUID) (*models.Restaurant, error) {
+	restaurant, err := s.restaurantRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.authorizer.Authorize(restaurant.ID, requesterID, models.PermManageRestaurant); err != nil {
+		return nil, err
+	}
+
+	update.Apply(restaurant)
+	if err := s.restaurantRepo.Update(restaurant); err != nil {
+		return nil, err
+	}
+
+	restaurant.SetOpenStatus(time.Now())
+	return restaurant, nil
+}
+
+// DeleteRestaurant soft-deletes a restaurant and deactivates its listings
+func (s *restaurantService) DeleteRestaurant(id uuid.UUID, requesterID uuid.UUID) error {
+	if _, err := s.restaurantRepo.FindByID(id); err != nil {
+		return err
+	}
+
+	if _, err := s.authorizer.Authorize(id, requesterID, models.PermDeleteRestaurant); err != nil {
+		return err
+	}
+
+	return s.restaurantRepo.Delete(id)
+}
+
+// SetOpeningHours replaces a restaurant's weekly schedule
+func (s *restaurantService) SetOpeningHours(id uuid.UUID, hours []models.OpeningHours, requesterID uuid.UUID) (*models.Restaurant, error) {
+	if _, err := s.restaurantRepo.FindByID(id); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.authorizer.Authorize(id, requesterID, models.PermManageRestaurant); err != nil {
+		return nil, err
+	}
+
+	if err := models.ValidateOpeningHours(hours); err != nil {
+		return nil, err
+	}
+
+	if err := s.restaurantRepo.ReplaceOpeningHours(id, hours); err != nil {
+		return nil, err
+	}
+
+	restaurant, err := s.restaurantRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.presentRestaurant(restaurant)
+}
+
+// AddClosure closes a restaurant for a range of dates
+func (s *restaurantService) AddClosure(closure *models.RestaurantClosure, requesterID uuid.UUID) error {
+	if closure.EndDate.Before(closure.StartDate.Time) {
+		return models.ErrInvalidInput
+	}
+
+	if _, err := s.restaurantRepo.FindByID(closure.RestaurantID); err != nil {
+		return err
+	}
+
+	if _, err := s.authorizer.Authorize(closure.RestaurantID, requesterID, models.PermManageRestaurant); err != nil {
+		return err
+	}
+
+	return s.restaurantRepo.CreateClosure(closure)
+}
+
+// RemoveClosure deletes a closure
+func (s *restaurantService) RemoveClosure(restaurantID uuid.UUID, closureID uuid.UUID, requesterID uuid.UUID) error {
+	if _, err := s.restaurantRepo.FindByID(restaurantID); err != nil {
+		return err
+	}
+
+	if _, err := s.authorizer.Authorize(restaurantID, requesterID, models.PermManageRestaurant); err != nil {
+		return err
+	}
+
+	return s.restaurantRepo.DeleteClosure(restaurantID, closureID)
+}
+
+// setOpenStatus computes the open status of each restaurant at the current time
+func setOpenStatus(restaurants []models.Restaurant) {
+	now := time.Now()
+	for i := range restaurants {
+		restaurants[i].SetOpenStatus(now)
+	}
 }
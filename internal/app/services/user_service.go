@@ -10,19 +10,22 @@ import (
 // UserService handles user-related business logic
 type UserService interface {
 	GetUserByID(id uuid.UUID) (*models.User, error)
+	GetProfile(id uuid.UUID) (*models.User, error)
 	GetUserByEmail(email string) (*models.User, error)
 	UpdateUser(user *models.User) error
 }
 
 // userService implements UserService
 type userService struct {
-	userRepo repositories.UserRepository
+	userRepo  repositories.UserRepository
+	orderRepo repositories.OrderRepository
 }
 
 // NewUserService creates a new user service
-func NewUserService(userRepo repositories.UserRepository) UserService {
+func NewUserService(userRepo repositories.UserRepository, orderRepo repositories.OrderRepository) UserService {
 	return &userService{
-		userRepo: userRepo,
+		userRepo:  userRepo,
+		orderRepo: orderRepo,
 	}
 }
 
@@ -31,6 +34,21 @@ func (s *userService) GetUserByID(id uuid.UUID) (*models.User, error) {
 	return s.userRepo.FindByID(id)
 }
 
+// GetProfile retrieves a user with their savings from completed orders
+func (s *userService) GetProfile(id uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	savings, err := s.orderRepo.SumSavingsByUser(id)
+	if err != nil {
+		return nil, err
+	}
+	user.Savings = savings
+	return user, nil
+}
+
 // GetUserByEmail retrieves a user by email
 func (s *userService) GetUserByEmail(email string) (*models.User, error) {
 	return s.userRepo.FindByEmail(email)
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"time"
+
+	"eatright-backend/internal/app/models"
+	"eatright-backend/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+// WaitlistService handles waitlist and back-in-stock subscription business logic
+type WaitlistService interface {
+	JoinWaitlist(userID, listingID uuid.UUID, qty int) (*models.WaitlistEntry, error)
+	LeaveWaitlist(userID, listingID uuid.UUID) error
+	Subscribe(userID, restaurantID uuid.UUID) (*models.RestaurantSubscription, error)
+	Unsubscribe(userID, restaurantID uuid.UUID) error
+	GetMyWaitlist(userID uuid.UUID) (*models.Waitlist, error)
+}
+
+// waitlistService implements WaitlistService
+type waitlistService struct {
+	waitlistRepo   repositories.WaitlistRepository
+	listingRepo    repositories.ListingRepository
+	restaurantRepo repositories.RestaurantRepository
+}
+
+// NewWaitlistService creates a new waitlist service
+func NewWaitlistService(
+	waitlistRepo repositories.WaitlistRepository,
+	listingRepo repositories.ListingRepository,
+	restaurantRepo repositories.RestaurantRepository,
+) WaitlistService {
+	return &waitlistService{
+		waitlistRepo:   waitlistRepo,
+		listingRepo:    listingRepo,
+		restaurantRepo: restaurantRepo,
+	}
+}
+
+// JoinWaitlist puts the user in line for qty units of a sold-out listing.
+// Joining again keeps the user's place and updates the quantity.
+func (s *waitlistService) JoinWaitlist(userID, listingID uuid.UUID, qty int) (*models.WaitlistEntry, error) {
+	if qty <= 0 {
+		return nil, models.ErrInvalidQuantity
+	}
+
+	listing, err := s.listingRepo.FindByID(listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !listing.IsActive {
+		return nil, models.ErrInvalidInput
+	}
+	if listing.Restaurant.IsSuspended() {
+		return nil, models.ErrRestaurantSuspended
+	}
+	if listing.IsPickupOver(time.Now()) {
+		return nil, models.ErrPickupWindowClosed
+	}
+	if listing.Stock > 0 {
+		return nil, models.ErrListingInStock
+	}
+	if listing.ExceedsMaxPerCustomer(0, qty) {
+		return nil, models.ErrListingLimitReached
+	}
+
+	entry := &models.WaitlistEntry{
+		UserID:    userID,
+		ListingID: listingID,
+		Qty:       qty,
+	}
+	if err := s.waitlistRepo.Join(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// LeaveWaitlist takes the user out of line for a listing
+func (s *waitlistService) LeaveWaitlist(userID, listingID uuid.UUID) error {
+	return s.waitlistRepo.Leave(userID, listingID)
+}
+
+// Subscribe notifies the user whenever one of the restaurant's sold-out
+// listings comes back in stock
+func (s *waitlistService) Subscribe(userID, restaurantID uuid.UUID) (*models.RestaurantSubscription, error) {
+	if _, err := s.restaurantRepo.FindByID(restaurantID); err != nil {
+		return nil, err
+	}
+
+	subscription := &models.RestaurantSubscription{
+		UserID:       userID,
+		RestaurantID: restaurantID,
+	}
+	if err := s.waitlistRepo.Subscribe(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// Unsubscribe stops the user's back-in-stock notifications for a restaurant
+func (s *waitlistService) Unsubscribe(userID, restaurantID uuid.UUID) error {
+	return s.waitlistRepo.Unsubscribe(userID, restaurantID)
+}
+
+// GetMyWaitlist retrieves the listings the user waits for and the restaurants
+// they subscribe to
+func (s *waitlistService) GetMyWaitlist(userID uuid.UUID) (*models.Waitlist, error) {
+	waitlist, err := s.waitlistRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range waitlist.Listings {
+		waitlist.Listings[i].Listing.LocalizePickup()
+		waitlist.Listings[i].Listing.SetPricing(now)
+	}
+	return waitlist, nil
+}
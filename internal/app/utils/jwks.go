@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// minJWKSRefreshInterval limits how often an unknown key ID can trigger a refetch
+const minJWKSRefreshInterval = 30 * time.Second
+
+// ErrJWKSUnavailable is returned when the key set cannot be loaded and the
+// requested key is not cached, e.g. during an identity provider outage
+var ErrJWKSUnavailable = errors.New("jwks source is unavailable")
+
+// JWK represents a single JSON Web Key
+type JWK struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// JWKSet represents a JSON Web Key Set document
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKSProvider loads public keys from a JWKS document and caches them.
+// Keys are reloaded when the cache expires or when a token references
+// a key ID that is not cached yet (key rotation).
+type JWKSProvider struct {
+	url    string
+	file   string
+	ttl    time.Duration
+	client *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]interface{}
+	fetchedAt   time.Time
+	lastAttempt time.Time
+	lastErr     error // Error of the last reload, nil once it succeeded
+}
+
+// NewJWKSProvider creates a JWKS provider reading from a URL or a local file
+func NewJWKSProvider(url, file string, ttl time.Duration) *JWKSProvider {
+	return &JWKSProvider{
+		url:    url,
+		file:   file,
+		ttl:    ttl,
+		client: &http.Client{Timeout: 5 * time.Second},
+		keys:   make(map[string]interface{}),
+	}
+}
+
+// Refresh reloads the key set from its source
+func (p *JWKSProvider) Refresh() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.refreshLocked()
+}
+
+// Key returns the public key for the given key ID. It returns an error
+// wrapping ErrJWKSUnavailable when the key set could not be loaded.
+func (p *JWKSProvider) Key(kid string) (interface{}, error) {
+	p.mu.RLock()
+	key, ok := p.keys[kid]
+	stale := time.Since(p.fetchedAt) > p.ttl
+	p.mu.RUnlock()
+
+	if ok && !stale {
+		return key, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Another goroutine may have refreshed while we waited for the lock
+	if key, ok := p.keys[kid]; ok && time.Since(p.fetchedAt) <= p.ttl {
+		return key, nil
+	}
+
+	if time.Since(p.lastAttempt) >= minJWKSRefreshInterval {
+		p.refreshLocked()
+	}
+
+	key, ok = p.keys[kid]
+	if ok {
+		// Keep serving cached keys if the source is temporarily unavailable
+		return key, nil
+	}
+	if p.lastErr != nil {
+		return nil, fmt.Errorf("%w: %v", ErrJWKSUnavailable, p.lastErr)
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// refreshLocked reloads the key set and records the outcome in lastErr; the
+// caller must hold the write lock
+func (p *JWKSProvider) refreshLocked() error {
+	p.lastAttempt = time.Now()
+	p.lastErr = p.reloadLocked()
+	return p.lastErr
+}
+
+// reloadLocked replaces the cached keys with the current key set
+func (p *JWKSProvider) reloadLocked() error {
+	data, err := p.load()
+	if err != nil {
+		return err
+	}
+
+	var set JWKSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("failed to decode jwks: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			log.Printf("⚠️  Skipping jwk %q: %v", jwk.Kid, err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	p.keys = keys
+	p.fetchedAt = time.Now()
+	return nil
+}
+
+// load reads the raw JWKS document from the configured source
+func (p *JWKSProvider) load() ([]byte, error) {
+	if p.file != "" {
+		data, err := os.ReadFile(p.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwks file: %w", err)
+		}
+		return data, nil
+	}
+
+	resp, err := p.client.Get(p.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks: %w", err)
+	}
+	return data, nil
+}
+
+// PublicKey converts the JWK into an *rsa.PublicKey or *ecdsa.PublicKey
+func (k JWK) PublicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBase64URLInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decodeBase64URLInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBase64URLInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %w", err)
+		}
+		y, err := decodeBase64URLInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %w", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// decodeBase64URLInt decodes a base64url-encoded big-endian integer
+func decodeBase64URLInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -1,6 +1,10 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -18,8 +22,9 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
-// GenerateToken generates a new JWT token for a user
-func GenerateToken(user *models.User, secret string, expiry time.Duration) (string, error) {
+// GenerateToken generates a new JWT access token for a user.
+// tokenID becomes the jti claim and is used to revoke the token.
+func GenerateToken(user *models.User, tokenID uuid.UUID, secret string, expiry time.Duration) (string, error) {
 	claims := &Claims{
 		UserID: user.ID,
 		Email:  user.Email,
@@ -30,6 +35,7 @@ func GenerateToken(user *models.User, secret string, expiry time.Duration) (stri
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    "eatright-backend",
 			Subject:   user.ID.String(),
+			ID:        tokenID.String(),
 		},
 	}
 
@@ -64,6 +70,21 @@ func ValidateToken(tokenString string, secret string) (*Claims, error) {
 	return claims, nil
 }
 
+// GenerateRefreshToken generates a random opaque refresh token
+func GenerateRefreshToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashToken returns the SHA-256 hex digest used to store opaque tokens
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 // ExtractToken extracts the token from Authorization header
 func ExtractToken(authHeader string) (string, error) {
 	if authHeader == "" {
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"eatright-backend/internal/app/models"
+
+	"github.com/google/uuid"
+)
+
+// pickupCodeAlphabet omits characters that are easily confused when read aloud or typed (0/O, 1/I/L)
+const pickupCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// pickupCodeLength is the number of characters in a pickup code
+const pickupCodeLength = 6
+
+// pickupPayloadPrefix versions the QR payload format
+const pickupPayloadPrefix = "ER1"
+
+// GeneratePickupCode generates a random human-readable pickup code
+func GeneratePickupCode() (string, error) {
+	buf := make([]byte, pickupCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate pickup code: %w", err)
+	}
+	code := make([]byte, pickupCodeLength)
+	for i, b := range buf {
+		// 256 is not a multiple of the alphabet size; the slight bias is harmless here
+		code[i] = pickupCodeAlphabet[int(b)%len(pickupCodeAlphabet)]
+	}
+	return string(code), nil
+}
+
+// NormalizePickupCode uppercases a typed pickup code and strips spaces and dashes
+func NormalizePickupCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
+
+// SignPickupPayload builds the QR payload for an order's pickup code:
+// "ER1.<order id>.<code>.<signature>"
+func SignPickupPayload(orderID uuid.UUID, code string, secret string) string {
+	message := pickupPayloadPrefix + "." + orderID.String() + "." + code
+	return message + "." + pickupSignature(message, secret)
+}
+
+// ParsePickupPayload verifies a QR payload and returns the order ID and pickup code
+func ParsePickupPayload(payload string, secret string) (uuid.UUID, string, error) {
+	parts := strings.Split(strings.TrimSpace(payload), ".")
+	if len(parts) != 4 || parts[0] != pickupPayloadPrefix {
+		return uuid.Nil, "", models.ErrInvalidPickupCode
+	}
+
+	message := strings.Join(parts[:3], ".")
+	if !hmac.Equal([]byte(parts[3]), []byte(pickupSignature(message, secret))) {
+		return uuid.Nil, "", models.ErrInvalidPickupCode
+	}
+
+	orderID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return uuid.Nil, "", models.ErrInvalidPickupCode
+	}
+	return orderID, parts[2], nil
+}
+
+// pickupSignature returns the base64url HMAC-SHA256 of message
+func pickupSignature(message string, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -10,6 +10,14 @@ type Response struct {
 	Error   string      `json:"error,omitempty"`
 }
 
+// PaginatedData wraps a page of results with paging information
+type PaginatedData struct {
+	Items interface{} `json:"items"`
+	Total int64       `json:"total"`
+	Page  int         `json:"page"`
+	Limit int         `json:"limit"`
+}
+
 // SuccessResponse sends a success response
 func SuccessResponse(c *fiber.Ctx, statusCode int, message string, data interface{}) error {
 	return c.Status(statusCode).JSON(Response{
@@ -0,0 +1,39 @@
+-- EatRight Refresh Tokens & Token Revocation
+-- Run this script in your Supabase SQL Editor after 001_create_tables.sql
+
+-- Refresh tokens table (only the SHA-256 hash of each token is stored)
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id UUID NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    access_jti UUID NOT NULL,
+    user_agent TEXT,
+    device_name VARCHAR(255),
+    ip_address VARCHAR(64),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    replaced_by UUID REFERENCES refresh_tokens(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for refresh tokens
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+-- Revoked access tokens (denylist keyed by the jti claim)
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for revoked tokens
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_user_id ON revoked_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
+
+-- Comments for documentation
+COMMENT ON TABLE refresh_tokens IS 'Rotating refresh tokens; tokens from one login share a family_id';
+COMMENT ON TABLE revoked_tokens IS 'Access tokens revoked before expiry (logout, refresh token reuse)';
+COMMENT ON COLUMN refresh_tokens.replaced_by IS 'Token issued when this one was rotated; reuse of a rotated token revokes the family';
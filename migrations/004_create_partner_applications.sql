@@ -0,0 +1,37 @@
+-- EatRight Restaurant Partner Onboarding
+-- Run this script in your Supabase SQL Editor after 003_create_auth_tokens.sql
+
+DO $$ BEGIN
+    CREATE TYPE partner_application_status AS ENUM ('pending', 'approved', 'rejected', 'withdrawn');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- Partner applications table
+CREATE TABLE IF NOT EXISTS partner_applications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    business_name VARCHAR(255) NOT NULL,
+    address TEXT NOT NULL,
+    phone VARCHAR(50),
+    documents JSONB NOT NULL DEFAULT '[]',
+    status partner_application_status NOT NULL DEFAULT 'pending',
+    review_note TEXT,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for partner applications
+CREATE INDEX IF NOT EXISTS idx_partner_applications_user_id ON partner_applications(user_id);
+CREATE INDEX IF NOT EXISTS idx_partner_applications_status ON partner_applications(status);
+
+-- At most one application per user can be under review
+CREATE UNIQUE INDEX IF NOT EXISTS idx_partner_applications_one_pending
+    ON partner_applications(user_id) WHERE status = 'pending';
+
+-- Comments for documentation
+COMMENT ON TABLE partner_applications IS 'Requests from users to become restaurant partners';
+COMMENT ON COLUMN partner_applications.documents IS 'Supporting document metadata: [{type, name, url}]';
+COMMENT ON COLUMN partner_applications.status IS 'pending -> approved | rejected | withdrawn';
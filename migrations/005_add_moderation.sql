@@ -0,0 +1,21 @@
+-- EatRight Platform Moderation
+-- Run this script in your Supabase SQL Editor after 004_create_partner_applications.sql
+
+-- Admin role moderates the platform and reviews partner applications
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'admin';
+
+-- Suspended users are rejected by the API even with a valid token
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspension_reason TEXT;
+
+-- Suspended restaurants are hidden and cannot take orders
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS suspension_reason TEXT;
+
+-- Create indexes for moderation filters
+CREATE INDEX IF NOT EXISTS idx_users_suspended_at ON users(suspended_at) WHERE suspended_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_restaurants_suspended_at ON restaurants(suspended_at) WHERE suspended_at IS NOT NULL;
+
+-- Comments for documentation
+COMMENT ON COLUMN users.suspended_at IS 'Set by an admin; NULL means the account is active';
+COMMENT ON COLUMN restaurants.suspended_at IS 'Set by an admin; NULL means the restaurant is visible';
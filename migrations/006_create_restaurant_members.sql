@@ -0,0 +1,45 @@
+-- EatRight Restaurant Membership
+-- Run this script in your Supabase SQL Editor after 005_add_moderation.sql
+
+-- Restaurant members table
+CREATE TABLE IF NOT EXISTS restaurant_members (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'manager', 'staff')),
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (restaurant_id, user_id)
+);
+
+-- Restaurant invitations table
+CREATE TABLE IF NOT EXISTS restaurant_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('manager', 'staff')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'revoked')),
+    invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for membership lookups
+CREATE INDEX IF NOT EXISTS idx_restaurant_members_user_id ON restaurant_members(user_id);
+CREATE INDEX IF NOT EXISTS idx_restaurant_invitations_restaurant_id ON restaurant_invitations(restaurant_id);
+CREATE INDEX IF NOT EXISTS idx_restaurant_invitations_email ON restaurant_invitations(email);
+
+-- Every restaurant has exactly one owner member
+CREATE UNIQUE INDEX IF NOT EXISTS idx_restaurant_members_one_owner
+    ON restaurant_members(restaurant_id) WHERE role = 'owner';
+
+-- Backfill owners of existing restaurants
+INSERT INTO restaurant_members (restaurant_id, user_id, role)
+SELECT id, owner_id, 'owner' FROM restaurants
+ON CONFLICT (restaurant_id, user_id) DO NOTHING;
+
+-- Comments for documentation
+COMMENT ON TABLE restaurant_members IS 'Users allowed to act on behalf of a restaurant';
+COMMENT ON COLUMN restaurant_members.role IS 'owner > manager > staff; permissions are enforced by the API';
+COMMENT ON TABLE restaurant_invitations IS 'Email invitations to join a restaurant, valid for 7 days';
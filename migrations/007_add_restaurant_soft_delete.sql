@@ -0,0 +1,11 @@
+-- EatRight Restaurant Soft Delete
+-- Run this script in your Supabase SQL Editor after 006_create_restaurant_members.sql
+
+-- Deleted restaurants are kept for order history but hidden everywhere
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Create index for soft delete filter
+CREATE INDEX IF NOT EXISTS idx_restaurants_deleted_at ON restaurants(deleted_at);
+
+-- Comments for documentation
+COMMENT ON COLUMN restaurants.deleted_at IS 'Set when the owner deletes the restaurant; its listings are deactivated';
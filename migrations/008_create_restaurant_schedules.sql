@@ -0,0 +1,34 @@
+-- EatRight Restaurant Opening Hours
+-- Run this script in your Supabase SQL Editor after 007_add_restaurant_soft_delete.sql
+
+-- Schedules are interpreted in the restaurant's own timezone
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta';
+
+-- Weekly opening hours table (several intervals per day allowed)
+CREATE TABLE IF NOT EXISTS restaurant_opening_hours (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    opens_at TIME NOT NULL,
+    closes_at TIME NOT NULL
+);
+
+-- Date-range closures table (holidays, temporary closures)
+CREATE TABLE IF NOT EXISTS restaurant_closures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_date >= start_date)
+);
+
+-- Create indexes for schedule lookups
+CREATE INDEX IF NOT EXISTS idx_restaurant_opening_hours_restaurant_id ON restaurant_opening_hours(restaurant_id);
+CREATE INDEX IF NOT EXISTS idx_restaurant_closures_restaurant_id ON restaurant_closures(restaurant_id, end_date);
+
+-- Comments for documentation
+COMMENT ON COLUMN restaurants.timezone IS 'IANA timezone name used for opening hours and pickup times';
+COMMENT ON TABLE restaurant_opening_hours IS 'Weekly schedule; weekday 0 = Sunday. closes_at <= opens_at means the interval ends after midnight';
+COMMENT ON TABLE restaurant_closures IS 'Inclusive date ranges when the restaurant is closed regardless of the weekly schedule';
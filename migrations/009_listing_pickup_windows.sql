@@ -0,0 +1,37 @@
+-- EatRight Listing Pickup Windows
+-- Run this script in your Supabase SQL Editor after 008_create_restaurant_schedules.sql
+
+-- Pickup windows replace the date-less pickup_time
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS pickup_start TIMESTAMP WITH TIME ZONE;
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS pickup_end TIMESTAMP WITH TIME ZONE;
+
+-- Backfill: pickup_time on the day the listing was created, in the restaurant's timezone,
+-- ending at closing time (or one hour later when closing time is not after pickup time)
+DO $$ BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'listings' AND column_name = 'pickup_time'
+    ) THEN
+        UPDATE listings l SET
+            pickup_start = (((l.created_at AT TIME ZONE r.timezone)::date + l.pickup_time) AT TIME ZONE r.timezone),
+            pickup_end = CASE
+                WHEN r.closing_time > l.pickup_time
+                    THEN (((l.created_at AT TIME ZONE r.timezone)::date + r.closing_time) AT TIME ZONE r.timezone)
+                ELSE (((l.created_at AT TIME ZONE r.timezone)::date + l.pickup_time) AT TIME ZONE r.timezone) + INTERVAL '1 hour'
+            END
+        FROM restaurants r
+        WHERE r.id = l.restaurant_id AND l.pickup_start IS NULL;
+
+        ALTER TABLE listings DROP COLUMN pickup_time;
+    END IF;
+END $$;
+
+ALTER TABLE listings ALTER COLUMN pickup_start SET NOT NULL;
+ALTER TABLE listings ALTER COLUMN pickup_end SET NOT NULL;
+
+-- Create index for filtering listings whose window has not ended
+CREATE INDEX IF NOT EXISTS idx_listings_pickup_end ON listings(pickup_end);
+
+-- Comments for documentation
+COMMENT ON COLUMN listings.pickup_start IS 'Start of the pickup window; must fall within opening hours';
+COMMENT ON COLUMN listings.pickup_end IS 'End of the pickup window; orders are refused afterwards';
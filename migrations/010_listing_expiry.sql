@@ -0,0 +1,15 @@
+-- EatRight Automatic Listing Expiry
+-- Run this script in your Supabase SQL Editor after 009_listing_pickup_windows.sql
+
+-- Orders not picked up before the pickup window ended
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'no_show';
+
+-- Record why a listing stopped being active
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS deactivation_reason VARCHAR(30);
+
+-- Create index for the expiry job
+CREATE INDEX IF NOT EXISTS idx_listings_active_pickup_end ON listings(pickup_end) WHERE is_active = TRUE;
+
+-- Comments for documentation
+COMMENT ON COLUMN listings.deactivation_reason IS 'manual | admin | pickup_ended | restaurant_closed | restaurant_deleted';
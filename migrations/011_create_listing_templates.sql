@@ -0,0 +1,48 @@
+-- EatRight Recurring Listing Templates
+-- Run this script in your Supabase SQL Editor after 010_listing_expiry.sql
+
+-- Listing templates table
+CREATE TABLE IF NOT EXISTS listing_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    type listing_type NOT NULL,
+    name VARCHAR(255),
+    description TEXT NOT NULL,
+    price INTEGER NOT NULL CHECK (price >= 0),
+    stock INTEGER NOT NULL CHECK (stock >= 0),
+    photo_url TEXT,
+    weekdays SMALLINT NOT NULL CHECK (weekdays BETWEEN 1 AND 127),
+    pickup_start TIME NOT NULL,
+    pickup_end TIME NOT NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Per-date overrides table
+CREATE TABLE IF NOT EXISTS listing_template_overrides (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    template_id UUID NOT NULL REFERENCES listing_templates(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    skip BOOLEAN NOT NULL DEFAULT FALSE,
+    stock INTEGER CHECK (stock >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Link listings to the template occurrence they were created for
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS template_id UUID REFERENCES listing_templates(id) ON DELETE SET NULL;
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS occurrence_date DATE;
+
+-- Create indexes for template lookups
+CREATE INDEX IF NOT EXISTS idx_listing_templates_restaurant_id ON listing_templates(restaurant_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_listing_template_overrides_date ON listing_template_overrides(template_id, date);
+-- At most one listing per template and date, even with several job runners
+CREATE UNIQUE INDEX IF NOT EXISTS idx_listings_template_occurrence ON listings(template_id, occurrence_date);
+
+-- Comments for documentation
+COMMENT ON COLUMN listing_templates.weekdays IS 'Bitmask of weekdays; bit 0 = Sunday';
+COMMENT ON COLUMN listing_templates.pickup_start IS 'Restaurant local time; pickup_end <= pickup_start means the window ends after midnight';
+COMMENT ON COLUMN listing_templates.stock IS 'Default stock for each occurrence';
+COMMENT ON TABLE listing_template_overrides IS 'Skips or changes the stock of a single template occurrence';
+COMMENT ON COLUMN listings.occurrence_date IS 'Restaurant local date of the template occurrence this listing was created for';
@@ -0,0 +1,17 @@
+-- EatRight Markdown Pricing
+-- Run this script in your Supabase SQL Editor after 011_create_listing_templates.sql
+
+-- Optional markdown schedule, e.g. {"steps":[{"minutes_before_end":120,"percent_off":30}],"floor_price":10000}
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS price_schedule JSONB;
+ALTER TABLE listing_templates ADD COLUMN IF NOT EXISTS price_schedule JSONB;
+
+-- Record the unit price actually charged
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS unit_price INTEGER;
+UPDATE orders SET unit_price = total_price / qty WHERE unit_price IS NULL AND qty > 0;
+UPDATE orders SET unit_price = 0 WHERE unit_price IS NULL;
+ALTER TABLE orders ALTER COLUMN unit_price SET NOT NULL;
+
+-- Comments for documentation
+COMMENT ON COLUMN listings.price IS 'Original price in smallest currency unit (e.g., cents), before markdown';
+COMMENT ON COLUMN listings.price_schedule IS 'Markdown steps relative to pickup_end; the deepest step in effect applies, never below floor_price';
+COMMENT ON COLUMN orders.unit_price IS 'Price per item charged when the order was placed, after markdown';
@@ -0,0 +1,13 @@
+-- EatRight Original Value and Savings
+-- Run this script in your Supabase SQL Editor after 012_listing_markdown_pricing.sql
+
+-- Retail value of the food, shown next to the price
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS original_value INTEGER;
+ALTER TABLE listing_templates ADD COLUMN IF NOT EXISTS original_value INTEGER;
+
+-- What the customer saved, fixed when the order is placed
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS savings INTEGER NOT NULL DEFAULT 0;
+
+-- Comments for documentation
+COMMENT ON COLUMN listings.original_value IS 'Retail value in smallest currency unit; must be greater than price when set';
+COMMENT ON COLUMN orders.savings IS '(original_value, or price if unset, minus unit_price) * qty at order time';
@@ -0,0 +1,14 @@
+-- EatRight Impact Metrics
+-- Run this script in your Supabase SQL Editor after 013_listing_original_value.sql
+
+-- Record when an order was picked up, for impact time series
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP WITH TIME ZONE;
+
+-- Orders completed before this migration have no pickup time; use their creation time
+UPDATE orders SET completed_at = created_at WHERE status = 'completed' AND completed_at IS NULL;
+
+-- Create index for impact time series
+CREATE INDEX IF NOT EXISTS idx_orders_completed_at ON orders(completed_at) WHERE status = 'completed';
+
+-- Comments for documentation
+COMMENT ON COLUMN orders.completed_at IS 'When the order was marked completed (picked up)';
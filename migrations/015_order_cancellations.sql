@@ -0,0 +1,10 @@
+-- EatRight Order Cancellations
+-- Run this script in your Supabase SQL Editor after 014_order_completed_at.sql
+
+-- Record who cancelled an order and why
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancelled_by UUID REFERENCES users(id);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancellation_reason TEXT;
+
+-- Comments for documentation
+COMMENT ON COLUMN orders.cancelled_by IS 'User who cancelled: the customer, a restaurant member or an admin';
@@ -0,0 +1,34 @@
+-- EatRight Order Status Events
+-- Run this script in your Supabase SQL Editor after 015_order_cancellations.sql
+
+-- Timeline of order status transitions
+CREATE TABLE IF NOT EXISTS order_status_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(20) NOT NULL CHECK (actor IN ('customer', 'restaurant', 'admin', 'system')),
+    actor_id UUID REFERENCES users(id),
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_events_order_id ON order_status_events(order_id);
+
+-- Backfill the timeline of existing orders: placement, then the current status
+INSERT INTO order_status_events (order_id, from_status, to_status, actor, actor_id, created_at)
+SELECT o.id, NULL, 'pending', 'customer', o.user_id, o.created_at
+FROM orders o
+WHERE NOT EXISTS (SELECT 1 FROM order_status_events e WHERE e.order_id = o.id);
+
+INSERT INTO order_status_events (order_id, from_status, to_status, actor, actor_id, reason, created_at)
+SELECT o.id, 'pending', o.status, 'system', o.cancelled_by, o.cancellation_reason,
+       COALESCE(o.completed_at, o.cancelled_at, o.created_at)
+FROM orders o
+WHERE o.status <> 'pending'
+  AND NOT EXISTS (SELECT 1 FROM order_status_events e WHERE e.order_id = o.id AND e.from_status IS NOT NULL);
+
+-- Comments for documentation
+COMMENT ON TABLE order_status_events IS 'Every order status transition with who triggered it';
+COMMENT ON COLUMN order_status_events.from_status IS 'NULL for the event recording the order being placed';
+COMMENT ON COLUMN order_status_events.actor_id IS 'User who triggered the transition; NULL for system jobs';
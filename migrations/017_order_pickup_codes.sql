@@ -0,0 +1,17 @@
+-- EatRight Order Pickup Codes
+-- Run this script in your Supabase SQL Editor after 016_create_order_status_events.sql
+
+-- Code the customer shows at the counter, and brute-force protection for verifying it
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS pickup_code VARCHAR(12) NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS pickup_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS pickup_locked_until TIMESTAMP WITH TIME ZONE;
+
+-- Give open orders placed before this migration a code so they can still be collected
+UPDATE orders
+SET pickup_code = upper(substr(md5(random()::text || id::text), 1, 6))
+WHERE pickup_code = '' AND status IN ('pending', 'ready');
+
+-- Comments for documentation
+COMMENT ON COLUMN orders.pickup_code IS 'Shown only to the customer; restaurants complete the order by verifying it';
+COMMENT ON COLUMN orders.pickup_attempts IS 'Invalid pickup codes since the last lockout';
+COMMENT ON COLUMN orders.pickup_locked_until IS 'Pickup verification is refused until this time';
@@ -0,0 +1,73 @@
+-- EatRight Order Items and Carts
+-- Run this script in your Supabase SQL Editor after 017_order_pickup_codes.sql
+
+-- Line items: an order now holds one or more listings of one restaurant
+CREATE TABLE IF NOT EXISTS order_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    qty INTEGER NOT NULL CHECK (qty > 0),
+    unit_price INTEGER NOT NULL CHECK (unit_price >= 0),
+    total_price INTEGER NOT NULL CHECK (total_price >= 0),
+    savings INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items(order_id);
+CREATE INDEX IF NOT EXISTS idx_order_items_listing_id ON order_items(listing_id);
+
+-- Orders keep their restaurant and the pickup window all items have in common
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS restaurant_id UUID REFERENCES restaurants(id) ON DELETE CASCADE;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS pickup_start TIMESTAMP WITH TIME ZONE;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS pickup_end TIMESTAMP WITH TIME ZONE;
+
+-- Backfill: every existing order becomes a single item of its listing
+DO $$ BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'orders' AND column_name = 'listing_id'
+    ) THEN
+        INSERT INTO order_items (order_id, listing_id, qty, unit_price, total_price, savings)
+        SELECT o.id, o.listing_id, o.qty, o.unit_price, o.total_price, o.savings
+        FROM orders o
+        WHERE NOT EXISTS (SELECT 1 FROM order_items i WHERE i.order_id = o.id);
+
+        UPDATE orders o SET
+            restaurant_id = l.restaurant_id,
+            pickup_start = l.pickup_start,
+            pickup_end = l.pickup_end
+        FROM listings l
+        WHERE l.id = o.listing_id AND o.restaurant_id IS NULL;
+
+        ALTER TABLE orders DROP COLUMN listing_id;
+        ALTER TABLE orders DROP COLUMN unit_price;
+    END IF;
+END $$;
+
+ALTER TABLE orders ALTER COLUMN restaurant_id SET NOT NULL;
+ALTER TABLE orders ALTER COLUMN pickup_start SET NOT NULL;
+ALTER TABLE orders ALTER COLUMN pickup_end SET NOT NULL;
+
+-- Create indexes for the restaurant queue and the no-show job
+CREATE INDEX IF NOT EXISTS idx_orders_restaurant_id ON orders(restaurant_id);
+CREATE INDEX IF NOT EXISTS idx_orders_pickup_end ON orders(pickup_end);
+
+-- Carts: listings a customer intends to order together, from one restaurant
+CREATE TABLE IF NOT EXISTS cart_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    qty INTEGER NOT NULL CHECK (qty > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cart_items_user_listing ON cart_items(user_id, listing_id);
+
+-- Comments for documentation
+COMMENT ON TABLE order_items IS 'Listings and quantities of an order; stock is decremented for all of them in one transaction';
+COMMENT ON COLUMN order_items.unit_price IS 'Price per item charged when the order was placed, after markdown';
+COMMENT ON COLUMN order_items.savings IS '(original_value, or price if unset, minus unit_price) * qty at order time';
+COMMENT ON COLUMN orders.qty IS 'Total items across all order items';
+COMMENT ON COLUMN orders.pickup_start IS 'Latest pickup_start of the order''s listings';
+COMMENT ON COLUMN orders.pickup_end IS 'Earliest pickup_end of the order''s listings';
+COMMENT ON TABLE cart_items IS 'Customer carts; a cart holds listings from one restaurant and reserves no stock';
@@ -0,0 +1,22 @@
+-- EatRight Idempotency Keys
+-- Run this script in your Supabase SQL Editor after 018_create_order_items_and_carts.sql
+
+-- Responses of requests sent with an Idempotency-Key header, replayed on retry
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    response BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_keys_user_key ON idempotency_keys(user_id, key);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- Comments for documentation
+COMMENT ON TABLE idempotency_keys IS 'Per-user Idempotency-Key records; expired keys are purged by a background job';
+COMMENT ON COLUMN idempotency_keys.fingerprint IS 'SHA-256 of the method, path and body; a key cannot be reused for a different request';
+COMMENT ON COLUMN idempotency_keys.status_code IS 'Status of the stored response; 0 while the original request is in progress';
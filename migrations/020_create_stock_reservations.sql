@@ -0,0 +1,29 @@
+-- EatRight Stock Reservations
+-- Run this script in your Supabase SQL Editor after 019_create_idempotency_keys.sql
+
+-- Units of a listing held for a customer during checkout; held units are taken
+-- out of listings.stock until they are ordered, released or expire
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    qty INTEGER NOT NULL CHECK (qty > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'converted', 'released', 'expired')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- A customer holds at most one active reservation per listing
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_reservations_active_user_listing
+    ON stock_reservations(user_id, listing_id) WHERE status = 'active';
+
+-- Create indexes for the expiry job and listing lookups
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_active_expires_at
+    ON stock_reservations(expires_at) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_listing_id ON stock_reservations(listing_id);
+
+-- Comments for documentation
+COMMENT ON TABLE stock_reservations IS 'Short-lived stock holds; expired reservations are returned to stock by a background job';
+COMMENT ON COLUMN stock_reservations.qty IS 'Units taken out of listings.stock while the reservation is active';
+COMMENT ON COLUMN stock_reservations.order_id IS 'Order that used the reservation, set when status is converted';
@@ -0,0 +1,13 @@
+-- EatRight Purchase Limits
+-- Run this script in your Supabase SQL Editor after 020_create_stock_reservations.sql
+
+-- Fair distribution: optional caps on what one customer may order
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS max_per_customer INTEGER CHECK (max_per_customer > 0);
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS daily_limit_per_customer INTEGER CHECK (daily_limit_per_customer > 0);
+
+-- Create index for counting a customer's orders at a restaurant
+CREATE INDEX IF NOT EXISTS idx_orders_user_restaurant ON orders(user_id, restaurant_id);
+
+-- Comments for documentation
+COMMENT ON COLUMN listings.max_per_customer IS 'Most units one customer may hold across their non-cancelled orders; NULL for no limit';
+COMMENT ON COLUMN restaurants.daily_limit_per_customer IS 'Most items one customer may order for pickup on one local day across non-cancelled orders; NULL for no limit';
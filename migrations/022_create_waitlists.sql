@@ -0,0 +1,45 @@
+-- EatRight Waitlists and Back-in-Stock Subscriptions
+-- Run this script in your Supabase SQL Editor after 021_purchase_limits.sql
+
+-- Customers in line for a sold-out listing, notified in the order they joined
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    qty INTEGER NOT NULL DEFAULT 1 CHECK (qty > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'notified')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    notified_at TIMESTAMP WITH TIME ZONE,
+    reservation_id UUID REFERENCES stock_reservations(id) ON DELETE SET NULL
+);
+
+-- A customer waits at most once per listing
+CREATE UNIQUE INDEX IF NOT EXISTS idx_waitlist_entries_waiting_user_listing
+    ON waitlist_entries(user_id, listing_id) WHERE status = 'waiting';
+
+-- Create index for serving a listing's line in order
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_waiting_listing_created_at
+    ON waitlist_entries(listing_id, created_at) WHERE status = 'waiting';
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_user_id ON waitlist_entries(user_id);
+
+-- Customers notified whenever one of a restaurant's sold-out listings is back
+CREATE TABLE IF NOT EXISTS restaurant_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_restaurant_subscriptions_user_restaurant
+    ON restaurant_subscriptions(user_id, restaurant_id);
+CREATE INDEX IF NOT EXISTS idx_restaurant_subscriptions_restaurant_id ON restaurant_subscriptions(restaurant_id);
+
+-- Marks listings that went from sold out back into stock
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS restocked_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_listings_restocked_at ON listings(restocked_at) WHERE restocked_at IS NOT NULL;
+
+-- Comments for documentation
+COMMENT ON TABLE waitlist_entries IS 'Customers waiting for sold-out listings; served first come, first served by a background job';
+COMMENT ON COLUMN waitlist_entries.reservation_id IS 'Stock held for the customer when they were notified, if any';
+COMMENT ON TABLE restaurant_subscriptions IS 'Back-in-stock notifications for all of a restaurant''s listings';
+COMMENT ON COLUMN listings.restocked_at IS 'Set when stock goes from zero back up; cleared once the waitlist is notified';
@@ -0,0 +1,10 @@
+-- EatRight Awaiting Payment Orders
+-- Run this script in your Supabase SQL Editor after 022_create_waitlists.sql
+-- New enum values can only be used once committed, so this runs on its own
+-- before 024_create_payments.sql indexes orders by it
+
+-- Orders placed while payments are enabled wait for their payment to be captured
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'awaiting_payment';
+
+-- Comments for documentation
+COMMENT ON COLUMN orders.status IS 'awaiting_payment, pending, ready, completed, cancelled or no_show';
@@ -0,0 +1,29 @@
+-- EatRight Payments
+-- Run this script in your Supabase SQL Editor after 023_order_awaiting_payment.sql
+
+-- One payment per order through the payment provider; orders awaiting payment
+-- hold their stock until the payment is captured or PAYMENT_TIMEOUT passes
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+    provider VARCHAR(30) NOT NULL,
+    provider_intent_id VARCHAR(255) NOT NULL UNIQUE,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'requires_payment'
+        CHECK (status IN ('requires_payment', 'failed', 'captured', 'cancelled', 'refunded')),
+    failure_reason TEXT,
+    captured_at TIMESTAMP WITH TIME ZONE,
+    client_secret VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create index for the payment expiry job
+CREATE INDEX IF NOT EXISTS idx_orders_awaiting_payment_created_at
+    ON orders(created_at) WHERE status = 'awaiting_payment';
+
+-- Comments for documentation
+COMMENT ON TABLE payments IS 'Order payments; amounts are in the smallest currency unit';
+COMMENT ON COLUMN payments.provider_intent_id IS 'Payment intent ID at the provider, matched against webhooks';
+COMMENT ON COLUMN payments.client_secret IS 'Lets the customer''s client authorize the intent; only shown to the customer';
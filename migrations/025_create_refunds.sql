@@ -0,0 +1,53 @@
+-- EatRight Refunds
+-- Run this script in your Supabase SQL Editor after 024_create_payments.sql
+
+-- Refunds return all or part of a captured payment. Customer requests wait for
+-- the restaurant or an admin; refunds they issue, and the full refunds queued
+-- when a paid order is cancelled, are approved at once
+CREATE TABLE IF NOT EXISTS refunds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'requested'
+        CHECK (status IN ('requested', 'approved', 'rejected', 'succeeded', 'failed')),
+    requested_by VARCHAR(20) NOT NULL CHECK (requested_by IN ('customer', 'restaurant', 'admin', 'system')),
+    requester_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    decided_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    decision_note TEXT,
+    provider_refund_id VARCHAR(255),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    failure_reason TEXT,
+    refunded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Refunded amounts shown on orders and payments
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS refunded_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS refunded_amount INTEGER NOT NULL DEFAULT 0;
+
+-- Payments may now be partially refunded
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_status_check;
+ALTER TABLE payments ADD CONSTRAINT payments_status_check
+    CHECK (status IN ('requires_payment', 'failed', 'captured', 'cancelled', 'partially_refunded', 'refunded'));
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_refunds_order_id ON refunds(order_id);
+CREATE INDEX IF NOT EXISTS idx_refunds_payment_id ON refunds(payment_id);
+
+-- Create index for the refund retry job
+CREATE INDEX IF NOT EXISTS idx_refunds_executable_created_at
+    ON refunds(created_at) WHERE status IN ('approved', 'failed');
+
+-- At most one customer request waits for a decision per order
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refunds_one_requested_per_order
+    ON refunds(order_id) WHERE status = 'requested';
+
+-- Comments for documentation
+COMMENT ON TABLE refunds IS 'Refunds of order payments; amounts are in the smallest currency unit';
+COMMENT ON COLUMN refunds.status IS 'requested (waiting for a decision), approved (queued for, or pending at, the provider), rejected, succeeded or failed (retried up to REFUND_MAX_ATTEMPTS, then by an admin)';
+COMMENT ON COLUMN refunds.id IS 'Also the idempotency key sent to the payment provider';
+COMMENT ON COLUMN orders.refunded_amount IS 'Returned to the customer by succeeded refunds';
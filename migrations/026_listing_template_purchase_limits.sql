@@ -0,0 +1,8 @@
+-- EatRight Listing Template Purchase Limits
+-- Run this script in your Supabase SQL Editor after 025_create_refunds.sql
+
+-- Templates carry the per-customer limit on to each listing they create
+ALTER TABLE listing_templates ADD COLUMN IF NOT EXISTS max_per_customer INTEGER CHECK (max_per_customer > 0);
+
+-- Comments for documentation
+COMMENT ON COLUMN listing_templates.max_per_customer IS 'Copied to listings.max_per_customer of each occurrence; NULL for no limit';